@@ -9,30 +9,186 @@ import (
 
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	ALLSATURATED        ErrorResponseErrorCode = "ALL_SATURATED"
+	MERGEPOLICYNOTMET   ErrorResponseErrorCode = "MERGE_POLICY_NOT_MET"
+	NOCANDIDATE         ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED         ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND            ErrorResponseErrorCode = "NOT_FOUND"
+	PRCLOSED            ErrorResponseErrorCode = "PR_CLOSED"
+	PRDRAFT             ErrorResponseErrorCode = "PR_DRAFT"
+	PREXISTS            ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED            ErrorResponseErrorCode = "PR_MERGED"
+	REVIEWERNOTELIGIBLE ErrorResponseErrorCode = "REVIEWER_NOT_ELIGIBLE"
+	TEAMEXISTS          ErrorResponseErrorCode = "TEAM_EXISTS"
+	UNKNOWNSTRATEGY     ErrorResponseErrorCode = "UNKNOWN_STRATEGY"
+)
+
+// Defines values for EventType.
+const (
+	EventTypeAPPROVED         EventType = "APPROVED"
+	EventTypeASSIGNED         EventType = "ASSIGNED"
+	EventTypeCHANGESREQUESTED EventType = "CHANGES_REQUESTED"
+	EventTypeCLOSED           EventType = "CLOSED"
+	EventTypeCOMMENTED        EventType = "COMMENTED"
+	EventTypeMERGED           EventType = "MERGED"
+	EventTypeREADYFORREVIEW   EventType = "READY_FOR_REVIEW"
+	EventTypeREOPENED         EventType = "REOPENED"
+	EventTypeSLABREACHED      EventType = "SLA_BREACHED"
+	EventTypeUNASSIGNED       EventType = "UNASSIGNED"
+)
+
+// Defines values for GitHubWebhookOutcome.
+const (
+	DUPLICATE GitHubWebhookOutcome = "DUPLICATE"
+	IGNORED   GitHubWebhookOutcome = "IGNORED"
+	PROCESSED GitHubWebhookOutcome = "PROCESSED"
+)
+
+// Defines values for LatencyRowGroup.
+const (
+	LatencyRowGroupReviewer LatencyRowGroup = "reviewer"
+	LatencyRowGroupTeam     LatencyRowGroup = "team"
+)
+
+// Defines values for LatencyRowMetric.
+const (
+	AssignmentToDecision LatencyRowMetric = "assignment_to_decision"
+	TimeToMerge          LatencyRowMetric = "time_to_merge"
+)
+
+// Defines values for PRAssignmentStatStatus.
+const (
+	PRAssignmentStatStatusCLOSED PRAssignmentStatStatus = "CLOSED"
+	PRAssignmentStatStatusDRAFT  PRAssignmentStatStatus = "DRAFT"
+	PRAssignmentStatStatusMERGED PRAssignmentStatStatus = "MERGED"
+	PRAssignmentStatStatusOPEN   PRAssignmentStatStatus = "OPEN"
 )
 
 // Defines values for PullRequestStatus.
 const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
+	PullRequestStatusDRAFT  PullRequestStatus = "DRAFT"
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
 )
 
+// Defines values for ReviewDecision.
+const (
+	ReviewDecisionAPPROVED         ReviewDecision = "APPROVED"
+	ReviewDecisionCHANGESREQUESTED ReviewDecision = "CHANGES_REQUESTED"
+	ReviewDecisionCOMMENTED        ReviewDecision = "COMMENTED"
+)
+
+// Defines values for SLAEscalation.
+const (
+	ADDREVIEWER SLAEscalation = "ADD_REVIEWER"
+	NOTIFY      SLAEscalation = "NOTIFY"
+	REASSIGN    SLAEscalation = "REASSIGN"
+)
+
+// Defines values for TimelineEntryStatus.
+const (
+	TimelineEntryStatusCLOSED TimelineEntryStatus = "CLOSED"
+	TimelineEntryStatusDRAFT  TimelineEntryStatus = "DRAFT"
+	TimelineEntryStatusMERGED TimelineEntryStatus = "MERGED"
+	TimelineEntryStatusOPEN   TimelineEntryStatus = "OPEN"
+)
+
+// Defines values for TimelineEntryType.
+const (
+	TimelineEntryTypeAPPROVED         TimelineEntryType = "APPROVED"
+	TimelineEntryTypeASSIGNED         TimelineEntryType = "ASSIGNED"
+	TimelineEntryTypeCHANGESREQUESTED TimelineEntryType = "CHANGES_REQUESTED"
+	TimelineEntryTypeCLOSED           TimelineEntryType = "CLOSED"
+	TimelineEntryTypeCOMMENTED        TimelineEntryType = "COMMENTED"
+	TimelineEntryTypeCREATED          TimelineEntryType = "CREATED"
+	TimelineEntryTypeMERGED           TimelineEntryType = "MERGED"
+	TimelineEntryTypeREADYFORREVIEW   TimelineEntryType = "READY_FOR_REVIEW"
+	TimelineEntryTypeREOPENED         TimelineEntryType = "REOPENED"
+	TimelineEntryTypeSLABREACHED      TimelineEntryType = "SLA_BREACHED"
+	TimelineEntryTypeUNASSIGNED       TimelineEntryType = "UNASSIGNED"
+)
+
+// Defines values for UnmetMergeConditionCode.
+const (
+	UnmetMergeConditionCodeAPPROVALSREQUIRED    UnmetMergeConditionCode = "APPROVALS_REQUIRED"
+	UnmetMergeConditionCodeCHANGESREQUESTED     UnmetMergeConditionCode = "CHANGES_REQUESTED"
+	UnmetMergeConditionCodeROLEAPPROVALREQUIRED UnmetMergeConditionCode = "ROLE_APPROVAL_REQUIRED"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	DELIVERED WebhookDeliveryStatus = "DELIVERED"
+	FAILED    WebhookDeliveryStatus = "FAILED"
+	PENDING   WebhookDeliveryStatus = "PENDING"
+)
+
+// Defines values for PostTeamSetSettingsJSONBodyReviewSlaEscalation.
+const (
+	PostTeamSetSettingsJSONBodyReviewSlaEscalationADDREVIEWER PostTeamSetSettingsJSONBodyReviewSlaEscalation = "ADD_REVIEWER"
+	PostTeamSetSettingsJSONBodyReviewSlaEscalationEmpty       PostTeamSetSettingsJSONBodyReviewSlaEscalation = ""
+	PostTeamSetSettingsJSONBodyReviewSlaEscalationNOTIFY      PostTeamSetSettingsJSONBodyReviewSlaEscalation = "NOTIFY"
+	PostTeamSetSettingsJSONBodyReviewSlaEscalationREASSIGN    PostTeamSetSettingsJSONBodyReviewSlaEscalation = "REASSIGN"
+)
+
+// Defines values for GetPullRequestListParamsSort.
+const (
+	MinusCreatedAt       GetPullRequestListParamsSort = "-created_at"
+	MinusPullRequestName GetPullRequestListParamsSort = "-pull_request_name"
+	CreatedAt            GetPullRequestListParamsSort = "created_at"
+	PullRequestName      GetPullRequestListParamsSort = "pull_request_name"
+)
+
+// Defines values for GetStatsAssignmentsParamsSection.
+const (
+	BySource       GetStatsAssignmentsParamsSection = "by_source"
+	PerPullRequest GetStatsAssignmentsParamsSection = "per_pull_request"
+	PerUser        GetStatsAssignmentsParamsSection = "per_user"
+)
+
+// Defines values for GetStatsAssignmentsParamsStatus.
+const (
+	GetStatsAssignmentsParamsStatusCLOSED GetStatsAssignmentsParamsStatus = "CLOSED"
+	GetStatsAssignmentsParamsStatusDRAFT  GetStatsAssignmentsParamsStatus = "DRAFT"
+	GetStatsAssignmentsParamsStatusMERGED GetStatsAssignmentsParamsStatus = "MERGED"
+	GetStatsAssignmentsParamsStatusOPEN   GetStatsAssignmentsParamsStatus = "OPEN"
+)
+
+// Defines values for GetUsersGetReviewParamsInclude.
+const (
+	Reviewers GetUsersGetReviewParamsInclude = "reviewers"
+)
+
 // Defines values for PullRequestShortStatus.
 const (
+	PullRequestShortStatusCLOSED PullRequestShortStatus = "CLOSED"
+	PullRequestShortStatusDRAFT  PullRequestShortStatus = "DRAFT"
 	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// AssignmentEvent defines model for AssignmentEvent.
+type AssignmentEvent struct {
+	CreatedAt     time.Time `json:"created_at"`
+	EventId       int64     `json:"event_id"`
+	EventType     EventType `json:"event_type"`
+	PullRequestId string    `json:"pull_request_id"`
+
+	// ReviewerId Отсутствует у событий, не относящихся к конкретному ревьюверу
+	ReviewerId *string `json:"reviewer_id,omitempty"`
+
+	// Source Источник события (AUTO, MANUAL, REASSIGN, GITHUB и т.д.)
+	Source string `json:"source"`
+
+	// TeamName Команда автора PR
+	TeamName string `json:"team_name"`
+}
+
 // AssignmentStats defines model for AssignmentStats.
 type AssignmentStats struct {
-	PerPullRequest *[]PRAssignmentStat   `json:"per_pull_request,omitempty"`
-	PerUser        *[]UserAssignmentStat `json:"per_user,omitempty"`
+	BySource       *[]SourceAssignmentStat `json:"by_source,omitempty"`
+	PerPullRequest *[]PRAssignmentStat     `json:"per_pull_request,omitempty"`
+	PerUser        *[]UserAssignmentStat   `json:"per_user,omitempty"`
 }
 
 // ErrorResponse defines model for ErrorResponse.
@@ -40,12 +196,34 @@ type ErrorResponse struct {
 	Error struct {
 		Code    ErrorResponseErrorCode `json:"code"`
 		Message string                 `json:"message"`
+
+		// UnmetConditions Невыполненные условия политики merge (только для MERGE_POLICY_NOT_MET)
+		UnmetConditions *[]UnmetMergeCondition `json:"unmet_conditions,omitempty"`
 	} `json:"error"`
 }
 
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// EventPage defines model for EventPage.
+type EventPage struct {
+	Events []AssignmentEvent `json:"events"`
+
+	// NextCursor Курсор следующей страницы; отсутствует на последней странице
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// EventType Тип события журнала назначений
+type EventType string
+
+// FairnessReport defines model for FairnessReport.
+type FairnessReport struct {
+	Teams []TeamFairness `json:"teams"`
+}
+
+// GitHubWebhookOutcome defines model for GitHubWebhookOutcome.
+type GitHubWebhookOutcome string
+
 // HealthResponse defines model for HealthResponse.
 type HealthResponse struct {
 	// Error Сообщение об ошибке, если сервис деградирован
@@ -55,6 +233,47 @@ type HealthResponse struct {
 	Status string `json:"status"`
 }
 
+// LatencyBreakdown defines model for LatencyBreakdown.
+type LatencyBreakdown struct {
+	PerReviewer []ReviewerLatency `json:"per_reviewer"`
+	PerTeam     []TeamLatency     `json:"per_team"`
+}
+
+// LatencyPercentiles defines model for LatencyPercentiles.
+type LatencyPercentiles struct {
+	// Count Количество измерений
+	Count      int64   `json:"count"`
+	P50Seconds float64 `json:"p50_seconds"`
+	P90Seconds float64 `json:"p90_seconds"`
+	P99Seconds float64 `json:"p99_seconds"`
+}
+
+// LatencyRow defines model for LatencyRow.
+type LatencyRow struct {
+	// Count Количество измерений
+	Count int64           `json:"count"`
+	Group LatencyRowGroup `json:"group"`
+
+	// Key Команда или идентификатор ревьювера
+	Key        string           `json:"key"`
+	Metric     LatencyRowMetric `json:"metric"`
+	P50Seconds float64          `json:"p50_seconds"`
+	P90Seconds float64          `json:"p90_seconds"`
+	P99Seconds float64          `json:"p99_seconds"`
+}
+
+// LatencyRowGroup defines model for LatencyRow.Group.
+type LatencyRowGroup string
+
+// LatencyRowMetric defines model for LatencyRow.Metric.
+type LatencyRowMetric string
+
+// LatencyStats defines model for LatencyStats.
+type LatencyStats struct {
+	AssignmentToDecision LatencyBreakdown `json:"assignment_to_decision"`
+	TimeToMerge          LatencyBreakdown `json:"time_to_merge"`
+}
+
 // MassDeactivateRequest defines model for MassDeactivateRequest.
 type MassDeactivateRequest struct {
 	// TeamName Имя команды, в которой необходимо отключить пользователей
@@ -71,63 +290,264 @@ type MassDeactivateResult struct {
 	// Reassignments Список PR, где произошла переассайнация, сгруппированный по user_id
 	Reassignments *map[string][]string `json:"reassignments,omitempty"`
 
-	// Skipped Пользователи, которых не удалось обработать, с указанием причины
+	// RemovedWithoutReplacement Список PR, где ревьювер снят без замены, сгруппированный по user_id: на PR осталось достаточно ревьюверов, все кандидаты достигли лимита открытых ревью или кандидатов нет (тогда пользователь также попадает в skipped)
+	RemovedWithoutReplacement *map[string][]string `json:"removed_without_replacement,omitempty"`
+
+	// Skipped Пользователи, для чьих PR не нашлось замены ни в команде, ни в резервных командах, с причиной
 	Skipped *map[string]string `json:"skipped,omitempty"`
 }
 
+// MemberShare defines model for MemberShare.
+type MemberShare struct {
+	Assigned int64 `json:"assigned"`
+
+	// Delta Количество назначений минус справедливая доля
+	Delta    float64 `json:"delta"`
+	UserId   string  `json:"user_id"`
+	Username string  `json:"username"`
+}
+
+// MergePolicy defines model for MergePolicy.
+type MergePolicy struct {
+	// BlockOnChangesRequested Запрещать merge, пока у кого-то из ревьюверов последнее решение CHANGES_REQUESTED
+	BlockOnChangesRequested bool `json:"block_on_changes_requested"`
+
+	// RequiredApprovals Количество одобрений, необходимое для merge; 0 — одобрения не требуются
+	RequiredApprovals int `json:"required_approvals"`
+
+	// RequiredRole Роль, одобрение от обладателя которой обязательно; пустая строка — не требуется
+	RequiredRole string `json:"required_role"`
+}
+
 // PRAssignmentStat defines model for PRAssignmentStat.
 type PRAssignmentStat struct {
-	PullRequestId string `json:"pull_request_id"`
-	ReviewerCount int64  `json:"reviewer_count"`
+	PullRequestId string                 `json:"pull_request_id"`
+	ReviewerCount int64                  `json:"reviewer_count"`
+	Status        PRAssignmentStatStatus `json:"status"`
 }
 
+// PRAssignmentStatStatus defines model for PRAssignmentStat.Status.
+type PRAssignmentStatStatus string
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
-	// AssignedReviewers user_id назначенных ревьюверов (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
-	PullRequestId     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	Status            PullRequestStatus `json:"status"`
+	// AssignedReviewers user_id назначенных ревьюверов (0..required_reviewers)
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	AuthorId          string     `json:"author_id"`
+	CreatedAt         *time.Time `json:"createdAt"`
+
+	// Decisions Последние решения назначенных ревьюверов; ревьюверы без решения не перечисляются
+	Decisions *[]Review `json:"decisions,omitempty"`
+
+	// FallbackReviewers user_id ревьюверов из assigned_reviewers, назначенных из резервных команд
+	FallbackReviewers *[]string  `json:"fallback_reviewers,omitempty"`
+	MergedAt          *time.Time `json:"mergedAt"`
+	PullRequestId     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+
+	// Status DRAFT — черновик без ревьюверов; OPEN — ожидает ревью; MERGED — смержен; CLOSED — закрыт без merge, ревьюверы сняты
+	Status PullRequestStatus `json:"status"`
 }
 
 // PullRequestStatus defines model for PullRequest.Status.
 type PullRequestStatus string
 
+// PullRequestPage defines model for PullRequestPage.
+type PullRequestPage struct {
+	// NextCursor Курсор следующей страницы; отсутствует на последней странице
+	NextCursor   *string            `json:"next_cursor,omitempty"`
+	PullRequests []PullRequestShort `json:"pull_requests"`
+}
+
 // PullRequestShort defines model for PullRequestShort.
 type PullRequestShort struct {
-	AuthorId        string                 `json:"author_id"`
-	PullRequestId   string                 `json:"pull_request_id"`
-	PullRequestName string                 `json:"pull_request_name"`
-	Status          PullRequestShortStatus `json:"status"`
+	// AssignedReviewers Текущие ревьюверы PR; в /users/getReview только с include=reviewers
+	AssignedReviewers *[]string              `json:"assigned_reviewers,omitempty"`
+	AuthorId          string                 `json:"author_id"`
+	CreatedAt         *time.Time             `json:"createdAt,omitempty"`
+	MergedAt          *time.Time             `json:"mergedAt"`
+	PullRequestId     string                 `json:"pull_request_id"`
+	PullRequestName   string                 `json:"pull_request_name"`
+	Status            PullRequestShortStatus `json:"status"`
 }
 
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// PullRequestTimeline defines model for PullRequestTimeline.
+type PullRequestTimeline struct {
+	Pr       PullRequest     `json:"pr"`
+	Timeline []TimelineEntry `json:"timeline"`
+}
+
+// Review defines model for Review.
+type Review struct {
+	DecidedAt  time.Time      `json:"decided_at"`
+	Decision   ReviewDecision `json:"decision"`
+	ReviewerId string         `json:"reviewer_id"`
+}
+
+// ReviewAssignmentPage defines model for ReviewAssignmentPage.
+type ReviewAssignmentPage struct {
+	// NextCursor Курсор следующей страницы; отсутствует на последней странице
+	NextCursor   *string            `json:"next_cursor,omitempty"`
+	PullRequests []PullRequestShort `json:"pull_requests"`
+
+	// Total Количество PR под фильтром статусов на всех страницах
+	Total  int64  `json:"total"`
+	UserId string `json:"user_id"`
+}
+
+// ReviewDecision defines model for ReviewDecision.
+type ReviewDecision string
+
 // Team defines model for Team.
 type Team struct {
-	Members  []TeamMember `json:"members"`
-	TeamName string       `json:"team_name"`
+	Members []TeamMember `json:"members"`
+
+	// RequiredReviewers Количество ревьюверов, назначаемых на PR команды. Если не задано, используется значение из конфигурации.
+	RequiredReviewers *int   `json:"required_reviewers,omitempty"`
+	TeamName          string `json:"team_name"`
 }
 
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
-	IsActive bool   `json:"is_active"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive bool `json:"is_active"`
+
+	// Role Роль пользователя, учитываемая политикой merge
+	Role     *string `json:"role,omitempty"`
+	UserId   string  `json:"user_id"`
+	Username string  `json:"username"`
+}
+
+// ReviewerLatency defines model for ReviewerLatency.
+type ReviewerLatency struct {
+	// Count Количество измерений
+	Count      int64   `json:"count"`
+	P50Seconds float64 `json:"p50_seconds"`
+	P90Seconds float64 `json:"p90_seconds"`
+	P99Seconds float64 `json:"p99_seconds"`
+	ReviewerId string  `json:"reviewer_id"`
+}
+
+// ReviewSLA defines model for ReviewSLA.
+type ReviewSLA struct {
+	// Escalation Действие при нарушении SLA на ревью: NOTIFY — только записать событие SLA_BREACHED, REASSIGN — заменить просроченного ревьювера, ADD_REVIEWER — добавить ещё одного ревьювера. Если кандидатов для замены или добавления нет, выполняется NOTIFY.
+	Escalation SLAEscalation `json:"escalation"`
+
+	// Hours Время в часах, за которое назначенный ревьювер должен вынести решение; 0 — SLA не отслеживается
+	Hours int `json:"hours"`
 }
 
+// SourceAssignmentStat defines model for SourceAssignmentStat.
+type SourceAssignmentStat struct {
+	Assigned int64 `json:"assigned"`
+
+	// Reassigned Снятия, после которых в той же операции назначен другой ревьювер
+	Reassigned int64 `json:"reassigned"`
+
+	// Source Источник события (AUTO, MANUAL, REASSIGN, SLA_REASSIGN и т.д.)
+	Source     string `json:"source"`
+	Unassigned int64  `json:"unassigned"`
+}
+
+// SLAEscalation Действие при нарушении SLA на ревью: NOTIFY — только записать событие SLA_BREACHED, REASSIGN — заменить просроченного ревьювера, ADD_REVIEWER — добавить ещё одного ревьювера. Если кандидатов для замены или добавления нет, выполняется NOTIFY.
+type SLAEscalation string
+
+// TeamSettings defines model for TeamSettings.
+type TeamSettings struct {
+	// FallbackTeams Резервные команды в порядке обращения, если в команде нет активных кандидатов. Если для команды список не задан, возвращается значение из конфигурации.
+	FallbackTeams []string `json:"fallback_teams"`
+
+	// MaxOpenReviews Лимит открытых ревью на участника команды по умолчанию; 0 — без ограничения. Если для команды значение не задано, возвращается значение из конфигурации.
+	MaxOpenReviews int         `json:"max_open_reviews"`
+	MergePolicy    MergePolicy `json:"merge_policy"`
+
+	// RequiredReviewers Количество ревьюверов, назначаемых на PR команды. Если для команды значение не задано, возвращается значение из конфигурации.
+	RequiredReviewers int       `json:"required_reviewers"`
+	ReviewSla         ReviewSLA `json:"review_sla"`
+
+	// ReviewerStrategy Стратегия выбора ревьюверов: random, least_loaded, round_robin, weighted или зарегистрированная дополнительно. Если для команды стратегия не задана, возвращается значение из конфигурации.
+	ReviewerStrategy string `json:"reviewer_strategy"`
+	TeamName         string `json:"team_name"`
+}
+
+// TeamFairness defines model for TeamFairness.
+type TeamFairness struct {
+	ActiveMembers int `json:"active_members"`
+
+	// FairShare Назначений на участника при равном распределении
+	FairShare float64 `json:"fair_share"`
+
+	// Gini Коэффициент Джини от 0 (поровну) до (n-1)/n (все назначения у одного из n участников)
+	Gini float64 `json:"gini"`
+
+	// MaxMinRatio Отношение наибольшего числа назначений к наименьшему; отсутствует, если у кого-то назначений нет
+	MaxMinRatio      *float64     `json:"max_min_ratio,omitempty"`
+	MostAbove        *MemberShare `json:"most_above,omitempty"`
+	MostBelow        *MemberShare `json:"most_below,omitempty"`
+	TeamName         string       `json:"team_name"`
+	TotalAssignments int64        `json:"total_assignments"`
+}
+
+// TeamLatency defines model for TeamLatency.
+type TeamLatency struct {
+	// Count Количество измерений
+	Count      int64   `json:"count"`
+	P50Seconds float64 `json:"p50_seconds"`
+	P90Seconds float64 `json:"p90_seconds"`
+	P99Seconds float64 `json:"p99_seconds"`
+
+	// TeamName Команда автора PR
+	TeamName string `json:"team_name"`
+}
+
+// TimelineEntry defines model for TimelineEntry.
+type TimelineEntry struct {
+	// At Время записи; для MERGED — время merge PR
+	At time.Time `json:"at"`
+
+	// EventId Идентификатор события журнала назначений; отсутствует у записи о создании
+	EventId    *int64  `json:"event_id,omitempty"`
+	ReviewerId *string `json:"reviewer_id,omitempty"`
+
+	// Source Источник события (AUTO, MANUAL, REASSIGN, STATUS_CHANGE и т.д.)
+	Source *string `json:"source,omitempty"`
+
+	// Status Статус PR после записи; только для создания и смены статуса
+	Status *TimelineEntryStatus `json:"status,omitempty"`
+	Type   TimelineEntryType    `json:"type"`
+}
+
+// TimelineEntryStatus Статус PR после записи; только для создания и смены статуса
+type TimelineEntryStatus string
+
+// TimelineEntryType defines model for TimelineEntry.Type.
+type TimelineEntryType string
+
 // User defines model for User.
 type User struct {
-	IsActive bool   `json:"is_active"`
-	TeamName string `json:"team_name"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive bool `json:"is_active"`
+
+	// MaxOpenReviews Персональный лимит открытых ревью. Если не задан, действует лимит команды.
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+
+	// Role Роль пользователя, учитываемая политикой merge
+	Role     *string `json:"role,omitempty"`
+	TeamName string  `json:"team_name"`
+	UserId   string  `json:"user_id"`
+	Username string  `json:"username"`
 }
 
+// UnmetMergeCondition defines model for UnmetMergeCondition.
+type UnmetMergeCondition struct {
+	Code    UnmetMergeConditionCode `json:"code"`
+	Message string                  `json:"message"`
+}
+
+// UnmetMergeConditionCode defines model for UnmetMergeCondition.Code.
+type UnmetMergeConditionCode string
+
 // UserAssignmentStat defines model for UserAssignmentStat.
 type UserAssignmentStat struct {
 	ActivePullRequests int64  `json:"active_pull_requests"`
@@ -137,28 +557,236 @@ type UserAssignmentStat struct {
 	Username           string `json:"username"`
 }
 
+// Webhook defines model for Webhook.
+type Webhook struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// EventTypes Типы отправляемых событий; пустой список — все
+	EventTypes []EventType `json:"event_types"`
+	IsActive   bool        `json:"is_active"`
+
+	// Secret Ключ подписи HMAC-SHA256; возвращается только при создании
+	Secret *string `json:"secret,omitempty"`
+
+	// Teams Команды авторов PR, события которых отправляются; пустой список — все
+	Teams []string `json:"teams"`
+
+	// Url Адрес, на который отправляется POST с событием
+	Url       string `json:"url"`
+	WebhookId int64  `json:"webhook_id"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    int        `json:"attempts"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+	DeliveryId  int64      `json:"delivery_id"`
+	EventId     int64      `json:"event_id"`
+
+	// EventType Тип события журнала назначений
+	EventType EventType `json:"event_type"`
+
+	// LastError Ошибка последней неудачной попытки
+	LastError *string `json:"last_error,omitempty"`
+
+	// LastStatusCode HTTP-код последнего ответа получателя
+	LastStatusCode *int                  `json:"last_status_code,omitempty"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	WebhookId      int64                 `json:"webhook_id"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDeliveryStatus.
+type WebhookDeliveryStatus string
+
 // TeamNameQuery defines model for TeamNameQuery.
 type TeamNameQuery = string
 
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = string
 
+// GetEventsParams defines parameters for GetEvents.
+type GetEventsParams struct {
+	PullRequestId *string `form:"pull_request_id,omitempty" json:"pull_request_id,omitempty"`
+	ReviewerId    *string `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+
+	// TeamName Команда автора PR
+	TeamName  *string    `form:"team_name,omitempty" json:"team_name,omitempty"`
+	EventType *EventType `form:"event_type,omitempty" json:"event_type,omitempty"`
+	Source    *string    `form:"source,omitempty" json:"source,omitempty"`
+
+	// From Нижняя граница created_at включительно (RFC 3339)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Верхняя граница created_at не включительно (RFC 3339)
+	To     *time.Time `form:"to,omitempty" json:"to,omitempty"`
+	Cursor *string    `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Limit  *int       `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetEventsExportParams defines parameters for GetEventsExport.
+type GetEventsExportParams struct {
+	PullRequestId *string `form:"pull_request_id,omitempty" json:"pull_request_id,omitempty"`
+	ReviewerId    *string `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+
+	// TeamName Команда автора PR
+	TeamName  *string    `form:"team_name,omitempty" json:"team_name,omitempty"`
+	EventType *EventType `form:"event_type,omitempty" json:"event_type,omitempty"`
+	Source    *string    `form:"source,omitempty" json:"source,omitempty"`
+
+	// From Нижняя граница created_at включительно (RFC 3339)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Верхняя граница created_at не включительно (RFC 3339)
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// PostPullRequestAddReviewerJSONBody defines parameters for PostPullRequestAddReviewer.
+type PostPullRequestAddReviewerJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+
+	// ReviewerId Добавляемый ревьювер
+	ReviewerId *string `json:"reviewer_id,omitempty"`
+}
+
+// PostPullRequestCloseJSONBody defines parameters for PostPullRequestClose.
+type PostPullRequestCloseJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
-	AuthorId        string `json:"author_id"`
+	AuthorId string `json:"author_id"`
+
+	// Draft Создать черновик без ревьюверов
+	Draft           *bool  `json:"draft,omitempty"`
 	PullRequestId   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 }
 
+// GetPullRequestListParams defines parameters for GetPullRequestList.
+type GetPullRequestListParams struct {
+	// Status Статусы PR через запятую, например OPEN,DRAFT
+	Status   *string `form:"status,omitempty" json:"status,omitempty"`
+	AuthorId *string `form:"author_id,omitempty" json:"author_id,omitempty"`
+
+	// TeamName Команда автора PR
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// ReviewerId Текущий ревьювер PR; нельзя сочетать с without_reviewers
+	ReviewerId *string `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+
+	// WithoutReviewers Только PR без текущих ревьюверов
+	WithoutReviewers *bool `form:"without_reviewers,omitempty" json:"without_reviewers,omitempty"`
+
+	// CreatedFrom Нижняя граница createdAt включительно (RFC 3339)
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo Верхняя граница createdAt не включительно (RFC 3339)
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// MergedFrom Нижняя граница mergedAt включительно (RFC 3339)
+	MergedFrom *time.Time `form:"merged_from,omitempty" json:"merged_from,omitempty"`
+
+	// MergedTo Верхняя граница mergedAt не включительно (RFC 3339)
+	MergedTo *time.Time `form:"merged_to,omitempty" json:"merged_to,omitempty"`
+
+	// Q Подстрока названия PR
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Sort Поле сортировки; минус означает порядок по убыванию
+	Sort   *GetPullRequestListParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+	Cursor *string                       `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Limit  *int                          `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetPullRequestListParamsSort defines parameters for GetPullRequestList.
+type GetPullRequestListParamsSort string
+
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
 type PostPullRequestMergeJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestReadyJSONBody defines parameters for PostPullRequestReady.
+type PostPullRequestReadyJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
 // PostPullRequestReassignJSONBody defines parameters for PostPullRequestReassign.
 type PostPullRequestReassignJSONBody struct {
-	OldUserId     string `json:"old_user_id"`
+	// NewReviewerId Ревьювер, которому передаётся ревью
+	NewReviewerId *string `json:"new_reviewer_id,omitempty"`
+	OldUserId     string  `json:"old_user_id"`
+	PullRequestId string  `json:"pull_request_id"`
+}
+
+// PostPullRequestRemoveReviewerJSONBody defines parameters for PostPullRequestRemoveReviewer.
+type PostPullRequestRemoveReviewerJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
+	ReviewerId    string `json:"reviewer_id"`
+}
+
+// PostPullRequestReopenJSONBody defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestReviewJSONBody defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBody struct {
+	Decision      ReviewDecision `json:"decision"`
+	PullRequestId string         `json:"pull_request_id"`
+	ReviewerId    string         `json:"reviewer_id"`
+}
+
+// GetPullRequestTimelineParams defines parameters for GetPullRequestTimeline.
+type GetPullRequestTimelineParams struct {
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetStatsAssignmentsParams defines parameters for GetStatsAssignments.
+type GetStatsAssignmentsParams struct {
+	// Section Раздел для выгрузки в CSV и NDJSON
+	Section *GetStatsAssignmentsParamsSection `form:"section,omitempty" json:"section,omitempty"`
+
+	// From Начало интервала включительно (RFC 3339)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец интервала не включительно (RFC 3339)
+	To       *time.Time `form:"to,omitempty" json:"to,omitempty"`
+	TeamName *string    `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// Status Текущий статус PR
+	Status *GetStatsAssignmentsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetStatsAssignmentsParamsSection defines parameters for GetStatsAssignments.
+type GetStatsAssignmentsParamsSection string
+
+// GetStatsAssignmentsParamsStatus defines parameters for GetStatsAssignments.
+type GetStatsAssignmentsParamsStatus string
+
+// GetStatsFairnessParams defines parameters for GetStatsFairness.
+type GetStatsFairnessParams struct {
+	// From Начало интервала включительно (RFC 3339)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец интервала не включительно (RFC 3339)
+	To       *time.Time `form:"to,omitempty" json:"to,omitempty"`
+	TeamName *string    `form:"team_name,omitempty" json:"team_name,omitempty"`
+}
+
+// GetStatsLatencyParams defines parameters for GetStatsLatency.
+type GetStatsLatencyParams struct {
+	// From Начало интервала включительно (RFC 3339)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец интервала не включительно (RFC 3339)
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// TeamName Команда автора PR
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
 }
 
 // GetTeamGetParams defines parameters for GetTeamGet.
@@ -167,32 +795,181 @@ type GetTeamGetParams struct {
 	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
+// GetTeamGetSettingsParams defines parameters for GetTeamGetSettings.
+type GetTeamGetSettingsParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+}
+
+// PostTeamSetSettingsJSONBody defines parameters for PostTeamSetSettings.
+type PostTeamSetSettingsJSONBody struct {
+	// FallbackTeams Пустой список сбрасывает резервные команды к значению из конфигурации
+	FallbackTeams *[]string `json:"fallback_teams,omitempty"`
+
+	// MaxOpenReviews 0 сбрасывает лимит открытых ревью к значению из конфигурации
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+
+	// MergePolicy Отсутствующие поля политики не меняются
+	MergePolicy *struct {
+		BlockOnChangesRequested *bool `json:"block_on_changes_requested,omitempty"`
+
+		// RequiredApprovals 0 сбрасывает количество одобрений к значению из конфигурации
+		RequiredApprovals *int `json:"required_approvals,omitempty"`
+
+		// RequiredRole Пустая строка сбрасывает роль к значению из конфигурации
+		RequiredRole *string `json:"required_role,omitempty"`
+	} `json:"merge_policy,omitempty"`
+
+	// RequiredReviewers 0 сбрасывает количество ревьюверов к значению из конфигурации
+	RequiredReviewers *int `json:"required_reviewers,omitempty"`
+
+	// ReviewSla Отсутствующие поля SLA не меняются
+	ReviewSla *struct {
+		// Escalation Пустая строка сбрасывает действие к значению из конфигурации
+		Escalation *PostTeamSetSettingsJSONBodyReviewSlaEscalation `json:"escalation,omitempty"`
+
+		// Hours 0 сбрасывает SLA к значению из конфигурации
+		Hours *int `json:"hours,omitempty"`
+	} `json:"review_sla,omitempty"`
+
+	// ReviewerStrategy Пустая строка сбрасывает стратегию к значению из конфигурации
+	ReviewerStrategy *string `json:"reviewer_strategy,omitempty"`
+	TeamName         string  `json:"team_name"`
+}
+
+// PostTeamSetSettingsJSONBodyReviewSlaEscalation defines parameters for PostTeamSetSettings.
+type PostTeamSetSettingsJSONBodyReviewSlaEscalation string
+
 // GetUsersGetReviewParams defines parameters for GetUsersGetReview.
 type GetUsersGetReviewParams struct {
 	// UserId Идентификатор пользователя
 	UserId UserIdQuery `form:"user_id" json:"user_id"`
+
+	// Status Статусы PR через запятую, например OPEN,DRAFT
+	Status *string `form:"status,omitempty" json:"status,omitempty"`
+
+	// Include reviewers — добавить текущих ревьюверов каждого PR
+	Include *GetUsersGetReviewParamsInclude `form:"include,omitempty" json:"include,omitempty"`
+	Cursor  *string                         `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Limit   *int                            `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// GetUsersGetReviewParamsInclude defines parameters for GetUsersGetReview.
+type GetUsersGetReviewParamsInclude string
+
 // PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
 type PostUsersSetIsActiveJSONBody struct {
 	IsActive bool   `json:"is_active"`
 	UserId   string `json:"user_id"`
 }
 
+// PostUsersSetMaxOpenReviewsJSONBody defines parameters for PostUsersSetMaxOpenReviews.
+type PostUsersSetMaxOpenReviewsJSONBody struct {
+	// MaxOpenReviews 0 сбрасывает лимит к значению команды
+	MaxOpenReviews int    `json:"max_open_reviews"`
+	UserId         string `json:"user_id"`
+}
+
+// PostUsersSetRoleJSONBody defines parameters for PostUsersSetRole.
+type PostUsersSetRoleJSONBody struct {
+	// Role Пустая строка снимает роль
+	Role   string `json:"role"`
+	UserId string `json:"user_id"`
+}
+
+// PostWebhooksCreateJSONBody defines parameters for PostWebhooksCreate.
+type PostWebhooksCreateJSONBody struct {
+	EventTypes *[]EventType `json:"event_types,omitempty"`
+	IsActive   *bool        `json:"is_active,omitempty"`
+
+	// Secret Ключ подписи; если не задан, генерируется
+	Secret *string   `json:"secret,omitempty"`
+	Teams  *[]string `json:"teams,omitempty"`
+	Url    string    `json:"url"`
+}
+
+// PostWebhooksDeleteJSONBody defines parameters for PostWebhooksDelete.
+type PostWebhooksDeleteJSONBody struct {
+	WebhookId int64 `json:"webhook_id"`
+}
+
+// GetWebhooksDeliveriesParams defines parameters for GetWebhooksDeliveries.
+type GetWebhooksDeliveriesParams struct {
+	WebhookId *int64                 `form:"webhook_id,omitempty" json:"webhook_id,omitempty"`
+	Status    *WebhookDeliveryStatus `form:"status,omitempty" json:"status,omitempty"`
+	Limit     *int                   `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostWebhooksRedeliverJSONBody defines parameters for PostWebhooksRedeliver.
+type PostWebhooksRedeliverJSONBody struct {
+	DeliveryId int64 `json:"delivery_id"`
+}
+
+// PostWebhooksUpdateJSONBody defines parameters for PostWebhooksUpdate.
+type PostWebhooksUpdateJSONBody struct {
+	EventTypes *[]EventType `json:"event_types,omitempty"`
+	IsActive   *bool        `json:"is_active,omitempty"`
+
+	// Secret Новый ключ подписи; пустая строка оставляет прежний
+	Secret    *string   `json:"secret,omitempty"`
+	Teams     *[]string `json:"teams,omitempty"`
+	Url       *string   `json:"url,omitempty"`
+	WebhookId int64     `json:"webhook_id"`
+}
+
+// PostPullRequestAddReviewerJSONRequestBody defines body for PostPullRequestAddReviewer for application/json ContentType.
+type PostPullRequestAddReviewerJSONRequestBody PostPullRequestAddReviewerJSONBody
+
+// PostPullRequestCloseJSONRequestBody defines body for PostPullRequestClose for application/json ContentType.
+type PostPullRequestCloseJSONRequestBody PostPullRequestCloseJSONBody
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
 type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
 
 // PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
 type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
 
+// PostPullRequestReadyJSONRequestBody defines body for PostPullRequestReady for application/json ContentType.
+type PostPullRequestReadyJSONRequestBody PostPullRequestReadyJSONBody
+
 // PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
 type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
 
+// PostPullRequestRemoveReviewerJSONRequestBody defines body for PostPullRequestRemoveReviewer for application/json ContentType.
+type PostPullRequestRemoveReviewerJSONRequestBody PostPullRequestRemoveReviewerJSONBody
+
+// PostPullRequestReopenJSONRequestBody defines body for PostPullRequestReopen for application/json ContentType.
+type PostPullRequestReopenJSONRequestBody PostPullRequestReopenJSONBody
+
+// PostPullRequestReviewJSONRequestBody defines body for PostPullRequestReview for application/json ContentType.
+type PostPullRequestReviewJSONRequestBody PostPullRequestReviewJSONBody
+
 // PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
 type PostTeamAddJSONRequestBody = Team
 
 // PostTeamDeactivateJSONRequestBody defines body for PostTeamDeactivate for application/json ContentType.
 type PostTeamDeactivateJSONRequestBody = MassDeactivateRequest
 
+// PostTeamSetSettingsJSONRequestBody defines body for PostTeamSetSettings for application/json ContentType.
+type PostTeamSetSettingsJSONRequestBody PostTeamSetSettingsJSONBody
+
 // PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
 type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
+
+// PostUsersSetMaxOpenReviewsJSONRequestBody defines body for PostUsersSetMaxOpenReviews for application/json ContentType.
+type PostUsersSetMaxOpenReviewsJSONRequestBody PostUsersSetMaxOpenReviewsJSONBody
+
+// PostUsersSetRoleJSONRequestBody defines body for PostUsersSetRole for application/json ContentType.
+type PostUsersSetRoleJSONRequestBody PostUsersSetRoleJSONBody
+
+// PostWebhooksCreateJSONRequestBody defines body for PostWebhooksCreate for application/json ContentType.
+type PostWebhooksCreateJSONRequestBody PostWebhooksCreateJSONBody
+
+// PostWebhooksDeleteJSONRequestBody defines body for PostWebhooksDelete for application/json ContentType.
+type PostWebhooksDeleteJSONRequestBody PostWebhooksDeleteJSONBody
+
+// PostWebhooksRedeliverJSONRequestBody defines body for PostWebhooksRedeliver for application/json ContentType.
+type PostWebhooksRedeliverJSONRequestBody PostWebhooksRedeliverJSONBody
+
+// PostWebhooksUpdateJSONRequestBody defines body for PostWebhooksUpdate for application/json ContentType.
+type PostWebhooksUpdateJSONRequestBody PostWebhooksUpdateJSONBody
@@ -18,18 +18,23 @@ import (
 
 	"pr-reviewer-service_Avito/internal/config"
 	"pr-reviewer-service_Avito/internal/http/router"
+	"pr-reviewer-service_Avito/internal/infrastructure/codehost"
 	"pr-reviewer-service_Avito/internal/infrastructure/nower"
+	"pr-reviewer-service_Avito/internal/infrastructure/outbox"
 	"pr-reviewer-service_Avito/internal/infrastructure/randomizer"
+	"pr-reviewer-service_Avito/internal/infrastructure/webhook"
 	"pr-reviewer-service_Avito/internal/repository"
 	"pr-reviewer-service_Avito/internal/service"
 )
 
 // App отвечает за жизненный цикл сервиса.
 type App struct {
-	cfg    config.Config
-	server *http.Server
-	repo   *repository.Storage
-	trMgr  trm.Manager
+	cfg         config.Config
+	server      *http.Server
+	repo        *repository.Storage
+	svc         *service.Service
+	trMgr       trm.Manager
+	outboxSinks []outbox.Sink
 }
 
 // New подготавливает все зависимости приложения: БД, репозитории, сервисы, HTTP-роутер.
@@ -55,6 +60,12 @@ func New(ctx context.Context, cfg config.Config) (*App, error) {
 	repo := repository.New(pool, nowerImpl)
 	svc := service.New(repo, cfg, trMgr, randomizerImpl)
 
+	outboxSinks, err := newOutboxSinks(cfg, svc)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+
 	var swaggerSpec []byte
 	if data, err := os.ReadFile(cfg.Swagger.SpecPath); err != nil {
 		slog.Warn("failed to load swagger spec", "path", cfg.Swagger.SpecPath, "error", err)
@@ -72,10 +83,12 @@ func New(ctx context.Context, cfg config.Config) (*App, error) {
 	}
 
 	return &App{
-		cfg:    cfg,
-		server: srv,
-		repo:   repo,
-		trMgr:  trMgr,
+		cfg:         cfg,
+		server:      srv,
+		repo:        repo,
+		svc:         svc,
+		trMgr:       trMgr,
+		outboxSinks: outboxSinks,
 	}, nil
 }
 
@@ -88,6 +101,14 @@ func (a *App) Run(ctx context.Context) error {
 		}
 		close(errCh)
 	}()
+	// Фоновая проверка SLA на ревью останавливается вместе с ctx
+	go a.svc.RunSLAWorker(ctx, a.cfg.SLA.CheckInterval)
+	// Фоновая доставка исходящих webhook'ов
+	go a.svc.RunWebhookWorker(ctx, webhook.New(a.cfg.Webhooks.Timeout))
+	// Публикация transactional outbox в приёмники
+	go a.svc.RunOutboxRelay(ctx, a.outboxSinks)
+	// Обновление gauge'ей текущей нагрузки из БД
+	go a.svc.RunWorkloadMetricsWorker(ctx, a.cfg.Metrics.WorkloadRefreshInterval)
 
 	select {
 	case <-ctx.Done():
@@ -106,6 +127,29 @@ func (a *App) Run(ctx context.Context) error {
 	}
 }
 
+// newOutboxSinks создаёт приёмники outbox по именам из конфигурации.
+func newOutboxSinks(cfg config.Config, svc *service.Service) ([]outbox.Sink, error) {
+	sinks := make([]outbox.Sink, 0, len(cfg.Outbox.Sinks))
+	for _, name := range cfg.Outbox.Sinks {
+		switch name {
+		case outbox.SinkLog:
+			sinks = append(sinks, outbox.NewLogSink(slog.Default()))
+		case outbox.SinkGitHub:
+			if cfg.GitHub.Token == "" {
+				return nil, fmt.Errorf("outbox sink %q requires GITHUB_TOKEN", name)
+			}
+			client := codehost.NewGitHub(cfg.GitHub.APIURL, cfg.GitHub.Token, cfg.GitHub.Timeout, codehost.RetryPolicy{
+				MaxAttempts: cfg.GitHub.MaxAttempts,
+				Backoff:     cfg.GitHub.RetryBackoff,
+			})
+			sinks = append(sinks, svc.NewCodeHostSink(client))
+		default:
+			return nil, fmt.Errorf("unknown outbox sink %q", name)
+		}
+	}
+	return sinks, nil
+}
+
 func runMigrations(cfg config.Config) error {
 	m, err := migrate.New("file://"+cfg.Database.MigrationsPath, cfg.Database.URL)
 	if err != nil {
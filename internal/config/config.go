@@ -14,12 +14,18 @@ const defaultConfigPath = "config/config.yaml"
 
 // Config объединяет все аспекты настройки приложения.
 type Config struct {
-	HTTP      HTTPConfig     `yaml:"http"`
-	Database  DatabaseConfig `yaml:"database"`
-	Timeouts  TimeoutConfig  `yaml:"timeouts"`
-	Logging   LoggingConfig  `yaml:"logging"`
-	Swagger   SwaggerConfig  `yaml:"swagger"`
-	LoadTests LoadTestConfig `yaml:"load_tests"`
+	HTTP      HTTPConfig      `yaml:"http"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Timeouts  TimeoutConfig   `yaml:"timeouts"`
+	Logging   LoggingConfig   `yaml:"logging"`
+	Swagger   SwaggerConfig   `yaml:"swagger"`
+	LoadTests LoadTestConfig  `yaml:"load_tests"`
+	Reviewers ReviewersConfig `yaml:"reviewers"`
+	SLA       SLAConfig       `yaml:"sla"`
+	Webhooks  WebhooksConfig  `yaml:"webhooks"`
+	Outbox    OutboxConfig    `yaml:"outbox"`
+	GitHub    GitHubConfig    `yaml:"github"`
+	Metrics   MetricsConfig   `yaml:"metrics"`
 }
 
 // HTTPConfig описывает HTTP-сервер.
@@ -63,6 +69,100 @@ type LoadTestConfig struct {
 	TargetsPath string `yaml:"targets_path" env:"LOAD_TEST_TARGETS"`
 }
 
+// ReviewersConfig описывает политику выбора ревьюверов.
+type ReviewersConfig struct {
+	// DefaultStrategy используется для команд, у которых стратегия не задана.
+	DefaultStrategy string `yaml:"default_strategy" env:"REVIEWERS_DEFAULT_STRATEGY"`
+	// DefaultRequired — количество ревьюверов на PR для команд без собственной настройки.
+	DefaultRequired int `yaml:"default_required_reviewers" env:"REVIEWERS_DEFAULT_REQUIRED"`
+	// DefaultMaxOpenReviews — лимит открытых ревью на пользователя по умолчанию; 0 — без ограничения.
+	DefaultMaxOpenReviews int `yaml:"default_max_open_reviews" env:"REVIEWERS_DEFAULT_MAX_OPEN_REVIEWS"`
+	// Teams содержит настройки отдельных команд (ключ — team_name).
+	Teams map[string]TeamReviewersConfig `yaml:"teams"`
+}
+
+// TeamReviewersConfig задаёт настройки выбора ревьюверов для одной команды.
+type TeamReviewersConfig struct {
+	Strategy          string `yaml:"strategy"`
+	RequiredReviewers int    `yaml:"required_reviewers"`
+	// FallbackTeams — резервные команды в порядке обращения, если в команде нет кандидатов.
+	FallbackTeams []string `yaml:"fallback_teams"`
+	// MaxOpenReviews — лимит открытых ревью на участника команды; 0 — значение по умолчанию.
+	MaxOpenReviews int `yaml:"max_open_reviews"`
+	// MergePolicy — условия merge PR авторов команды; по умолчанию merge ничем не ограничен.
+	MergePolicy MergePolicyConfig `yaml:"merge_policy"`
+	// ReviewSLA — SLA на ревью PR авторов команды; по умолчанию не отслеживается.
+	ReviewSLA ReviewSLAConfig `yaml:"review_sla"`
+	// Weights используется стратегией weighted (ключ — user_id, по умолчанию вес 1).
+	Weights map[string]int `yaml:"weights"`
+}
+
+// MergePolicyConfig задаёт политику merge команды.
+type MergePolicyConfig struct {
+	RequiredApprovals       int    `yaml:"required_approvals"`
+	BlockOnChangesRequested bool   `yaml:"block_on_changes_requested"`
+	RequiredRole            string `yaml:"required_role"`
+}
+
+// ReviewSLAConfig задаёт SLA на ревью команды.
+type ReviewSLAConfig struct {
+	Hours int `yaml:"hours"`
+	// Escalation — NOTIFY, REASSIGN или ADD_REVIEWER; по умолчанию NOTIFY.
+	Escalation string `yaml:"escalation"`
+}
+
+// SLAConfig описывает фоновую проверку SLA на ревью.
+type SLAConfig struct {
+	CheckInterval time.Duration `yaml:"check_interval" env:"SLA_CHECK_INTERVAL"`
+}
+
+// WebhooksConfig описывает фоновую доставку исходящих webhook'ов.
+type WebhooksConfig struct {
+	// PollInterval — как часто искать доставки, которым пора выполнить попытку.
+	PollInterval time.Duration `yaml:"poll_interval" env:"WEBHOOKS_POLL_INTERVAL"`
+	// Timeout — таймаут одного HTTP-запроса к получателю.
+	Timeout time.Duration `yaml:"timeout" env:"WEBHOOKS_TIMEOUT"`
+	// MaxAttempts — количество попыток, после которого доставка считается неудачной.
+	MaxAttempts int `yaml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS"`
+	// BackoffBase и BackoffMax задают экспоненциальную задержку между попытками: base·2^(n-1), но не больше max.
+	BackoffBase time.Duration `yaml:"backoff_base" env:"WEBHOOKS_BACKOFF_BASE"`
+	BackoffMax  time.Duration `yaml:"backoff_max" env:"WEBHOOKS_BACKOFF_MAX"`
+	// BatchSize — сколько доставок обрабатывается за один проход.
+	BatchSize int `yaml:"batch_size" env:"WEBHOOKS_BATCH_SIZE"`
+}
+
+// OutboxConfig описывает relay transactional outbox.
+type OutboxConfig struct {
+	// PollInterval — как часто искать неопубликованные записи outbox.
+	PollInterval time.Duration `yaml:"poll_interval" env:"OUTBOX_POLL_INTERVAL"`
+	// BatchSize — сколько записей публикуется за одну транзакцию relay.
+	BatchSize int `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE"`
+	// Sinks — имена приёмников в порядке публикации; по умолчанию только log.
+	Sinks []string `yaml:"sinks" env:"OUTBOX_SINKS" envSeparator:","`
+}
+
+// GitHubConfig описывает приём webhook'ов GitHub.
+type GitHubConfig struct {
+	// WebhookSecret — секрет webhook'а в настройках репозитория; пока он не задан, все запросы отклоняются.
+	WebhookSecret string `yaml:"webhook_secret" env:"GITHUB_WEBHOOK_SECRET"`
+	// Users сопоставляет login GitHub с user_id сервиса; события пользователей без сопоставления игнорируются.
+	Users map[string]string `yaml:"users" env:"GITHUB_USERS" envSeparator:"," envKeyValSeparator:":"`
+	// APIURL и Token используются приёмником outbox github для записи назначений в PR.
+	APIURL string `yaml:"api_url" env:"GITHUB_API_URL"`
+	Token  string `yaml:"token" env:"GITHUB_TOKEN"`
+	// Timeout — таймаут одного запроса к API.
+	Timeout time.Duration `yaml:"timeout" env:"GITHUB_TIMEOUT"`
+	// MaxAttempts и RetryBackoff задают повторы при 429, 5xx и сетевых ошибках: задержка RetryBackoff·2^(n-1).
+	MaxAttempts  int           `yaml:"max_attempts" env:"GITHUB_MAX_ATTEMPTS"`
+	RetryBackoff time.Duration `yaml:"retry_backoff" env:"GITHUB_RETRY_BACKOFF"`
+}
+
+// MetricsConfig описывает фоновое обновление метрик текущей нагрузки.
+type MetricsConfig struct {
+	// WorkloadRefreshInterval — как часто пересчитывать открытые PR по командам и открытые ревью по ревьюверам.
+	WorkloadRefreshInterval time.Duration `yaml:"workload_refresh_interval" env:"METRICS_WORKLOAD_REFRESH_INTERVAL"`
+}
+
 // MustLoad загружает конфигурацию из YAML + ENV и паникует при ошибке.
 func MustLoad() Config {
 	cfg, err := Load()
@@ -145,4 +245,61 @@ func (c *Config) normalize() {
 	if c.Swagger.SpecPath == "" {
 		c.Swagger.SpecPath = "openapi.yml"
 	}
+	// Выбор ревьюверов
+	if c.Reviewers.DefaultStrategy == "" {
+		c.Reviewers.DefaultStrategy = "random"
+	}
+	if c.Reviewers.DefaultRequired <= 0 {
+		c.Reviewers.DefaultRequired = 2
+	}
+	// Проверка SLA на ревью
+	if c.SLA.CheckInterval <= 0 {
+		c.SLA.CheckInterval = 5 * time.Minute
+	}
+	// Исходящие webhook'и
+	if c.Webhooks.PollInterval <= 0 {
+		c.Webhooks.PollInterval = 5 * time.Second
+	}
+	if c.Webhooks.Timeout <= 0 {
+		c.Webhooks.Timeout = 5 * time.Second
+	}
+	if c.Webhooks.MaxAttempts <= 0 {
+		c.Webhooks.MaxAttempts = 8
+	}
+	if c.Webhooks.BackoffBase <= 0 {
+		c.Webhooks.BackoffBase = 10 * time.Second
+	}
+	if c.Webhooks.BackoffMax <= 0 {
+		c.Webhooks.BackoffMax = time.Hour
+	}
+	if c.Webhooks.BatchSize <= 0 {
+		c.Webhooks.BatchSize = 100
+	}
+	// Transactional outbox
+	if c.Outbox.PollInterval <= 0 {
+		c.Outbox.PollInterval = time.Second
+	}
+	if c.Outbox.BatchSize <= 0 {
+		c.Outbox.BatchSize = 100
+	}
+	if len(c.Outbox.Sinks) == 0 {
+		c.Outbox.Sinks = []string{"log"}
+	}
+	// Интеграция с GitHub
+	if c.GitHub.APIURL == "" {
+		c.GitHub.APIURL = "https://api.github.com"
+	}
+	if c.GitHub.Timeout <= 0 {
+		c.GitHub.Timeout = 10 * time.Second
+	}
+	if c.GitHub.MaxAttempts <= 0 {
+		c.GitHub.MaxAttempts = 3
+	}
+	if c.GitHub.RetryBackoff <= 0 {
+		c.GitHub.RetryBackoff = time.Second
+	}
+	// Метрики текущей нагрузки
+	if c.Metrics.WorkloadRefreshInterval <= 0 {
+		c.Metrics.WorkloadRefreshInterval = 30 * time.Second
+	}
 }
@@ -29,6 +29,9 @@ timeouts:
 	t.Setenv("CONFIG_PATH", path)
 	t.Setenv("HTTP_PORT", "9000")
 	t.Setenv("SHUTDOWN_TIMEOUT", "20s")
+	t.Setenv("WEBHOOKS_MAX_ATTEMPTS", "3")
+	t.Setenv("OUTBOX_SINKS", "log,audit")
+	t.Setenv("GITHUB_USERS", "alice:u1,bob:u2")
 
 	cfg, err := Load()
 	require.NoError(t, err)
@@ -37,6 +40,57 @@ timeouts:
 	require.Equal(t, 10*time.Second, cfg.Timeouts.Operation)
 	require.Equal(t, 20*time.Second, cfg.Timeouts.Shutdown)
 	require.Equal(t, "postgres://localhost:5432/db", cfg.Database.URL)
+	require.Equal(t, "random", cfg.Reviewers.DefaultStrategy)
+	require.Equal(t, 2, cfg.Reviewers.DefaultRequired)
+	require.Equal(t, 3, cfg.Webhooks.MaxAttempts)
+	require.Equal(t, 10*time.Second, cfg.Webhooks.BackoffBase)
+	require.Equal(t, time.Hour, cfg.Webhooks.BackoffMax)
+	require.Equal(t, []string{"log", "audit"}, cfg.Outbox.Sinks)
+	require.Equal(t, time.Second, cfg.Outbox.PollInterval)
+	require.Equal(t, 100, cfg.Outbox.BatchSize)
+	require.Equal(t, map[string]string{"alice": "u1", "bob": "u2"}, cfg.GitHub.Users)
+	require.Equal(t, "https://api.github.com", cfg.GitHub.APIURL)
+	require.Equal(t, 3, cfg.GitHub.MaxAttempts)
+	require.Equal(t, 30*time.Second, cfg.Metrics.WorkloadRefreshInterval)
+}
+
+func TestLoadReadsTeamReviewerSettings(t *testing.T) {
+	path := writeTempConfig(t, `
+reviewers:
+  default_strategy: least_loaded
+  teams:
+    backend:
+      strategy: weighted
+      required_reviewers: 3
+      fallback_teams: [platform, mobile]
+      max_open_reviews: 5
+      merge_policy:
+        required_approvals: 2
+        block_on_changes_requested: true
+        required_role: lead
+      review_sla:
+        hours: 24
+        escalation: REASSIGN
+      weights:
+        u1: 3
+sla:
+  check_interval: 1m
+`)
+	t.Setenv("CONFIG_PATH", path)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	require.Equal(t, "least_loaded", cfg.Reviewers.DefaultStrategy)
+	require.Equal(t, "weighted", cfg.Reviewers.Teams["backend"].Strategy)
+	require.Equal(t, 3, cfg.Reviewers.Teams["backend"].Weights["u1"])
+	require.Equal(t, 3, cfg.Reviewers.Teams["backend"].RequiredReviewers)
+	require.Equal(t, []string{"platform", "mobile"}, cfg.Reviewers.Teams["backend"].FallbackTeams)
+	require.Equal(t, 5, cfg.Reviewers.Teams["backend"].MaxOpenReviews)
+	require.Equal(t, MergePolicyConfig{RequiredApprovals: 2, BlockOnChangesRequested: true, RequiredRole: "lead"},
+		cfg.Reviewers.Teams["backend"].MergePolicy)
+	require.Equal(t, ReviewSLAConfig{Hours: 24, Escalation: "REASSIGN"}, cfg.Reviewers.Teams["backend"].ReviewSLA)
+	require.Equal(t, time.Minute, cfg.SLA.CheckInterval)
 }
 
 func TestLoadMissingFileReturnsError(t *testing.T) {
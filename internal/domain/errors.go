@@ -1,16 +1,59 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 // Доменные ошибки, используемые для обработки бизнес-логики.
 // Эти ошибки преобразуются в HTTP-ответы в слое обработчиков.
 var (
-	ErrTeamExists     = errors.New("team already exists")                   // Возникает при попытке создать команду, которая уже существует.
-	ErrTeamNotFound   = errors.New("team not found")                        // Возникает при попытке получить несуществующую команду.
-	ErrUserNotFound   = errors.New("user not found")                        // Возникает при попытке получить несуществующего пользователя.
-	ErrPRExists       = errors.New("pull request already exists")           // Возникает при попытке создать PR с уже существующим ID.
-	ErrPRNotFound     = errors.New("pull request not found")                // Возникает при попытке получить несуществующий PR.
-	ErrPRMerged       = errors.New("pull request already merged")           // Возникает при попытке выполнить операцию над уже смерженным PR.
-	ErrReviewerAbsent = errors.New("reviewer not assigned to pull request") // Возникает при попытке переназначить ревьювера, который не назначен на PR.
-	ErrNoCandidate    = errors.New("no candidate available")                // Возникает когда нет доступных кандидатов для назначения ревьювером.
+	ErrTeamExists          = errors.New("team already exists")                     // Возникает при попытке создать команду, которая уже существует.
+	ErrTeamNotFound        = errors.New("team not found")                          // Возникает при попытке получить несуществующую команду.
+	ErrUserNotFound        = errors.New("user not found")                          // Возникает при попытке получить несуществующего пользователя.
+	ErrPRExists            = errors.New("pull request already exists")             // Возникает при попытке создать PR с уже существующим ID.
+	ErrPRNotFound          = errors.New("pull request not found")                  // Возникает при попытке получить несуществующий PR.
+	ErrPRMerged            = errors.New("pull request already merged")             // Возникает при попытке выполнить операцию над уже смерженным PR.
+	ErrPRClosed            = errors.New("pull request closed")                     // Возникает при попытке выполнить операцию над закрытым PR.
+	ErrPRDraft             = errors.New("pull request is a draft")                 // Возникает при попытке выполнить операцию над черновиком PR.
+	ErrReviewerAbsent      = errors.New("reviewer not assigned to pull request")   // Возникает при попытке переназначить ревьювера, который не назначен на PR.
+	ErrReviewerNotEligible = errors.New("user cannot review this pull request")    // Возникает при выборе ревьювера, не подходящего по правилам назначения.
+	ErrNoCandidate         = errors.New("no candidate available")                  // Возникает когда нет доступных кандидатов для назначения ревьювером.
+	ErrUnknownStrategy     = errors.New("unknown reviewer strategy")               // Возникает при указании неизвестной стратегии выбора ревьюверов.
+	ErrAllSaturated        = errors.New("all candidates reached max open reviews") // Возникает когда все кандидаты достигли лимита открытых ревью.
+	ErrMergePolicyNotMet   = errors.New("merge policy not satisfied")              // Возникает при попытке смержить PR, не удовлетворяющий политике команды.
+	ErrWebhookNotFound     = errors.New("webhook not found")                       // Возникает при обращении к несуществующей подписке на webhook.
+	ErrDeliveryNotFound    = errors.New("webhook delivery not found")              // Возникает при обращении к несуществующей доставке webhook'а.
 )
+
+// RequireOpenStatus возвращает ошибку, если PR в статусе status нельзя изменять как открытый:
+// назначать и снимать ревьюверов, выносить решения и мержить. Для OPEN возвращает nil.
+func RequireOpenStatus(status PRStatus) error {
+	switch status {
+	case PRStatusMerged:
+		return ErrPRMerged
+	case PRStatusClosed:
+		return ErrPRClosed
+	case PRStatusDraft:
+		return ErrPRDraft
+	}
+	return nil
+}
+
+// MergePolicyError перечисляет невыполненные условия политики merge.
+// errors.Is(err, ErrMergePolicyNotMet) возвращает true.
+type MergePolicyError struct {
+	Unmet []UnmetMergeCondition
+}
+
+func (e *MergePolicyError) Error() string {
+	messages := make([]string, 0, len(e.Unmet))
+	for _, c := range e.Unmet {
+		messages = append(messages, c.Message)
+	}
+	return ErrMergePolicyNotMet.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *MergePolicyError) Unwrap() error {
+	return ErrMergePolicyNotMet
+}
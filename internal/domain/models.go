@@ -1,27 +1,164 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // PRStatus отражает возможные состояния PR.
 type PRStatus string
 
 const (
-	PRStatusOpen   PRStatus = "OPEN"
-	PRStatusMerged PRStatus = "MERGED"
+	PRStatusDraft  PRStatus = "DRAFT"  // Черновик: ревьюверы не назначаются, пока PR не готов к ревью
+	PRStatusOpen   PRStatus = "OPEN"   // Открыт и ожидает ревью
+	PRStatusMerged PRStatus = "MERGED" // Смержен, финальное состояние
+	PRStatusClosed PRStatus = "CLOSED" // Закрыт без merge, ревьюверы сняты; может быть переоткрыт
+)
+
+// ReviewerStrategy задаёт политику выбора ревьюверов.
+type ReviewerStrategy string
+
+const (
+	ReviewerStrategyRandom      ReviewerStrategy = "random"
+	ReviewerStrategyLeastLoaded ReviewerStrategy = "least_loaded"
+	ReviewerStrategyRoundRobin  ReviewerStrategy = "round_robin"
+	ReviewerStrategyWeighted    ReviewerStrategy = "weighted"
+)
+
+// ReviewDecision — решение ревьювера по PR.
+type ReviewDecision string
+
+const (
+	ReviewDecisionApproved         ReviewDecision = "APPROVED"
+	ReviewDecisionChangesRequested ReviewDecision = "CHANGES_REQUESTED"
+	ReviewDecisionCommented        ReviewDecision = "COMMENTED"
+)
+
+// SLAEscalation — действие при нарушении SLA на ревью.
+type SLAEscalation string
+
+const (
+	SLAEscalationNotify      SLAEscalation = "NOTIFY"       // Зафиксировать нарушение в журнале событий
+	SLAEscalationReassign    SLAEscalation = "REASSIGN"     // Заменить просроченного ревьювера
+	SLAEscalationAddReviewer SLAEscalation = "ADD_REVIEWER" // Добавить ещё одного ревьювера
+)
+
+// EventType — тип события журнала назначений (review_assignment_events).
+type EventType string
+
+const (
+	EventAssigned         EventType = "ASSIGNED"          // Ревьювер назначен на PR
+	EventUnassigned       EventType = "UNASSIGNED"        // Ревьювер снят с PR
+	EventApproved         EventType = "APPROVED"          // Ревьювер одобрил PR
+	EventChangesRequested EventType = "CHANGES_REQUESTED" // Ревьювер запросил изменения
+	EventCommented        EventType = "COMMENTED"         // Ревьювер оставил комментарий
+	EventReadyForReview   EventType = "READY_FOR_REVIEW"  // Черновик готов к ревью
+	EventMerged           EventType = "MERGED"            // PR смержен
+	EventClosed           EventType = "CLOSED"            // PR закрыт без merge
+	EventReopened         EventType = "REOPENED"          // PR переоткрыт
+	EventSLABreached      EventType = "SLA_BREACHED"      // Ревьювер нарушил SLA на ревью
+)
+
+// EventTypes перечисляет все типы событий журнала назначений.
+var EventTypes = []EventType{
+	EventAssigned, EventUnassigned,
+	EventApproved, EventChangesRequested, EventCommented,
+	EventReadyForReview, EventMerged, EventClosed, EventReopened,
+	EventSLABreached,
+}
+
+// Типы агрегатов, к которым относятся записи outbox.
+const (
+	OutboxAggregateTeam        = "team"
+	OutboxAggregateUser        = "user"
+	OutboxAggregatePullRequest = "pull_request"
+)
+
+// Типы событий outbox для команд, пользователей и создания PR. Остальные события PR совпадают
+// с EventType журнала назначений.
+const (
+	OutboxTeamCreated               = "TEAM_CREATED"
+	OutboxTeamSettingsUpdated       = "TEAM_SETTINGS_UPDATED"
+	OutboxUserActivityChanged       = "USER_ACTIVITY_CHANGED"
+	OutboxUserMaxOpenReviewsChanged = "USER_MAX_OPEN_REVIEWS_CHANGED"
+	OutboxUserRoleChanged           = "USER_ROLE_CHANGED"
+	OutboxPullRequestCreated        = "PULL_REQUEST_CREATED"
+)
+
+// WebhookDeliveryStatus — состояние доставки webhook'а.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"   // Ожидает первой или повторной попытки
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "DELIVERED" // Получатель ответил 2xx
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED"    // Попытки исчерпаны
 )
 
 // Team описывает команду и её участников.
 type Team struct {
 	Name    string `json:"team_name"`
 	Members []User `json:"members"`
+	// RequiredReviewers задаётся при создании команды; 0 — значение из конфигурации.
+	RequiredReviewers int `json:"required_reviewers,omitempty"`
 }
 
+// TeamSettings содержит настройки команды, влияющие на назначение ревьюверов.
+type TeamSettings struct {
+	TeamName         string           `json:"team_name"`
+	ReviewerStrategy ReviewerStrategy `json:"reviewer_strategy"`
+	// RequiredReviewers — количество ревьюверов на PR; 0 означает значение из конфигурации.
+	RequiredReviewers int `json:"required_reviewers"`
+	// FallbackTeams — команды, к которым по порядку обращается назначение, если в команде нет кандидатов.
+	FallbackTeams []string `json:"fallback_teams"`
+	// MaxOpenReviews — ограничение открытых ревью на участника по умолчанию; 0 означает отсутствие ограничения.
+	MaxOpenReviews int `json:"max_open_reviews"`
+	// MergePolicy — условия, при которых PR авторов команды можно смержить.
+	MergePolicy MergePolicy `json:"merge_policy"`
+	// ReviewSLA — SLA на ревью PR авторов команды.
+	ReviewSLA ReviewSLA `json:"review_sla"`
+}
+
+// ReviewSLA описывает, сколько назначенный ревьювер может не выносить решение и что делать после.
+type ReviewSLA struct {
+	// Hours — SLA в часах; 0 означает, что SLA не отслеживается.
+	Hours int `json:"hours"`
+	// Escalation — действие при нарушении SLA; пустое значение означает NOTIFY.
+	Escalation SLAEscalation `json:"escalation"`
+}
+
+// MergePolicy описывает условия merge PR. Незаданные поля означают значения из конфигурации.
+type MergePolicy struct {
+	// RequiredApprovals — количество одобрений; 0 означает, что одобрения не требуются.
+	RequiredApprovals int `json:"required_approvals"`
+	// BlockOnChangesRequested запрещает merge, пока есть решение CHANGES_REQUESTED.
+	BlockOnChangesRequested *bool `json:"block_on_changes_requested"`
+	// RequiredRole — роль, одобрение от обладателя которой обязательно; пустая строка — не требуется.
+	RequiredRole string `json:"required_role"`
+}
+
+// UnmetMergeCondition описывает невыполненное условие политики merge.
+type UnmetMergeCondition struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Коды невыполненных условий политики merge.
+const (
+	MergeConditionApprovals        = "APPROVALS_REQUIRED"
+	MergeConditionChangesRequested = "CHANGES_REQUESTED"
+	MergeConditionRoleApproval     = "ROLE_APPROVAL_REQUIRED"
+)
+
 // User представляет участника команды.
 type User struct {
 	ID       string `json:"user_id"`
 	Username string `json:"username"`
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
+	// MaxOpenReviews — персональное ограничение открытых ревью; 0 означает значение команды.
+	MaxOpenReviews int `json:"max_open_reviews,omitempty"`
+	// Role — роль пользователя, учитываемая политикой merge.
+	Role string `json:"role,omitempty"`
 }
 
 // PullRequest содержит данные PR.
@@ -31,22 +168,114 @@ type PullRequest struct {
 	AuthorID          string     `json:"author_id"`
 	Status            PRStatus   `json:"status"`
 	AssignedReviewers []string   `json:"assigned_reviewers"`
+	FallbackReviewers []string   `json:"fallback_reviewers,omitempty"` // Ревьюверы, назначенные из резервных команд
+	Decisions         []Review   `json:"decisions,omitempty"`          // Последние решения назначенных ревьюверов
 	CreatedAt         time.Time  `json:"createdAt"`
 	MergedAt          *time.Time `json:"mergedAt,omitempty"`
 }
 
+// Review описывает решение ревьювера по PR.
+type Review struct {
+	ReviewerID string         `json:"reviewer_id"`
+	Decision   ReviewDecision `json:"decision"`
+	DecidedAt  time.Time      `json:"decided_at"`
+}
+
+// PendingReview описывает назначение ревьювера на открытый PR, по которому ещё нет решения.
+type PendingReview struct {
+	PullRequestID string
+	ReviewerID    string
+	AuthorTeam    string // Команда автора PR, SLA которой применяется
+	AssignedAt    time.Time
+}
+
 // PullRequestShort используется там, где достаточно укороченного представления.
 type PullRequestShort struct {
-	ID       string   `json:"pull_request_id"`
-	Name     string   `json:"pull_request_name"`
-	AuthorID string   `json:"author_id"`
-	Status   PRStatus `json:"status"`
+	ID                string     `json:"pull_request_id"`
+	Name              string     `json:"pull_request_name"`
+	AuthorID          string     `json:"author_id"`
+	Status            PRStatus   `json:"status"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	AssignedReviewers []string   `json:"assigned_reviewers,omitempty"` // В /users/getReview только по запросу include=reviewers
+}
+
+// ReviewAssignmentFilter ограничивает выборку PR, на которые назначен ревьювер.
+// PR упорядочены от новых к старым по created_at, при равенстве — по pull_request_id.
+type ReviewAssignmentFilter struct {
+	UserID           string
+	Statuses         []PRStatus // Пусто — любой статус
+	IncludeReviewers bool       // Заполнять AssignedReviewers
+	// Курсор: только PR, идущие в порядке выборки после PR с AfterCreatedAt и AfterID
+	AfterCreatedAt *time.Time
+	AfterID        string
+	Limit          int // 0 — без ограничения
+}
+
+// ReviewAssignmentPage — страница PR, на которые назначен ревьювер.
+type ReviewAssignmentPage struct {
+	UserID       string             `json:"user_id"`
+	PullRequests []PullRequestShort `json:"pull_requests"`
+	Total        int64              `json:"total"`                 // PR под фильтром статусов без учёта курсора и limit
+	NextCursor   string             `json:"next_cursor,omitempty"` // Пусто на последней странице
+}
+
+// PullRequestSort — поле сортировки списка PR.
+type PullRequestSort string
+
+// Поля сортировки списка PR. При равенстве PR упорядочиваются по pull_request_id в том же направлении.
+const (
+	PullRequestSortCreatedAt PullRequestSort = "created_at"
+	PullRequestSortName      PullRequestSort = "pull_request_name"
+)
+
+// PullRequestFilter ограничивает выборку списка PR. Пустые поля не ограничивают выборку.
+type PullRequestFilter struct {
+	Statuses         []PRStatus
+	AuthorID         string
+	TeamName         string     // Команда автора PR
+	ReviewerID       string     // Среди текущих ревьюверов PR
+	WithoutReviewers bool       // Только PR без текущих ревьюверов
+	CreatedFrom      *time.Time // Включительно
+	CreatedTo        *time.Time // Не включительно
+	MergedFrom       *time.Time // Включительно
+	MergedTo         *time.Time // Не включительно
+	Query            string     // Подстрока pull_request_name без учёта регистра
+	Sort             PullRequestSort
+	Desc             bool
+	After            *PullRequestCursor // Курсор: только PR после указанного в порядке сортировки
+	Limit            int                // 0 — без ограничения
+}
+
+// PullRequestCursor — позиция в списке PR: сортировка, для которой он выдан, значение поля сортировки
+// и ID последнего PR страницы.
+type PullRequestCursor struct {
+	Sort      PullRequestSort
+	Desc      bool
+	CreatedAt time.Time
+	Name      string
+	ID        string
+}
+
+// PullRequestPage — страница списка PR.
+type PullRequestPage struct {
+	PullRequests []PullRequestShort `json:"pull_requests"`
+	NextCursor   string             `json:"next_cursor,omitempty"` // Пусто на последней странице
 }
 
 // AssignmentStats содержит метрики по назначениям.
 type AssignmentStats struct {
-	PerUser []UserAssignmentStat `json:"per_user"`
-	PerPR   []PRAssignmentStat   `json:"per_pull_request"`
+	PerUser  []UserAssignmentStat   `json:"per_user"`
+	PerPR    []PRAssignmentStat     `json:"per_pull_request"`
+	BySource []SourceAssignmentStat `json:"by_source"`
+}
+
+// StatsFilter ограничивает выборку статистики. Пустые поля не ограничивают выборку.
+type StatsFilter struct {
+	From     *time.Time // Включительно: время назначения, создания PR или события
+	To       *time.Time // Не включительно
+	TeamName string     // Команда пользователя для per_user, команда автора PR для остального
+	Status   PRStatus   // Текущий статус PR
 }
 
 // UserAssignmentStat хранит информацию о количестве назначений конкретного пользователя.
@@ -60,6 +289,218 @@ type UserAssignmentStat struct {
 
 // PRAssignmentStat описывает статистику по PR.
 type PRAssignmentStat struct {
-	PullRequestID string `json:"pull_request_id"`
-	ReviewerCount int64  `json:"reviewer_count"`
+	PullRequestID string   `json:"pull_request_id"`
+	Status        PRStatus `json:"status"`
+	ReviewerCount int64    `json:"reviewer_count"`
+}
+
+// SourceAssignmentStat — количество назначений, снятий и замен ревьюверов по источнику события.
+type SourceAssignmentStat struct {
+	Source     string `json:"source"`
+	Assigned   int64  `json:"assigned"`
+	Unassigned int64  `json:"unassigned"`
+	// Reassigned — снятия, после которых в той же операции назначен другой ревьювер.
+	Reassigned int64 `json:"reassigned"`
+}
+
+// LatencyFilter ограничивает выборку задержек ревью. Пустые поля не ограничивают выборку.
+type LatencyFilter struct {
+	From     *time.Time // Включительно: время merge или решения ревьювера
+	To       *time.Time // Не включительно
+	TeamName string     // Команда автора PR
+}
+
+// LatencyPercentiles — перцентили задержки в секундах по Count измерениям.
+type LatencyPercentiles struct {
+	Count int64   `json:"count"`
+	P50   float64 `json:"p50_seconds"`
+	P90   float64 `json:"p90_seconds"`
+	P99   float64 `json:"p99_seconds"`
+}
+
+// TeamLatency — перцентили задержки по PR команды автора.
+type TeamLatency struct {
+	TeamName string `json:"team_name"`
+	LatencyPercentiles
+}
+
+// ReviewerLatency — перцентили задержки по PR, на которые назначен ревьювер.
+type ReviewerLatency struct {
+	ReviewerID string `json:"reviewer_id"`
+	LatencyPercentiles
+}
+
+// LatencyBreakdown — перцентили одной задержки по командам и по ревьюверам.
+type LatencyBreakdown struct {
+	PerTeam     []TeamLatency     `json:"per_team"`
+	PerReviewer []ReviewerLatency `json:"per_reviewer"`
+}
+
+// LatencyStats содержит задержки ревью: от создания PR до merge и от назначения ревьювера до его первого решения.
+type LatencyStats struct {
+	TimeToMerge          LatencyBreakdown `json:"time_to_merge"`
+	AssignmentToDecision LatencyBreakdown `json:"assignment_to_decision"`
+}
+
+// FairnessFilter ограничивает выборку отчёта о равномерности назначений. Пустые поля не ограничивают выборку.
+type FairnessFilter struct {
+	From     *time.Time // Включительно: время назначения
+	To       *time.Time // Не включительно
+	TeamName string     // Команда участников
+}
+
+// MemberAssignments — количество назначений активного участника команды за интервал.
+type MemberAssignments struct {
+	TeamName string
+	UserID   string
+	Username string
+	Assigned int64
+}
+
+// MemberShare — отклонение количества назначений участника от справедливой доли.
+type MemberShare struct {
+	UserID   string  `json:"user_id"`
+	Username string  `json:"username"`
+	Assigned int64   `json:"assigned"`
+	Delta    float64 `json:"delta"` // Assigned минус справедливая доля
+}
+
+// TeamFairness описывает, насколько равномерно назначения распределены между активными участниками команды.
+type TeamFairness struct {
+	TeamName         string  `json:"team_name"`
+	ActiveMembers    int     `json:"active_members"`
+	TotalAssignments int64   `json:"total_assignments"`
+	FairShare        float64 `json:"fair_share"` // Назначений на участника при равном распределении
+	// Gini — коэффициент Джини от 0 (поровну) до (n-1)/n (все назначения у одного из n участников).
+	Gini float64 `json:"gini"`
+	// MaxMinRatio — отношение наибольшего числа назначений к наименьшему; пусто, если у кого-то назначений нет.
+	MaxMinRatio *float64     `json:"max_min_ratio,omitempty"`
+	MostAbove   *MemberShare `json:"most_above,omitempty"` // Участник дальше всех выше справедливой доли
+	MostBelow   *MemberShare `json:"most_below,omitempty"` // Участник дальше всех ниже справедливой доли
+}
+
+// FairnessReport — отчёт о равномерности назначений по командам.
+type FairnessReport struct {
+	Teams []TeamFairness `json:"teams"`
+}
+
+// TeamWorkload — число открытых PR авторов команды.
+type TeamWorkload struct {
+	TeamName         string
+	OpenPullRequests int64
+}
+
+// ReviewerWorkload — число открытых PR, на которые назначен ревьювер.
+type ReviewerWorkload struct {
+	ReviewerID  string
+	TeamName    string
+	OpenReviews int64
+}
+
+// Workload — текущая нагрузка: открытые PR по всем командам и открытые ревью по активным пользователям
+// и неактивным, за которыми ещё числятся ревью.
+type Workload struct {
+	Teams     []TeamWorkload
+	Reviewers []ReviewerWorkload
+}
+
+// Webhook описывает подписку на события журнала назначений.
+type Webhook struct {
+	ID  int64  `json:"webhook_id"`
+	URL string `json:"url"`
+	// Secret — ключ подписи HMAC-SHA256; возвращается клиенту только при создании.
+	Secret string `json:"secret,omitempty"`
+	// EventTypes — типы событий, которые отправляются подписке; пустой список — все.
+	EventTypes []EventType `json:"event_types"`
+	// Teams — команды авторов PR, события которых отправляются подписке; пустой список — все.
+	Teams     []string  `json:"teams"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookDelivery описывает доставку одного события одной подписке.
+type WebhookDelivery struct {
+	ID             int64                 `json:"delivery_id"`
+	WebhookID      int64                 `json:"webhook_id"`
+	EventID        int64                 `json:"event_id"`
+	EventType      EventType             `json:"event_type"`
+	Payload        []byte                `json:"-"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	LastStatusCode *int                  `json:"last_status_code,omitempty"`
+	LastError      *string               `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	// URL и Secret подписки заполняются только для отправки.
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+// WebhookDeliveryFilter задаёт выборку журнала доставок.
+type WebhookDeliveryFilter struct {
+	WebhookID int64                 // 0 — все подписки
+	Status    WebhookDeliveryStatus // Пустое значение — любые статусы
+	Limit     int
+}
+
+// AssignmentEvent — запись журнала назначений.
+type AssignmentEvent struct {
+	ID            int64     `json:"event_id"`
+	PullRequestID string    `json:"pull_request_id"`
+	ReviewerID    *string   `json:"reviewer_id,omitempty"` // Пусто для смены статуса PR
+	EventType     EventType `json:"event_type"`
+	Source        string    `json:"source"`
+	TeamName      string    `json:"team_name"` // Команда автора PR
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// EventFilter — фильтр журнала назначений. Пустые поля не ограничивают выборку.
+type EventFilter struct {
+	PullRequestID string
+	ReviewerID    string
+	TeamName      string // Команда автора PR
+	EventType     EventType
+	Source        string
+	From          *time.Time // Включительно
+	To            *time.Time // Не включительно
+	AfterID       int64      // Курсор: только события с id больше AfterID
+	Limit         int        // 0 — без ограничения
+}
+
+// EventPage — страница журнала назначений.
+type EventPage struct {
+	Events     []AssignmentEvent `json:"events"`
+	NextCursor string            `json:"next_cursor,omitempty"` // Пусто на последней странице
+}
+
+// TimelineCreated — запись истории PR о его создании. Остальные записи совпадают с EventType журнала назначений.
+const TimelineCreated EventType = "CREATED"
+
+// TimelineEntry — запись истории PR.
+type TimelineEntry struct {
+	EventID    int64     `json:"event_id,omitempty"` // Пусто для записи о создании
+	Type       EventType `json:"type"`
+	ReviewerID *string   `json:"reviewer_id,omitempty"`
+	Source     string    `json:"source,omitempty"`
+	Status     PRStatus  `json:"status,omitempty"` // Статус PR после записи; только для создания и смены статуса
+	At         time.Time `json:"at"`
+}
+
+// PullRequestTimeline — упорядоченная история PR.
+type PullRequestTimeline struct {
+	PullRequest PullRequest     `json:"pr"`
+	Timeline    []TimelineEntry `json:"timeline"`
+}
+
+// OutboxMessage — доменное событие, записанное в outbox в одной транзакции с изменением состояния.
+type OutboxMessage struct {
+	ID            int64           `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+	// PublishedSinks — приёмники, уже принявшие сообщение
+	PublishedSinks []string `json:"-"`
 }
@@ -2,12 +2,14 @@ package addteam
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 
 	"pr-reviewer-service_Avito/internal/api"
 	"pr-reviewer-service_Avito/internal/http/handler/common"
+	"pr-reviewer-service_Avito/internal/service"
 )
 
 // Handler отвечает за HTTP-слой создания команды.
@@ -33,6 +35,10 @@ func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
 	if req.TeamName == "" {
 		return common.NewBadRequestError("VALIDATION_ERROR", "team_name обязателен")
 	}
+	if req.RequiredReviewers != nil && (*req.RequiredReviewers < 1 || *req.RequiredReviewers > service.MaxRequiredReviewers) {
+		return common.NewBadRequestError("VALIDATION_ERROR",
+			fmt.Sprintf("required_reviewers должен быть от 1 до %d", service.MaxRequiredReviewers))
+	}
 	team, err := h.useCase.CreateTeam(r.Context(), common.ToDomainTeam(req))
 	if err != nil {
 		return err
@@ -61,3 +61,21 @@ func TestHandler_Success(t *testing.T) {
 	require.Equal(t, http.StatusCreated, rec.Code)
 	require.True(t, useCase.called)
 }
+
+func TestHandler_RejectsInvalidRequiredReviewers(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	body := `{"team_name":"backend","members":[],"required_reviewers":0}`
+	req := httptest.NewRequest(http.MethodPost, "/add", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.False(t, useCase.called)
+}
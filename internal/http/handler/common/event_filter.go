@@ -0,0 +1,71 @@
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+// ParseEventFilter разбирает query-параметры фильтра журнала назначений:
+// pull_request_id, reviewer_id, team_name, event_type, source, from, to (RFC 3339), cursor и limit.
+func ParseEventFilter(query url.Values) (domain.EventFilter, error) {
+	filter := domain.EventFilter{
+		PullRequestID: query.Get("pull_request_id"),
+		ReviewerID:    query.Get("reviewer_id"),
+		TeamName:      query.Get("team_name"),
+		EventType:     domain.EventType(query.Get("event_type")),
+		Source:        query.Get("source"),
+	}
+	var err error
+	if filter.From, filter.To, err = parseTimeRange(query, "from", "to"); err != nil {
+		return domain.EventFilter{}, err
+	}
+	if raw := query.Get("cursor"); raw != "" {
+		afterID, err := service.DecodeEventCursor(raw)
+		if err != nil {
+			return domain.EventFilter{}, NewBadRequestError("VALIDATION_ERROR", "некорректный cursor")
+		}
+		filter.AfterID = afterID
+	}
+	if filter.Limit, err = parseLimit(query, service.MaxEventsLimit); err != nil {
+		return domain.EventFilter{}, err
+	}
+	if filter.EventType != "" && service.ValidateEventTypes([]domain.EventType{filter.EventType}) != nil {
+		return domain.EventFilter{}, NewBadRequestError("VALIDATION_ERROR", "неизвестный event_type")
+	}
+	if service.ValidateEventFilter(filter) != nil {
+		return domain.EventFilter{}, NewBadRequestError("VALIDATION_ERROR", "from должен быть раньше to")
+	}
+	return filter, nil
+}
+
+// parseLimit разбирает необязательный query-параметр limit от 1 до max; без параметра возвращает 0.
+func parseLimit(query url.Values, max int) (int, error) {
+	raw := query.Get("limit")
+	if raw == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit <= 0 || limit > max {
+		return 0, NewBadRequestError("VALIDATION_ERROR", fmt.Sprintf("limit должен быть от 1 до %d", max))
+	}
+	return limit, nil
+}
+
+// parseTimeRange разбирает необязательные query-параметры интервала fromName и toName в формате RFC 3339.
+func parseTimeRange(query url.Values, fromName, toName string) (from, to *time.Time, err error) {
+	for name, dst := range map[string]**time.Time{fromName: &from, toName: &to} {
+		if raw := query.Get(name); raw != "" {
+			t, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				return nil, nil, NewBadRequestError("VALIDATION_ERROR", name+" должен быть в формате RFC 3339")
+			}
+			*dst = &t
+		}
+	}
+	return from, to, nil
+}
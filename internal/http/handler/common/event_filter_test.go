@@ -0,0 +1,23 @@
+package common
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestParseEventFilterEmpty(t *testing.T) {
+	filter, err := ParseEventFilter(url.Values{})
+	require.NoError(t, err)
+	require.Equal(t, domain.EventFilter{}, filter)
+}
+
+func TestParseEventFilterRejectsUnknownEventType(t *testing.T) {
+	_, err := ParseEventFilter(url.Values{"event_type": {"PUSHED"}})
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, "неизвестный event_type", httpErr.Error())
+}
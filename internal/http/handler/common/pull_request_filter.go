@@ -0,0 +1,77 @@
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+// ParsePullRequestFilter разбирает query-параметры списка PR: status (через запятую), author_id, team_name,
+// reviewer_id, without_reviewers, created_from, created_to, merged_from, merged_to (RFC 3339), q, sort,
+// cursor и limit. sort — поле created_at или pull_request_name, с минусом по убыванию; по умолчанию -created_at.
+func ParsePullRequestFilter(query url.Values) (domain.PullRequestFilter, error) {
+	filter := domain.PullRequestFilter{
+		AuthorID:   query.Get("author_id"),
+		TeamName:   query.Get("team_name"),
+		ReviewerID: query.Get("reviewer_id"),
+		Query:      strings.TrimSpace(query.Get("q")),
+	}
+	for _, status := range splitList(query.Get("status")) {
+		filter.Statuses = append(filter.Statuses, domain.PRStatus(status))
+	}
+	if service.ValidatePRStatuses(filter.Statuses) != nil {
+		return domain.PullRequestFilter{}, NewBadRequestError("VALIDATION_ERROR",
+			"status должен быть списком из DRAFT, OPEN, MERGED и CLOSED")
+	}
+	if raw := query.Get("without_reviewers"); raw != "" {
+		withoutReviewers, err := strconv.ParseBool(raw)
+		if err != nil {
+			return domain.PullRequestFilter{}, NewBadRequestError("VALIDATION_ERROR", "without_reviewers должен быть true или false")
+		}
+		filter.WithoutReviewers = withoutReviewers
+	}
+	if filter.WithoutReviewers && filter.ReviewerID != "" {
+		return domain.PullRequestFilter{}, NewBadRequestError("VALIDATION_ERROR",
+			"reviewer_id нельзя сочетать с without_reviewers")
+	}
+	var err error
+	if filter.CreatedFrom, filter.CreatedTo, err = parseTimeRange(query, "created_from", "created_to"); err != nil {
+		return domain.PullRequestFilter{}, err
+	}
+	if filter.MergedFrom, filter.MergedTo, err = parseTimeRange(query, "merged_from", "merged_to"); err != nil {
+		return domain.PullRequestFilter{}, err
+	}
+	if len([]rune(filter.Query)) > service.MaxPullRequestQueryLength {
+		return domain.PullRequestFilter{}, NewBadRequestError("VALIDATION_ERROR",
+			fmt.Sprintf("q должен быть не длиннее %d символов", service.MaxPullRequestQueryLength))
+	}
+	sort := query.Get("sort")
+	if sort == "" {
+		sort = "-" + string(domain.PullRequestSortCreatedAt)
+	}
+	sortField, desc := strings.CutPrefix(sort, "-")
+	filter.Sort, filter.Desc = domain.PullRequestSort(sortField), desc
+	if filter.Sort != domain.PullRequestSortCreatedAt && filter.Sort != domain.PullRequestSortName {
+		return domain.PullRequestFilter{}, NewBadRequestError("VALIDATION_ERROR",
+			"sort должен быть created_at или pull_request_name, с минусом для сортировки по убыванию")
+	}
+	if raw := query.Get("cursor"); raw != "" {
+		cursor, err := service.DecodePullRequestCursor(raw)
+		if err != nil {
+			return domain.PullRequestFilter{}, NewBadRequestError("VALIDATION_ERROR", "некорректный cursor")
+		}
+		filter.After = &cursor
+	}
+	if filter.Limit, err = parseLimit(query, service.MaxPullRequestsLimit); err != nil {
+		return domain.PullRequestFilter{}, err
+	}
+	if service.ValidatePullRequestFilter(filter) != nil {
+		return domain.PullRequestFilter{}, NewBadRequestError("VALIDATION_ERROR",
+			"интервалы должны начинаться раньше, чем заканчиваются, а cursor — соответствовать sort")
+	}
+	return filter, nil
+}
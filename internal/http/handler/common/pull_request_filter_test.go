@@ -0,0 +1,77 @@
+package common
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+func TestParsePullRequestFilter(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	cursor := domain.PullRequestCursor{Sort: domain.PullRequestSortName, Name: "Fix", ID: "pr-7"}
+	encoded, err := service.EncodePullRequestCursor(cursor)
+	require.NoError(t, err)
+	filter, err := ParsePullRequestFilter(url.Values{
+		"status":       {"OPEN,MERGED"},
+		"author_id":    {"u1"},
+		"team_name":    {"backend"},
+		"reviewer_id":  {"u2"},
+		"created_from": {"2025-01-01T00:00:00Z"},
+		"created_to":   {"2025-02-01T00:00:00Z"},
+		"merged_from":  {"2025-01-01T00:00:00Z"},
+		"q":            {" search "},
+		"sort":         {"pull_request_name"},
+		"cursor":       {encoded},
+		"limit":        {"20"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, domain.PullRequestFilter{
+		Statuses:    []domain.PRStatus{domain.PRStatusOpen, domain.PRStatusMerged},
+		AuthorID:    "u1",
+		TeamName:    "backend",
+		ReviewerID:  "u2",
+		CreatedFrom: &from,
+		CreatedTo:   &to,
+		MergedFrom:  &from,
+		Query:       "search",
+		Sort:        domain.PullRequestSortName,
+		After:       &cursor,
+		Limit:       20,
+	}, filter)
+}
+
+func TestParsePullRequestFilterDefaults(t *testing.T) {
+	filter, err := ParsePullRequestFilter(url.Values{"without_reviewers": {"true"}})
+	require.NoError(t, err)
+	require.Equal(t, domain.PullRequestFilter{
+		WithoutReviewers: true,
+		Sort:             domain.PullRequestSortCreatedAt,
+		Desc:             true,
+	}, filter)
+}
+
+func TestParsePullRequestFilterRejectsInvalid(t *testing.T) {
+	nameCursor, err := service.EncodePullRequestCursor(domain.PullRequestCursor{Sort: domain.PullRequestSortName, ID: "pr-1"})
+	require.NoError(t, err)
+	for name, query := range map[string]url.Values{
+		"unknown status":    {"status": {"STALE"}},
+		"bad flag":          {"without_reviewers": {"maybe"}},
+		"reviewer conflict": {"reviewer_id": {"u1"}, "without_reviewers": {"true"}},
+		"bad time":          {"created_from": {"yesterday"}},
+		"reversed range":    {"merged_from": {"2025-02-01T00:00:00Z"}, "merged_to": {"2025-01-01T00:00:00Z"}},
+		"unknown sort":      {"sort": {"author_id"}},
+		"bad cursor":        {"cursor": {"%%%"}},
+		"cursor mismatch":   {"cursor": {nameCursor}},
+		"limit too big":     {"limit": {"100000"}},
+	} {
+		_, err := ParsePullRequestFilter(query)
+		var httpErr *HTTPError
+		require.ErrorAs(t, err, &httpErr, name)
+	}
+}
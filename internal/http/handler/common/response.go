@@ -18,6 +18,8 @@ type APIError struct {
 type APIErrorBody struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	// UnmetConditions перечисляет невыполненные условия политики merge.
+	UnmetConditions []domain.UnmetMergeCondition `json:"unmet_conditions,omitempty"`
 }
 
 // RespondJSON отправляет JSON-ответ с указанным статус-кодом.
@@ -83,11 +85,22 @@ func WriteDomainError(w http.ResponseWriter, r *http.Request, err error) {
 	ctx := r.Context()
 	requestID := chimw.GetReqID(ctx)
 
+	var policyErr *domain.MergePolicyError
+	if errors.As(err, &policyErr) {
+		slog.DebugContext(ctx, "merge policy not satisfied", "request_id", requestID, "error", err)
+		RespondJSON(w, http.StatusConflict, APIError{Error: APIErrorBody{
+			Code:            "MERGE_POLICY_NOT_MET",
+			Message:         domain.ErrMergePolicyNotMet.Error(),
+			UnmetConditions: policyErr.Unmet,
+		}})
+		return
+	}
+
 	switch err {
 	case domain.ErrTeamExists:
 		slog.DebugContext(ctx, "team already exists", "request_id", requestID, "error", err)
 		RespondJSON(w, http.StatusBadRequest, APIError{Error: APIErrorBody{Code: "TEAM_EXISTS", Message: err.Error()}})
-	case domain.ErrTeamNotFound, domain.ErrUserNotFound, domain.ErrPRNotFound:
+	case domain.ErrTeamNotFound, domain.ErrUserNotFound, domain.ErrPRNotFound, domain.ErrWebhookNotFound, domain.ErrDeliveryNotFound:
 		slog.DebugContext(ctx, "resource not found", "request_id", requestID, "error", err)
 		RespondJSON(w, http.StatusNotFound, APIError{Error: APIErrorBody{Code: "NOT_FOUND", Message: err.Error()}})
 	case domain.ErrPRExists:
@@ -96,12 +109,27 @@ func WriteDomainError(w http.ResponseWriter, r *http.Request, err error) {
 	case domain.ErrPRMerged:
 		slog.DebugContext(ctx, "PR already merged", "request_id", requestID, "error", err)
 		RespondJSON(w, http.StatusConflict, APIError{Error: APIErrorBody{Code: "PR_MERGED", Message: err.Error()}})
+	case domain.ErrPRClosed:
+		slog.DebugContext(ctx, "PR closed", "request_id", requestID, "error", err)
+		RespondJSON(w, http.StatusConflict, APIError{Error: APIErrorBody{Code: "PR_CLOSED", Message: err.Error()}})
+	case domain.ErrPRDraft:
+		slog.DebugContext(ctx, "PR is a draft", "request_id", requestID, "error", err)
+		RespondJSON(w, http.StatusConflict, APIError{Error: APIErrorBody{Code: "PR_DRAFT", Message: err.Error()}})
 	case domain.ErrReviewerAbsent:
 		slog.DebugContext(ctx, "reviewer not assigned", "request_id", requestID, "error", err)
 		RespondJSON(w, http.StatusConflict, APIError{Error: APIErrorBody{Code: "NOT_ASSIGNED", Message: err.Error()}})
+	case domain.ErrReviewerNotEligible:
+		slog.DebugContext(ctx, "reviewer not eligible", "request_id", requestID, "error", err)
+		RespondJSON(w, http.StatusConflict, APIError{Error: APIErrorBody{Code: "REVIEWER_NOT_ELIGIBLE", Message: err.Error()}})
 	case domain.ErrNoCandidate:
 		slog.DebugContext(ctx, "no candidate for reassignment", "request_id", requestID, "error", err)
 		RespondJSON(w, http.StatusConflict, APIError{Error: APIErrorBody{Code: "NO_CANDIDATE", Message: err.Error()}})
+	case domain.ErrAllSaturated:
+		slog.DebugContext(ctx, "all candidates saturated", "request_id", requestID, "error", err)
+		RespondJSON(w, http.StatusConflict, APIError{Error: APIErrorBody{Code: "ALL_SATURATED", Message: err.Error()}})
+	case domain.ErrUnknownStrategy:
+		slog.DebugContext(ctx, "unknown reviewer strategy", "request_id", requestID, "error", err)
+		RespondJSON(w, http.StatusBadRequest, APIError{Error: APIErrorBody{Code: "UNKNOWN_STRATEGY", Message: err.Error()}})
 	default:
 		slog.ErrorContext(ctx, "unhandled domain error", "request_id", requestID, "error", err)
 		RespondJSON(w, http.StatusInternalServerError, APIError{Error: APIErrorBody{Code: "INTERNAL_ERROR", Message: "internal server error"}})
@@ -59,6 +59,22 @@ func TestWithErrorHandlingFallsBackToDomainErrors(t *testing.T) {
 	require.Equal(t, "TEAM_EXISTS", apiErr.Error.Code)
 }
 
+func TestWriteDomainErrorMergePolicy(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+	WriteDomainError(rec, req, &domain.MergePolicyError{Unmet: []domain.UnmetMergeCondition{
+		{Code: domain.MergeConditionApprovals, Message: "2 approvals required, got 1"},
+	}})
+
+	require.Equal(t, http.StatusConflict, rec.Code)
+	var apiErr APIError
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &apiErr))
+	require.Equal(t, "MERGE_POLICY_NOT_MET", apiErr.Error.Code)
+	require.Len(t, apiErr.Error.UnmetConditions, 1)
+	require.Equal(t, domain.MergeConditionApprovals, apiErr.Error.UnmetConditions[0].Code)
+}
+
 func TestWriteDomainErrorUnknownError(t *testing.T) {
 	rec := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
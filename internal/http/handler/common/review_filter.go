@@ -0,0 +1,54 @@
+package common
+
+import (
+	"net/url"
+	"strings"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+// ParseReviewAssignmentFilter разбирает query-параметры списка PR ревьювера: user_id, status (через запятую),
+// include=reviewers, cursor и limit.
+func ParseReviewAssignmentFilter(query url.Values) (domain.ReviewAssignmentFilter, error) {
+	filter := domain.ReviewAssignmentFilter{UserID: query.Get("user_id")}
+	if filter.UserID == "" {
+		return domain.ReviewAssignmentFilter{}, NewBadRequestError("VALIDATION_ERROR", "user_id обязателен")
+	}
+	for _, status := range splitList(query.Get("status")) {
+		filter.Statuses = append(filter.Statuses, domain.PRStatus(status))
+	}
+	for _, include := range splitList(query.Get("include")) {
+		if include != "reviewers" {
+			return domain.ReviewAssignmentFilter{}, NewBadRequestError("VALIDATION_ERROR", "include поддерживает только reviewers")
+		}
+		filter.IncludeReviewers = true
+	}
+	if raw := query.Get("cursor"); raw != "" {
+		createdAt, prID, err := service.DecodeReviewCursor(raw)
+		if err != nil {
+			return domain.ReviewAssignmentFilter{}, NewBadRequestError("VALIDATION_ERROR", "некорректный cursor")
+		}
+		filter.AfterCreatedAt, filter.AfterID = &createdAt, prID
+	}
+	var err error
+	if filter.Limit, err = parseLimit(query, service.MaxReviewAssignmentsLimit); err != nil {
+		return domain.ReviewAssignmentFilter{}, err
+	}
+	if service.ValidateReviewAssignmentFilter(filter) != nil {
+		return domain.ReviewAssignmentFilter{}, NewBadRequestError("VALIDATION_ERROR",
+			"status должен быть списком из DRAFT, OPEN, MERGED и CLOSED")
+	}
+	return filter, nil
+}
+
+// splitList разбирает значение query-параметра со списком через запятую, пропуская пустые элементы.
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,46 @@
+package common
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+func TestParseReviewAssignmentFilter(t *testing.T) {
+	created := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	cursor, err := service.EncodeReviewCursor(created, "pr-7")
+	require.NoError(t, err)
+	filter, err := ParseReviewAssignmentFilter(url.Values{
+		"user_id": {"u2"},
+		"status":  {"OPEN, MERGED"},
+		"include": {"reviewers"},
+		"cursor":  {cursor},
+		"limit":   {"20"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "u2", filter.UserID)
+	require.Equal(t, []domain.PRStatus{domain.PRStatusOpen, domain.PRStatusMerged}, filter.Statuses)
+	require.True(t, filter.IncludeReviewers)
+	require.True(t, created.Equal(*filter.AfterCreatedAt))
+	require.Equal(t, "pr-7", filter.AfterID)
+	require.Equal(t, 20, filter.Limit)
+}
+
+func TestParseReviewAssignmentFilterRejectsInvalid(t *testing.T) {
+	for name, query := range map[string]url.Values{
+		"missing user":   {},
+		"unknown status": {"user_id": {"u2"}, "status": {"OPEN,STALE"}},
+		"unknown expand": {"user_id": {"u2"}, "include": {"team"}},
+		"bad cursor":     {"user_id": {"u2"}, "cursor": {"%%%"}},
+		"limit too big":  {"user_id": {"u2"}, "limit": {"100000"}},
+	} {
+		_, err := ParseReviewAssignmentFilter(query)
+		var httpErr *HTTPError
+		require.ErrorAs(t, err, &httpErr, name)
+	}
+}
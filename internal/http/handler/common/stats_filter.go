@@ -0,0 +1,53 @@
+package common
+
+import (
+	"net/url"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+// ParseStatsFilter разбирает query-параметры фильтра статистики: from, to (RFC 3339), team_name и status.
+func ParseStatsFilter(query url.Values) (domain.StatsFilter, error) {
+	filter := domain.StatsFilter{
+		TeamName: query.Get("team_name"),
+		Status:   domain.PRStatus(query.Get("status")),
+	}
+	var err error
+	if filter.From, filter.To, err = parseTimeRange(query, "from", "to"); err != nil {
+		return domain.StatsFilter{}, err
+	}
+	if service.ValidateStatsFilter(domain.StatsFilter{Status: filter.Status}) != nil {
+		return domain.StatsFilter{}, NewBadRequestError("VALIDATION_ERROR", "status должен быть DRAFT, OPEN, MERGED или CLOSED")
+	}
+	if service.ValidateStatsFilter(filter) != nil {
+		return domain.StatsFilter{}, NewBadRequestError("VALIDATION_ERROR", "from должен быть раньше to")
+	}
+	return filter, nil
+}
+
+// ParseLatencyFilter разбирает query-параметры фильтра задержек ревью: from, to (RFC 3339) и team_name.
+func ParseLatencyFilter(query url.Values) (domain.LatencyFilter, error) {
+	filter := domain.LatencyFilter{TeamName: query.Get("team_name")}
+	var err error
+	if filter.From, filter.To, err = parseTimeRange(query, "from", "to"); err != nil {
+		return domain.LatencyFilter{}, err
+	}
+	if service.ValidateLatencyFilter(filter) != nil {
+		return domain.LatencyFilter{}, NewBadRequestError("VALIDATION_ERROR", "from должен быть раньше to")
+	}
+	return filter, nil
+}
+
+// ParseFairnessFilter разбирает query-параметры отчёта о равномерности назначений: from, to (RFC 3339) и team_name.
+func ParseFairnessFilter(query url.Values) (domain.FairnessFilter, error) {
+	filter := domain.FairnessFilter{TeamName: query.Get("team_name")}
+	var err error
+	if filter.From, filter.To, err = parseTimeRange(query, "from", "to"); err != nil {
+		return domain.FairnessFilter{}, err
+	}
+	if service.ValidateFairnessFilter(filter) != nil {
+		return domain.FairnessFilter{}, NewBadRequestError("VALIDATION_ERROR", "from должен быть раньше to")
+	}
+	return filter, nil
+}
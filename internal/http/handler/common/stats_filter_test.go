@@ -0,0 +1,50 @@
+package common
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestParseStatsFilter(t *testing.T) {
+	filter, err := ParseStatsFilter(url.Values{
+		"from":      {"2025-01-01T00:00:00Z"},
+		"to":        {"2025-01-15T00:00:00Z"},
+		"team_name": {"backend"},
+		"status":    {"MERGED"},
+	})
+	require.NoError(t, err)
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, domain.StatsFilter{From: &from, To: &to, TeamName: "backend", Status: domain.PRStatusMerged}, filter)
+}
+
+func TestParseStatsFilterRejectsInvalid(t *testing.T) {
+	for query, message := range map[string]string{
+		"status=DONE":    "status должен быть DRAFT, OPEN, MERGED или CLOSED",
+		"from=yesterday": "from должен быть в формате RFC 3339",
+		"from=2025-01-02T00:00:00Z&to=2025-01-01T00:00:00Z": "from должен быть раньше to",
+	} {
+		values, err := url.ParseQuery(query)
+		require.NoError(t, err)
+		_, err = ParseStatsFilter(values)
+		var httpErr *HTTPError
+		require.ErrorAs(t, err, &httpErr, query)
+		require.Equal(t, message, httpErr.Error(), query)
+	}
+}
+
+func TestParseLatencyFilter(t *testing.T) {
+	filter, err := ParseLatencyFilter(url.Values{"team_name": {"backend"}, "to": {"2025-01-15T00:00:00Z"}})
+	require.NoError(t, err)
+	to := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, domain.LatencyFilter{To: &to, TeamName: "backend"}, filter)
+
+	_, err = ParseLatencyFilter(url.Values{"from": {"2025-01-15T00:00:00Z"}, "to": {"2025-01-15T00:00:00Z"}})
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+}
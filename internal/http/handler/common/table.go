@@ -0,0 +1,115 @@
+package common
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Табличные форматы ответа, выбираемые заголовком Accept.
+const (
+	FormatJSON   = ""
+	FormatCSV    = "text/csv"
+	FormatNDJSON = "application/x-ndjson"
+)
+
+// flushEvery — через сколько строк табличный ответ отправляется клиенту, не дожидаясь конца.
+const flushEvery = 500
+
+// NegotiateFormat выбирает формат ответа по заголовку Accept: первый из перечисленных text/csv
+// или application/x-ndjson. Остальные типы, в том числе */*, означают JSON.
+func NegotiateFormat(r *http.Request) string {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case FormatCSV, FormatNDJSON:
+			return mediaType
+		}
+	}
+	return FormatJSON
+}
+
+// TableWriter построчно пишет ответ в CSV или NDJSON. Статус и заголовки отправляются с первой строкой,
+// поэтому ошибку до неё можно вернуть обычным ответом (см. Close).
+type TableWriter[T any] struct {
+	w       http.ResponseWriter
+	format  string
+	header  []string
+	record  func(T) []string
+	csv     *csv.Writer
+	encoder *json.Encoder
+	written int
+}
+
+// NewTableWriter создаёт писатель строк в формате format (FormatCSV или FormatNDJSON).
+// Для CSV header — первая строка, а record превращает строку в поля; для NDJSON строка кодируется целиком.
+func NewTableWriter[T any](w http.ResponseWriter, format string, header []string, record func(T) []string) *TableWriter[T] {
+	return &TableWriter[T]{w: w, format: format, header: header, record: record}
+}
+
+// Write записывает строку.
+func (t *TableWriter[T]) Write(row T) error {
+	if t.written == 0 {
+		if err := t.start(); err != nil {
+			return err
+		}
+	}
+	t.written++
+	if t.format == FormatCSV {
+		if err := t.csv.Write(t.record(row)); err != nil {
+			return err
+		}
+	} else if err := t.encoder.Encode(row); err != nil {
+		return err
+	}
+	if t.written%flushEvery == 0 {
+		t.flush()
+	}
+	return nil
+}
+
+// Close завершает ответ. Ошибка выгрузки до первой строки возвращается для обычного ответа с ошибкой;
+// после первой строки статус уже отправлен, и обрыв выгрузки только логируется.
+func (t *TableWriter[T]) Close(ctx context.Context, err error) error {
+	if t.written == 0 {
+		if err != nil {
+			return err
+		}
+		if err := t.start(); err != nil {
+			return err
+		}
+	} else if err != nil {
+		slog.ErrorContext(ctx, "table export interrupted", "format", t.format, "written", t.written, "error", err)
+	}
+	t.flush()
+	return nil
+}
+
+func (t *TableWriter[T]) start() error {
+	if t.format != FormatCSV {
+		t.w.Header().Set("Content-Type", t.format)
+		t.w.WriteHeader(http.StatusOK)
+		t.encoder = json.NewEncoder(t.w)
+		return nil
+	}
+	t.w.Header().Set("Content-Type", t.format+"; charset=utf-8")
+	t.w.WriteHeader(http.StatusOK)
+	t.csv = csv.NewWriter(t.w)
+	return t.csv.Write(t.header)
+}
+
+func (t *TableWriter[T]) flush() {
+	if t.csv != nil {
+		t.csv.Flush()
+	}
+	if flusher, ok := t.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,73 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tableRow struct {
+	Name  string `json:"name"`
+	Count string `json:"count"`
+}
+
+func tableRecord(row tableRow) []string {
+	return []string{row.Name, row.Count}
+}
+
+func TestNegotiateFormat(t *testing.T) {
+	for accept, format := range map[string]string{
+		"":                                     FormatJSON,
+		"*/*":                                  FormatJSON,
+		"application/json":                     FormatJSON,
+		"text/csv":                             FormatCSV,
+		"application/x-ndjson":                 FormatNDJSON,
+		"text/html, text/csv;q=0.9, */*;q=0.1": FormatCSV,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", accept)
+		require.Equal(t, format, NegotiateFormat(req), accept)
+	}
+}
+
+func TestTableWriterCSV(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rows := NewTableWriter(rec, FormatCSV, []string{"name", "count"}, tableRecord)
+
+	require.NoError(t, rows.Write(tableRow{Name: "a,b", Count: "1"}))
+	require.NoError(t, rows.Write(tableRow{Name: "c", Count: "2"}))
+	require.NoError(t, rows.Close(context.Background(), nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	require.Equal(t, "name,count\n\"a,b\",1\nc,2\n", rec.Body.String())
+}
+
+func TestTableWriterNDJSONEmpty(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rows := NewTableWriter(rec, FormatNDJSON, nil, tableRecord)
+
+	require.NoError(t, rows.Close(context.Background(), nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, FormatNDJSON, rec.Header().Get("Content-Type"))
+	require.Empty(t, rec.Body.String())
+}
+
+func TestTableWriterErrors(t *testing.T) {
+	failure := errors.New("boom")
+
+	rec := httptest.NewRecorder()
+	rows := NewTableWriter(rec, FormatNDJSON, nil, tableRecord)
+	require.ErrorIs(t, rows.Close(context.Background(), failure), failure)
+
+	rec = httptest.NewRecorder()
+	rows = NewTableWriter(rec, FormatNDJSON, nil, tableRecord)
+	require.NoError(t, rows.Write(tableRow{Name: "a"}))
+	require.NoError(t, rows.Close(context.Background(), failure))
+	require.Equal(t, "{\"name\":\"a\",\"count\":\"\"}\n", rec.Body.String())
+}
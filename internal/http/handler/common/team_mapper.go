@@ -9,31 +9,47 @@ import (
 func ToDomainTeam(req api.Team) domain.Team {
 	members := make([]domain.User, 0, len(req.Members))
 	for _, member := range req.Members {
-		members = append(members, domain.User{
+		user := domain.User{
 			ID:       member.UserId,
 			Username: member.Username,
 			IsActive: member.IsActive,
 			TeamName: req.TeamName,
-		})
+		}
+		if member.Role != nil {
+			user.Role = *member.Role
+		}
+		members = append(members, user)
 	}
-	return domain.Team{
+	team := domain.Team{
 		Name:    req.TeamName,
 		Members: members,
 	}
+	if req.RequiredReviewers != nil {
+		team.RequiredReviewers = *req.RequiredReviewers
+	}
+	return team
 }
 
 // FromDomainTeam преобразует domain entity в API DTO.
 func FromDomainTeam(team domain.Team) api.Team {
 	members := make([]api.TeamMember, 0, len(team.Members))
 	for _, member := range team.Members {
-		members = append(members, api.TeamMember{
+		apiMember := api.TeamMember{
 			UserId:   member.ID,
 			Username: member.Username,
 			IsActive: member.IsActive,
-		})
+		}
+		if member.Role != "" {
+			apiMember.Role = &member.Role
+		}
+		members = append(members, apiMember)
 	}
-	return api.Team{
+	result := api.Team{
 		TeamName: team.Name,
 		Members:  members,
 	}
+	if team.RequiredReviewers > 0 {
+		result.RequiredReviewers = &team.RequiredReviewers
+	}
+	return result
 }
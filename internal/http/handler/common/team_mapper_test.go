@@ -22,3 +22,33 @@ func TestTeamMappingRoundtrip(t *testing.T) {
 	require.Equal(t, apiTeam.TeamName, back.TeamName)
 	require.Len(t, back.Members, 1)
 }
+
+func TestTeamMappingMemberRole(t *testing.T) {
+	role := "lead"
+	domainTeam := ToDomainTeam(api.Team{
+		TeamName: "backend",
+		Members: []api.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true, Role: &role},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+		},
+	})
+	require.Equal(t, "lead", domainTeam.Members[0].Role)
+	require.Empty(t, domainTeam.Members[1].Role)
+
+	back := FromDomainTeam(domainTeam)
+	require.Equal(t, &role, back.Members[0].Role)
+	require.Nil(t, back.Members[1].Role)
+}
+
+func TestTeamMappingRequiredReviewers(t *testing.T) {
+	required := 3
+	domainTeam := ToDomainTeam(api.Team{TeamName: "platform", RequiredReviewers: &required})
+	require.Equal(t, 3, domainTeam.RequiredReviewers)
+
+	back := FromDomainTeam(domainTeam)
+	require.NotNil(t, back.RequiredReviewers)
+	require.Equal(t, 3, *back.RequiredReviewers)
+
+	// Незаданное значение не попадает в ответ
+	require.Nil(t, FromDomainTeam(ToDomainTeam(api.Team{TeamName: "backend"})).RequiredReviewers)
+}
@@ -0,0 +1,11 @@
+package eventsexport
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	ExportEvents(ctx context.Context, filter domain.EventFilter, fn func(domain.AssignmentEvent) error) error
+}
@@ -0,0 +1,33 @@
+package eventsexport
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+)
+
+// Handler реализует GET /events/export: выгрузку журнала назначений в NDJSON.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Get("/export", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	filter, err := common.ParseEventFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	rows := common.NewTableWriter[domain.AssignmentEvent](w, common.FormatNDJSON, nil, nil)
+	err = h.useCase.ExportEvents(r.Context(), filter, rows.Write)
+	return rows.Close(r.Context(), err)
+}
@@ -0,0 +1,77 @@
+package eventsexport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	events []domain.AssignmentEvent
+	err    error
+	filter domain.EventFilter
+}
+
+func (s *stubUseCase) ExportEvents(ctx context.Context, filter domain.EventFilter, fn func(domain.AssignmentEvent) error) error {
+	s.filter = filter
+	for _, event := range s.events {
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return s.err
+}
+
+func TestHandler_StreamsNDJSON(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{events: []domain.AssignmentEvent{
+		{ID: 1, PullRequestID: "pr-1", EventType: domain.EventAssigned},
+		{ID: 2, PullRequestID: "pr-1", EventType: domain.EventMerged},
+	}}
+	router := chi.NewRouter()
+	router.Route("/events", New(useCase).Register)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events/export?pull_request_id=pr-1", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	require.Equal(t, "pr-1", useCase.filter.PullRequestID)
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[1], `"event_type":"MERGED"`)
+}
+
+func TestHandler_EmptyExport(t *testing.T) {
+	t.Parallel()
+
+	router := chi.NewRouter()
+	router.Route("/events", New(&stubUseCase{}).Register)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events/export", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Body.String())
+}
+
+func TestHandler_ErrorBeforeFirstRow(t *testing.T) {
+	t.Parallel()
+
+	router := chi.NewRouter()
+	router.Route("/events", New(&stubUseCase{err: errors.New("db down")}).Register)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events/export", nil))
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+}
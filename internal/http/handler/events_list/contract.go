@@ -0,0 +1,11 @@
+package eventslist
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	ListEvents(ctx context.Context, filter domain.EventFilter) (domain.EventPage, error)
+}
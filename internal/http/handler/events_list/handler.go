@@ -0,0 +1,35 @@
+package eventslist
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+)
+
+// Handler реализует GET /events.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Get("/", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	filter, err := common.ParseEventFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	page, err := h.useCase.ListEvents(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, page)
+	return nil
+}
@@ -0,0 +1,78 @@
+package eventslist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+type stubUseCase struct {
+	filter domain.EventFilter
+	page   domain.EventPage
+}
+
+func (s *stubUseCase) ListEvents(ctx context.Context, filter domain.EventFilter) (domain.EventPage, error) {
+	s.filter = filter
+	return s.page, nil
+}
+
+func TestHandler_PassesFilters(t *testing.T) {
+	t.Parallel()
+
+	reviewer := "u2"
+	nextCursor, err := service.EncodeEventCursor(5)
+	require.NoError(t, err)
+	afterCursor, err := service.EncodeEventCursor(4)
+	require.NoError(t, err)
+	useCase := &stubUseCase{page: domain.EventPage{
+		Events: []domain.AssignmentEvent{{ID: 5, PullRequestID: "pr-1", ReviewerID: &reviewer,
+			EventType: domain.EventAssigned, Source: "AUTO", TeamName: "backend",
+			CreatedAt: time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)}},
+		NextCursor: nextCursor,
+	}}
+	router := chi.NewRouter()
+	router.Route("/events", New(useCase).Register)
+
+	req := httptest.NewRequest(http.MethodGet, "/events?pull_request_id=pr-1&reviewer_id=u2&team_name=backend"+
+		"&event_type=ASSIGNED&source=AUTO&from=2025-01-01T00:00:00Z&to=2025-02-01T00:00:00Z&limit=1&cursor="+
+		afterCursor, nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	from, to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, domain.EventFilter{
+		PullRequestID: "pr-1", ReviewerID: "u2", TeamName: "backend", EventType: domain.EventAssigned, Source: "AUTO",
+		From: &from, To: &to, AfterID: 4, Limit: 1,
+	}, useCase.filter)
+	require.JSONEq(t, `{"events":[{"event_id":5,"pull_request_id":"pr-1","reviewer_id":"u2","event_type":"ASSIGNED",
+		"source":"AUTO","team_name":"backend","created_at":"2025-01-10T12:00:00Z"}],"next_cursor":"`+
+		nextCursor+`"}`, rec.Body.String())
+}
+
+func TestHandler_ValidatesQuery(t *testing.T) {
+	t.Parallel()
+
+	router := chi.NewRouter()
+	router.Route("/events", New(&stubUseCase{}).Register)
+
+	for _, query := range []string{
+		"event_type=PUSHED",
+		"from=yesterday",
+		"from=2025-02-01T00:00:00Z&to=2025-01-01T00:00:00Z",
+		"cursor=***",
+		"limit=0",
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events?"+query, nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
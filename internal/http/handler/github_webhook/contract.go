@@ -0,0 +1,12 @@
+package githubwebhook
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+type UseCase interface {
+	VerifyGitHubSignature(body []byte, signature string) bool
+	HandleGitHubEvent(ctx context.Context, event service.GitHubEvent) (service.GitHubOutcome, error)
+}
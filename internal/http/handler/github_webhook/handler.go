@@ -0,0 +1,96 @@
+package githubwebhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+// Заголовки webhook'а GitHub.
+const (
+	HeaderEvent     = "X-GitHub-Event"
+	HeaderDelivery  = "X-GitHub-Delivery"
+	HeaderSignature = "X-Hub-Signature-256"
+)
+
+// maxBodySize — предельный размер тела, который GitHub отправляет в webhook'е.
+const maxBodySize = 25 << 20
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+// payload содержит поля событий pull_request и pull_request_review, которые использует сервис.
+type payload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number int        `json:"number"`
+		Title  string     `json:"title"`
+		Draft  bool       `json:"draft"`
+		Merged bool       `json:"merged"`
+		User   githubUser `json:"user"`
+	} `json:"pull_request"`
+	Review struct {
+		State string     `json:"state"`
+		User  githubUser `json:"user"`
+	} `json:"review"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// Handler реализует POST /integrations/github/webhook.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Post("/webhook", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		return common.NewBadRequestError("INVALID_BODY", "не удалось прочитать тело запроса")
+	}
+	// Подпись проверяется до разбора тела: неподписанный запрос не должен влиять на сервис
+	if !h.useCase.VerifyGitHubSignature(body, r.Header.Get(HeaderSignature)) {
+		return common.NewHTTPError(http.StatusUnauthorized, "INVALID_SIGNATURE", "подпись X-Hub-Signature-256 не совпадает")
+	}
+	event := service.GitHubEvent{
+		DeliveryID: r.Header.Get(HeaderDelivery),
+		Event:      r.Header.Get(HeaderEvent),
+	}
+	if event.DeliveryID == "" || event.Event == "" {
+		return common.NewBadRequestError("VALIDATION_ERROR", "заголовки X-GitHub-Event и X-GitHub-Delivery обязательны")
+	}
+	var p payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return common.NewBadRequestError("INVALID_BODY", "не удалось прочитать тело запроса")
+	}
+	event.Action = p.Action
+	event.Repository = p.Repository.FullName
+	event.Number = p.PullRequest.Number
+	event.Title = p.PullRequest.Title
+	event.AuthorLogin = p.PullRequest.User.Login
+	event.Draft = p.PullRequest.Draft
+	event.Merged = p.PullRequest.Merged
+	event.ReviewerLogin = p.Review.User.Login
+	event.ReviewState = p.Review.State
+
+	outcome, err := h.useCase.HandleGitHubEvent(r.Context(), event)
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, map[string]service.GitHubOutcome{"status": outcome})
+	return nil
+}
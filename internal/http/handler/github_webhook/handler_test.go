@@ -0,0 +1,142 @@
+package githubwebhook
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+type stubUseCase struct {
+	valid   bool
+	outcome service.GitHubOutcome
+	err     error
+	event   service.GitHubEvent
+	body    []byte
+}
+
+func (s *stubUseCase) VerifyGitHubSignature(body []byte, signature string) bool {
+	s.body = body
+	return s.valid && signature == "sha256=ok"
+}
+
+func (s *stubUseCase) HandleGitHubEvent(ctx context.Context, event service.GitHubEvent) (service.GitHubOutcome, error) {
+	s.event = event
+	return s.outcome, s.err
+}
+
+func newRequest(body, event, signature string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(body))
+	req.Header.Set(HeaderEvent, event)
+	req.Header.Set(HeaderDelivery, "d-1")
+	req.Header.Set(HeaderSignature, signature)
+	return req
+}
+
+func TestHandler_RejectsInvalidSignature(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{valid: true}
+	router := chi.NewRouter()
+	New(useCase).Register(router)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest(`{"action":"opened"}`, "pull_request", "sha256=bad"))
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Contains(t, rec.Body.String(), "INVALID_SIGNATURE")
+	require.Empty(t, useCase.event.DeliveryID)
+}
+
+func TestHandler_MapsPullRequestPayload(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{valid: true, outcome: service.GitHubOutcomeProcessed}
+	router := chi.NewRouter()
+	New(useCase).Register(router)
+
+	body := `{"action":"closed","pull_request":{"number":12,"title":"Fix","draft":false,"merged":true,
+		"user":{"login":"alice"}},"repository":{"full_name":"acme/api"}}`
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest(body, "pull_request", "sha256=ok"))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"status":"PROCESSED"}`, rec.Body.String())
+	require.Equal(t, body, string(useCase.body))
+	require.Equal(t, service.GitHubEvent{
+		DeliveryID:  "d-1",
+		Event:       "pull_request",
+		Action:      "closed",
+		Repository:  "acme/api",
+		Number:      12,
+		Title:       "Fix",
+		AuthorLogin: "alice",
+		Merged:      true,
+	}, useCase.event)
+}
+
+func TestHandler_MapsReviewPayload(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{valid: true, outcome: service.GitHubOutcomeDuplicate}
+	router := chi.NewRouter()
+	New(useCase).Register(router)
+
+	body := `{"action":"submitted","review":{"state":"approved","user":{"login":"bob"}},
+		"pull_request":{"number":12,"user":{"login":"alice"}},"repository":{"full_name":"acme/api"}}`
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest(body, "pull_request_review", "sha256=ok"))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"status":"DUPLICATE"}`, rec.Body.String())
+	require.Equal(t, "bob", useCase.event.ReviewerLogin)
+	require.Equal(t, "approved", useCase.event.ReviewState)
+}
+
+func TestHandler_RequiresDeliveryHeaders(t *testing.T) {
+	t.Parallel()
+
+	router := chi.NewRouter()
+	New(&stubUseCase{valid: true}).Register(router)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest(`{}`, "", "sha256=ok"))
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_MapsDomainErrors(t *testing.T) {
+	t.Parallel()
+
+	router := chi.NewRouter()
+	New(&stubUseCase{valid: true, err: domain.ErrPRMerged}).Register(router)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest(`{"action":"reopened"}`, "pull_request", "sha256=ok"))
+
+	require.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestHandler_AnswersIgnoredForUnknownPullRequest(t *testing.T) {
+	t.Parallel()
+
+	// Сервис игнорирует события о PR, которых в нём нет, и GitHub получает 200, а не 404
+	useCase := &stubUseCase{valid: true, outcome: service.GitHubOutcomeIgnored}
+	router := chi.NewRouter()
+	New(useCase).Register(router)
+
+	body := `{"action":"closed","pull_request":{"number":7,"user":{"login":"alice"}},"repository":{"full_name":"acme/api"}}`
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest(body, "pull_request", "sha256=ok"))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"status":"IGNORED"}`, rec.Body.String())
+	require.Equal(t, "closed", useCase.event.Action)
+}
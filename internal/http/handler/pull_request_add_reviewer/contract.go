@@ -0,0 +1,11 @@
+package pullrequestaddreviewer
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	AddReviewer(ctx context.Context, prID, reviewerID string) (domain.PullRequest, string, error)
+}
@@ -0,0 +1,48 @@
+package pullrequestaddreviewer
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+)
+
+type request struct {
+	PRID string `json:"pull_request_id"`
+	// ReviewerID — добавляемый ревьювер; если не задан, он выбирается автоматически
+	ReviewerID string `json:"reviewer_id,omitempty"`
+}
+
+// Handler реализует POST /pullRequest/addReviewer.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Post("/addReviewer", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return common.NewBadRequestError("INVALID_BODY", "не удалось прочитать тело запроса")
+	}
+	if req.PRID == "" {
+		return common.NewBadRequestError("VALIDATION_ERROR", "pull_request_id обязателен")
+	}
+	pr, added, err := h.useCase.AddReviewer(r.Context(), req.PRID, req.ReviewerID)
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, map[string]any{
+		"pr":          pr,
+		"reviewer_id": added,
+	})
+	return nil
+}
@@ -0,0 +1,88 @@
+package pullrequestaddreviewer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	prID     string
+	reviewer string
+}
+
+func (s *stubUseCase) AddReviewer(ctx context.Context, prID, reviewerID string) (domain.PullRequest, string, error) {
+	s.prID = prID
+	s.reviewer = reviewerID
+	if reviewerID == "" {
+		reviewerID = "u9"
+	}
+	return domain.PullRequest{ID: prID, AssignedReviewers: []string{reviewerID}}, reviewerID, nil
+}
+
+func TestHandler_ValidatesRequest(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/addReviewer", bytes.NewBufferString(`{}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_PassesReviewer(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	payload, err := json.Marshal(request{PRID: "pr-1", ReviewerID: "u3"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/addReviewer", bytes.NewReader(payload))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "pr-1", useCase.prID)
+	require.Equal(t, "u3", useCase.reviewer)
+}
+
+func TestHandler_AutoPick(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/addReviewer", bytes.NewBufferString(`{"pull_request_id":"pr-1"}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, useCase.reviewer)
+
+	var body struct {
+		ReviewerID string `json:"reviewer_id"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "u9", body.ReviewerID)
+}
@@ -0,0 +1,11 @@
+package pullrequestclose
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	ClosePullRequest(ctx context.Context, prID string) (domain.PullRequest, error)
+}
@@ -7,5 +7,5 @@ import (
 )
 
 type UseCase interface {
-	CreatePullRequest(ctx context.Context, prID, name, authorID string) (domain.PullRequest, error)
+	CreatePullRequest(ctx context.Context, prID, name, authorID string, draft bool) (domain.PullRequest, error)
 }
@@ -14,6 +14,8 @@ type request struct {
 	ID     string `json:"pull_request_id"`
 	Name   string `json:"pull_request_name"`
 	Author string `json:"author_id"`
+	// Draft создаёт черновик без ревьюверов
+	Draft bool `json:"draft"`
 }
 
 // Handler реализует POST /pullRequest/create.
@@ -37,7 +39,7 @@ func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
 	if req.ID == "" || req.Name == "" || req.Author == "" {
 		return common.NewBadRequestError("VALIDATION_ERROR", "все поля обязательны")
 	}
-	pr, err := h.useCase.CreatePullRequest(r.Context(), req.ID, req.Name, req.Author)
+	pr, err := h.useCase.CreatePullRequest(r.Context(), req.ID, req.Name, req.Author, req.Draft)
 	if err != nil {
 		return err
 	}
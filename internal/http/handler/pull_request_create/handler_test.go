@@ -19,13 +19,15 @@ type stubUseCase struct {
 		id     string
 		name   string
 		author string
+		draft  bool
 	}
 }
 
-func (s *stubUseCase) CreatePullRequest(ctx context.Context, prID, name, authorID string) (domain.PullRequest, error) {
+func (s *stubUseCase) CreatePullRequest(ctx context.Context, prID, name, authorID string, draft bool) (domain.PullRequest, error) {
 	s.args.id = prID
 	s.args.name = name
 	s.args.author = authorID
+	s.args.draft = draft
 	return domain.PullRequest{ID: prID, Name: name, AuthorID: authorID}, nil
 }
 
@@ -64,4 +66,23 @@ func TestHandler_PassesPayload(t *testing.T) {
 	require.Equal(t, "pr-1", useCase.args.id)
 	require.Equal(t, "Feature", useCase.args.name)
 	require.Equal(t, "u1", useCase.args.author)
+	require.False(t, useCase.args.draft)
+}
+
+func TestHandler_PassesDraftFlag(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/create",
+		bytes.NewBufferString(`{"pull_request_id":"pr-1","pull_request_name":"Feature","author_id":"u1","draft":true}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.True(t, useCase.args.draft)
 }
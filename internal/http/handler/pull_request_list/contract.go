@@ -0,0 +1,11 @@
+package pullrequestlist
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	ListPullRequests(ctx context.Context, filter domain.PullRequestFilter) (domain.PullRequestPage, error)
+}
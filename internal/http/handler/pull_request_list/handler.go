@@ -0,0 +1,36 @@
+package pullrequestlist
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+)
+
+// Handler реализует GET /pullRequest/list: страницу PR с фильтрами по статусу, автору, команде автора,
+// ревьюверу и интервалам создания и merge, поиском по названию и сортировкой.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Get("/list", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	filter, err := common.ParsePullRequestFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	page, err := h.useCase.ListPullRequests(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, page)
+	return nil
+}
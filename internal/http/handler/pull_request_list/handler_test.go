@@ -0,0 +1,79 @@
+package pullrequestlist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	filter domain.PullRequestFilter
+}
+
+func (s *stubUseCase) ListPullRequests(ctx context.Context, filter domain.PullRequestFilter) (domain.PullRequestPage, error) {
+	s.filter = filter
+	mergedAt := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	return domain.PullRequestPage{
+		PullRequests: []domain.PullRequestShort{{ID: "pr-1", Status: domain.PRStatusMerged, MergedAt: &mergedAt, AssignedReviewers: []string{"u2"}}},
+		NextCursor:   "next",
+	}, nil
+}
+
+func TestHandler_PassesFilter(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/list?status=MERGED&team_name=backend&q=fix&sort=-pull_request_name&limit=10", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, []domain.PRStatus{domain.PRStatusMerged}, useCase.filter.Statuses)
+	require.Equal(t, "backend", useCase.filter.TeamName)
+	require.Equal(t, "fix", useCase.filter.Query)
+	require.Equal(t, domain.PullRequestSortName, useCase.filter.Sort)
+	require.True(t, useCase.filter.Desc)
+	require.Equal(t, 10, useCase.filter.Limit)
+
+	var resp struct {
+		PullRequests []struct {
+			ID                string   `json:"pull_request_id"`
+			MergedAt          string   `json:"mergedAt"`
+			AssignedReviewers []string `json:"assigned_reviewers"`
+		} `json:"pull_requests"`
+		NextCursor string `json:"next_cursor"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, "pr-1", resp.PullRequests[0].ID)
+	require.Equal(t, "2025-01-02T10:00:00Z", resp.PullRequests[0].MergedAt)
+	require.Equal(t, []string{"u2"}, resp.PullRequests[0].AssignedReviewers)
+	require.Equal(t, "next", resp.NextCursor)
+}
+
+func TestHandler_RejectsInvalidFilter(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/list?reviewer_id=u1&without_reviewers=true", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -16,10 +16,14 @@ import (
 
 type stubUseCase struct {
 	prID string
+	err  error
 }
 
 func (s *stubUseCase) MergePullRequest(ctx context.Context, prID string) (domain.PullRequest, error) {
 	s.prID = prID
+	if s.err != nil {
+		return domain.PullRequest{}, s.err
+	}
 	return domain.PullRequest{ID: prID}, nil
 }
 
@@ -57,3 +61,29 @@ func TestHandler_PassesID(t *testing.T) {
 	require.Equal(t, http.StatusOK, rec.Code)
 	require.Equal(t, "pr-1", useCase.prID)
 }
+
+func TestHandler_ReportsUnmetMergePolicy(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{err: &domain.MergePolicyError{Unmet: []domain.UnmetMergeCondition{
+		{Code: domain.MergeConditionChangesRequested, Message: "changes requested by u3"},
+	}}})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/merge", bytes.NewBufferString(`{"pull_request_id":"pr-1"}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusConflict, rec.Code)
+	var body struct {
+		Error struct {
+			Code            string                       `json:"code"`
+			UnmetConditions []domain.UnmetMergeCondition `json:"unmet_conditions"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "MERGE_POLICY_NOT_MET", body.Error.Code)
+	require.Equal(t, domain.MergeConditionChangesRequested, body.Error.UnmetConditions[0].Code)
+}
@@ -0,0 +1,11 @@
+package pullrequestready
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	MarkReadyForReview(ctx context.Context, prID string) (domain.PullRequest, error)
+}
@@ -0,0 +1,79 @@
+package pullrequestready
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	prID string
+	err  error
+}
+
+func (s *stubUseCase) MarkReadyForReview(ctx context.Context, prID string) (domain.PullRequest, error) {
+	s.prID = prID
+	if s.err != nil {
+		return domain.PullRequest{}, s.err
+	}
+	return domain.PullRequest{ID: prID}, nil
+}
+
+func TestHandler_ValidatesRequest(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/ready", bytes.NewBufferString(`{}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_PassesID(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	payload, err := json.Marshal(request{ID: "pr-1"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/ready", bytes.NewReader(payload))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "pr-1", useCase.prID)
+}
+
+func TestHandler_RejectsClosed(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{err: domain.ErrPRClosed})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/ready", bytes.NewBufferString(`{"pull_request_id":"pr-1"}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusConflict, rec.Code)
+	require.Contains(t, rec.Body.String(), "PR_CLOSED")
+}
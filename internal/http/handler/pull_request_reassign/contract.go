@@ -7,5 +7,5 @@ import (
 )
 
 type UseCase interface {
-	ReassignReviewer(ctx context.Context, prID, oldReviewer string) (domain.PullRequest, string, error)
+	ReassignReviewer(ctx context.Context, prID, oldReviewer, newReviewer string) (domain.PullRequest, string, error)
 }
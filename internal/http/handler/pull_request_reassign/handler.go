@@ -12,6 +12,8 @@ import (
 type request struct {
 	PRID      string `json:"pull_request_id"`
 	OldUserID string `json:"old_user_id"`
+	// NewUserID — ревьювер, которому передаётся ревью; если не задан, он выбирается автоматически
+	NewUserID string `json:"new_reviewer_id,omitempty"`
 }
 
 // Handler реализует POST /pullRequest/reassign.
@@ -35,7 +37,7 @@ func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
 	if req.PRID == "" || req.OldUserID == "" {
 		return common.NewBadRequestError("VALIDATION_ERROR", "pull_request_id и old_user_id обязательны")
 	}
-	pr, replacedBy, err := h.useCase.ReassignReviewer(r.Context(), req.PRID, req.OldUserID)
+	pr, replacedBy, err := h.useCase.ReassignReviewer(r.Context(), req.PRID, req.OldUserID, req.NewUserID)
 	if err != nil {
 		return err
 	}
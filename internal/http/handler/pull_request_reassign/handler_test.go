@@ -15,13 +15,15 @@ import (
 )
 
 type stubUseCase struct {
-	prID     string
-	reviewer string
+	prID        string
+	reviewer    string
+	newReviewer string
 }
 
-func (s *stubUseCase) ReassignReviewer(ctx context.Context, prID, oldReviewer string) (domain.PullRequest, string, error) {
+func (s *stubUseCase) ReassignReviewer(ctx context.Context, prID, oldReviewer, newReviewer string) (domain.PullRequest, string, error) {
 	s.prID = prID
 	s.reviewer = oldReviewer
+	s.newReviewer = newReviewer
 	return domain.PullRequest{ID: prID}, "u2", nil
 }
 
@@ -59,4 +61,25 @@ func TestHandler_PassesArgs(t *testing.T) {
 	require.Equal(t, http.StatusOK, rec.Code)
 	require.Equal(t, "pr-1", useCase.prID)
 	require.Equal(t, "u1", useCase.reviewer)
+	require.Empty(t, useCase.newReviewer)
+}
+
+func TestHandler_PassesNewReviewer(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	payload, err := json.Marshal(request{PRID: "pr-1", OldUserID: "u1", NewUserID: "u3"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/reassign", bytes.NewReader(payload))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "u3", useCase.newReviewer)
 }
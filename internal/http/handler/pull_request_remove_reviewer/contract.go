@@ -0,0 +1,11 @@
+package pullrequestremovereviewer
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	RemoveReviewer(ctx context.Context, prID, reviewerID string) (domain.PullRequest, error)
+}
@@ -0,0 +1,44 @@
+package pullrequestremovereviewer
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+)
+
+type request struct {
+	PRID       string `json:"pull_request_id"`
+	ReviewerID string `json:"reviewer_id"`
+}
+
+// Handler реализует POST /pullRequest/removeReviewer.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Post("/removeReviewer", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return common.NewBadRequestError("INVALID_BODY", "не удалось прочитать тело запроса")
+	}
+	if req.PRID == "" || req.ReviewerID == "" {
+		return common.NewBadRequestError("VALIDATION_ERROR", "pull_request_id и reviewer_id обязательны")
+	}
+	pr, err := h.useCase.RemoveReviewer(r.Context(), req.PRID, req.ReviewerID)
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, map[string]any{"pr": pr})
+	return nil
+}
@@ -0,0 +1,78 @@
+package pullrequestremovereviewer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	prID     string
+	reviewer string
+	err      error
+}
+
+func (s *stubUseCase) RemoveReviewer(ctx context.Context, prID, reviewerID string) (domain.PullRequest, error) {
+	s.prID = prID
+	s.reviewer = reviewerID
+	return domain.PullRequest{ID: prID}, s.err
+}
+
+func TestHandler_ValidatesRequest(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/removeReviewer", bytes.NewBufferString(`{"pull_request_id":"pr-1"}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_PassesArgs(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	payload, err := json.Marshal(request{PRID: "pr-1", ReviewerID: "u1"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/removeReviewer", bytes.NewReader(payload))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "pr-1", useCase.prID)
+	require.Equal(t, "u1", useCase.reviewer)
+}
+
+func TestHandler_MapsMergedPR(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{err: domain.ErrPRMerged})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/removeReviewer", bytes.NewBufferString(`{"pull_request_id":"pr-1","reviewer_id":"u1"}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusConflict, rec.Code)
+}
@@ -0,0 +1,11 @@
+package pullrequestreopen
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	ReopenPullRequest(ctx context.Context, prID string) (domain.PullRequest, error)
+}
@@ -0,0 +1,11 @@
+package pullrequestreview
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	SubmitReview(ctx context.Context, prID, reviewerID string, decision domain.ReviewDecision) (domain.PullRequest, error)
+}
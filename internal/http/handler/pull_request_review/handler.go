@@ -0,0 +1,50 @@
+package pullrequestreview
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+type request struct {
+	PRID       string                `json:"pull_request_id"`
+	ReviewerID string                `json:"reviewer_id"`
+	Decision   domain.ReviewDecision `json:"decision"`
+}
+
+// Handler реализует POST /pullRequest/review.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Post("/review", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return common.NewBadRequestError("INVALID_BODY", "не удалось прочитать тело запроса")
+	}
+	if req.PRID == "" || req.ReviewerID == "" {
+		return common.NewBadRequestError("VALIDATION_ERROR", "pull_request_id и reviewer_id обязательны")
+	}
+	if service.ValidateReviewDecision(req.Decision) != nil {
+		return common.NewBadRequestError("VALIDATION_ERROR", "decision должен быть APPROVED, CHANGES_REQUESTED или COMMENTED")
+	}
+	pr, err := h.useCase.SubmitReview(r.Context(), req.PRID, req.ReviewerID, req.Decision)
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, map[string]domain.PullRequest{"pr": pr})
+	return nil
+}
@@ -0,0 +1,104 @@
+package pullrequestreview
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	prID     string
+	reviewer string
+	decision domain.ReviewDecision
+	err      error
+}
+
+func (s *stubUseCase) SubmitReview(ctx context.Context, prID, reviewerID string, decision domain.ReviewDecision) (domain.PullRequest, error) {
+	s.prID = prID
+	s.reviewer = reviewerID
+	s.decision = decision
+	if s.err != nil {
+		return domain.PullRequest{}, s.err
+	}
+	return domain.PullRequest{
+		ID:                prID,
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{reviewerID},
+		Decisions:         []domain.Review{{ReviewerID: reviewerID, Decision: decision}},
+	}, nil
+}
+
+func TestHandler_ValidatesRequest(t *testing.T) {
+	t.Parallel()
+
+	cases := []string{
+		`{}`,
+		`{"pull_request_id":"pr-1","decision":"APPROVED"}`,
+		`{"pull_request_id":"pr-1","reviewer_id":"u2","decision":"LGTM"}`,
+	}
+	for _, body := range cases {
+		useCase := &stubUseCase{}
+		handler := New(useCase)
+		router := chi.NewRouter()
+		handler.Register(router)
+
+		req := httptest.NewRequest(http.MethodPost, "/review", bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code, body)
+		require.Empty(t, useCase.prID)
+	}
+}
+
+func TestHandler_PassesDecision(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	body := `{"pull_request_id":"pr-1","reviewer_id":"u2","decision":"CHANGES_REQUESTED"}`
+	req := httptest.NewRequest(http.MethodPost, "/review", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "pr-1", useCase.prID)
+	require.Equal(t, "u2", useCase.reviewer)
+	require.Equal(t, domain.ReviewDecisionChangesRequested, useCase.decision)
+	require.Contains(t, rec.Body.String(), `"decision":"CHANGES_REQUESTED"`)
+}
+
+func TestHandler_MapsDomainErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[error]int{
+		domain.ErrPRNotFound:     http.StatusNotFound,
+		domain.ErrPRMerged:       http.StatusConflict,
+		domain.ErrReviewerAbsent: http.StatusConflict,
+	}
+	for domainErr, status := range cases {
+		handler := New(&stubUseCase{err: domainErr})
+		router := chi.NewRouter()
+		handler.Register(router)
+
+		body := `{"pull_request_id":"pr-1","reviewer_id":"u2","decision":"APPROVED"}`
+		req := httptest.NewRequest(http.MethodPost, "/review", bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, status, rec.Code, domainErr.Error())
+	}
+}
@@ -0,0 +1,11 @@
+package pullrequesttimeline
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	GetPullRequestTimeline(ctx context.Context, prID string) (domain.PullRequestTimeline, error)
+}
@@ -0,0 +1,35 @@
+package pullrequesttimeline
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+)
+
+// Handler реализует GET /pullRequest/timeline.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Get("/timeline", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		return common.NewBadRequestError("VALIDATION_ERROR", "pull_request_id обязателен")
+	}
+	timeline, err := h.useCase.GetPullRequestTimeline(r.Context(), prID)
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, timeline)
+	return nil
+}
@@ -0,0 +1,90 @@
+package pullrequesttimeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	calledWith string
+	err        error
+}
+
+func (s *stubUseCase) GetPullRequestTimeline(ctx context.Context, prID string) (domain.PullRequestTimeline, error) {
+	s.calledWith = prID
+	if s.err != nil {
+		return domain.PullRequestTimeline{}, s.err
+	}
+	created := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	return domain.PullRequestTimeline{
+		PullRequest: domain.PullRequest{ID: prID, Status: domain.PRStatusOpen, CreatedAt: created},
+		Timeline: []domain.TimelineEntry{
+			{Type: domain.TimelineCreated, Status: domain.PRStatusOpen, At: created},
+		},
+	}, nil
+}
+
+func TestHandler_ValidatesPullRequestID(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/timeline", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_ReturnsTimeline(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/timeline?pull_request_id=pr-1", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "pr-1", useCase.calledWith)
+
+	var resp struct {
+		PR       domain.PullRequest     `json:"pr"`
+		Timeline []domain.TimelineEntry `json:"timeline"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, "pr-1", resp.PR.ID)
+	require.Len(t, resp.Timeline, 1)
+	require.Equal(t, domain.TimelineCreated, resp.Timeline[0].Type)
+}
+
+func TestHandler_NotFound(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{err: domain.ErrPRNotFound})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/timeline?pull_request_id=missing", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
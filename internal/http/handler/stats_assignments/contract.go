@@ -7,5 +7,8 @@ import (
 )
 
 type UseCase interface {
-	Stats(ctx context.Context) (domain.AssignmentStats, error)
+	Stats(ctx context.Context, filter domain.StatsFilter) (domain.AssignmentStats, error)
+	ExportUserStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.UserAssignmentStat) error) error
+	ExportPullRequestStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.PRAssignmentStat) error) error
+	ExportSourceStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.SourceAssignmentStat) error) error
 }
@@ -2,13 +2,23 @@ package statsassignments
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
+	"pr-reviewer-service_Avito/internal/domain"
 	"pr-reviewer-service_Avito/internal/http/handler/common"
 )
 
-// Handler реализует GET /stats/assignments.
+// Разделы статистики, выгружаемые в CSV и NDJSON по параметру section.
+const (
+	sectionPerUser        = "per_user"
+	sectionPerPullRequest = "per_pull_request"
+	sectionBySource       = "by_source"
+)
+
+// Handler реализует GET /stats/assignments. Для Accept: text/csv и application/x-ndjson
+// отдаёт построчно один раздел статистики (section, по умолчанию per_user).
 type Handler struct {
 	useCase UseCase
 }
@@ -22,10 +32,50 @@ func (h *Handler) Register(router chi.Router) {
 }
 
 func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
-	stats, err := h.useCase.Stats(r.Context())
+	filter, err := common.ParseStatsFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	if format := common.NegotiateFormat(r); format != common.FormatJSON {
+		return h.export(w, r, format, filter)
+	}
+	stats, err := h.useCase.Stats(r.Context(), filter)
 	if err != nil {
 		return err
 	}
 	common.RespondJSON(w, http.StatusOK, stats)
 	return nil
 }
+
+func (h *Handler) export(w http.ResponseWriter, r *http.Request, format string, filter domain.StatsFilter) error {
+	ctx := r.Context()
+	switch section := r.URL.Query().Get("section"); section {
+	case "", sectionPerUser:
+		rows := common.NewTableWriter(w, format,
+			[]string{"user_id", "username", "team_name", "assigned_total", "active_pull_requests"},
+			func(stat domain.UserAssignmentStat) []string {
+				return []string{stat.UserID, stat.Username, stat.TeamName, formatInt(stat.Assigned), formatInt(stat.ActivePRs)}
+			})
+		return rows.Close(ctx, h.useCase.ExportUserStats(ctx, filter, rows.Write))
+	case sectionPerPullRequest:
+		rows := common.NewTableWriter(w, format,
+			[]string{"pull_request_id", "status", "reviewer_count"},
+			func(stat domain.PRAssignmentStat) []string {
+				return []string{stat.PullRequestID, string(stat.Status), formatInt(stat.ReviewerCount)}
+			})
+		return rows.Close(ctx, h.useCase.ExportPullRequestStats(ctx, filter, rows.Write))
+	case sectionBySource:
+		rows := common.NewTableWriter(w, format,
+			[]string{"source", "assigned", "unassigned", "reassigned"},
+			func(stat domain.SourceAssignmentStat) []string {
+				return []string{stat.Source, formatInt(stat.Assigned), formatInt(stat.Unassigned), formatInt(stat.Reassigned)}
+			})
+		return rows.Close(ctx, h.useCase.ExportSourceStats(ctx, filter, rows.Write))
+	default:
+		return common.NewBadRequestError("VALIDATION_ERROR", "section должен быть per_user, per_pull_request или by_source")
+	}
+}
+
+func formatInt(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
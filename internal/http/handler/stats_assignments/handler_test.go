@@ -14,13 +14,34 @@ import (
 
 type stubUseCase struct {
 	called bool
+	filter domain.StatsFilter
 }
 
-func (s *stubUseCase) Stats(ctx context.Context) (domain.AssignmentStats, error) {
+func (s *stubUseCase) Stats(ctx context.Context, filter domain.StatsFilter) (domain.AssignmentStats, error) {
 	s.called = true
+	s.filter = filter
 	return domain.AssignmentStats{}, nil
 }
 
+func (s *stubUseCase) ExportUserStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.UserAssignmentStat) error) error {
+	s.filter = filter
+	return fn(domain.UserAssignmentStat{UserID: "u1", Username: "Alice", TeamName: "backend", Assigned: 3, ActivePRs: 1})
+}
+
+func (s *stubUseCase) ExportPullRequestStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.PRAssignmentStat) error) error {
+	s.filter = filter
+	for _, id := range []string{"pr-1", "pr-2"} {
+		if err := fn(domain.PRAssignmentStat{PullRequestID: id, Status: domain.PRStatusOpen, ReviewerCount: 2}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stubUseCase) ExportSourceStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.SourceAssignmentStat) error) error {
+	return fn(domain.SourceAssignmentStat{Source: "AUTO", Assigned: 5})
+}
+
 func TestHandler_ReturnsStats(t *testing.T) {
 	t.Parallel()
 
@@ -37,3 +58,95 @@ func TestHandler_ReturnsStats(t *testing.T) {
 	require.Equal(t, http.StatusOK, rec.Code)
 	require.True(t, useCase.called)
 }
+
+func TestHandler_PassesFilter(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/assignments?team_name=backend&status=OPEN&from=2025-01-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "backend", useCase.filter.TeamName)
+	require.Equal(t, domain.PRStatusOpen, useCase.filter.Status)
+	require.NotNil(t, useCase.filter.From)
+	require.Nil(t, useCase.filter.To)
+}
+
+func TestHandler_RejectsInvalidFilter(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/assignments?status=DONE", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.False(t, useCase.called)
+}
+
+func TestHandler_ExportsPerPullRequestCSV(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/assignments?section=per_pull_request&status=OPEN", nil)
+	req.Header.Set("Accept", "text/csv")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.False(t, useCase.called)
+	require.Equal(t, domain.PRStatusOpen, useCase.filter.Status)
+	require.Equal(t, "pull_request_id,status,reviewer_count\npr-1,OPEN,2\npr-2,OPEN,2\n", rec.Body.String())
+}
+
+func TestHandler_ExportsPerUserNDJSON(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/assignments", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"user_id":"u1","username":"Alice","team_name":"backend","assigned_total":3,"active_pull_requests":1}`,
+		rec.Body.String())
+}
+
+func TestHandler_RejectsUnknownSection(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/assignments?section=per_team", nil)
+	req.Header.Set("Accept", "text/csv")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,11 @@
+package statsfairness
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	FairnessReport(ctx context.Context, filter domain.FairnessFilter) (domain.FairnessReport, error)
+}
@@ -0,0 +1,81 @@
+package statsfairness
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+)
+
+var fairnessHeader = []string{
+	"team_name", "active_members", "total_assignments", "fair_share", "gini", "max_min_ratio",
+	"most_above_user_id", "most_above_assigned", "most_below_user_id", "most_below_assigned",
+}
+
+// Handler реализует GET /stats/fairness. Для Accept: application/x-ndjson отдаёт по строке на команду,
+// для text/csv — те же строки с участниками-крайностями в отдельных колонках.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Get("/fairness", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	filter, err := common.ParseFairnessFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	report, err := h.useCase.FairnessReport(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+	format := common.NegotiateFormat(r)
+	if format == common.FormatJSON {
+		common.RespondJSON(w, http.StatusOK, report)
+		return nil
+	}
+	rows := common.NewTableWriter(w, format, fairnessHeader, fairnessRecord)
+	for _, team := range report.Teams {
+		if err = rows.Write(team); err != nil {
+			break
+		}
+	}
+	return rows.Close(r.Context(), err)
+}
+
+func fairnessRecord(team domain.TeamFairness) []string {
+	record := []string{
+		team.TeamName,
+		strconv.Itoa(team.ActiveMembers),
+		strconv.FormatInt(team.TotalAssignments, 10),
+		formatFloat(team.FairShare),
+		formatFloat(team.Gini),
+		"",
+	}
+	if team.MaxMinRatio != nil {
+		record[5] = formatFloat(*team.MaxMinRatio)
+	}
+	record = append(record, memberFields(team.MostAbove)...)
+	return append(record, memberFields(team.MostBelow)...)
+}
+
+// memberFields возвращает идентификатор и число назначений участника либо пустые поля, если его нет.
+func memberFields(member *domain.MemberShare) []string {
+	if member == nil {
+		return []string{"", ""}
+	}
+	return []string{member.UserID, strconv.FormatInt(member.Assigned, 10)}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,112 @@
+package statsfairness
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	called bool
+	filter domain.FairnessFilter
+}
+
+func (s *stubUseCase) FairnessReport(ctx context.Context, filter domain.FairnessFilter) (domain.FairnessReport, error) {
+	s.called = true
+	s.filter = filter
+	return domain.FairnessReport{Teams: []domain.TeamFairness{{
+		TeamName:         "backend",
+		ActiveMembers:    2,
+		TotalAssignments: 4,
+		FairShare:        2,
+		Gini:             0.25,
+		MostAbove:        &domain.MemberShare{UserID: "u1", Assigned: 3, Delta: 1},
+		MostBelow:        &domain.MemberShare{UserID: "u2", Assigned: 1, Delta: -1},
+	}}}, nil
+}
+
+func TestHandler_ReturnsReport(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/fairness?team_name=backend&to=2025-02-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "backend", useCase.filter.TeamName)
+	require.NotNil(t, useCase.filter.To)
+
+	var resp domain.FairnessReport
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Len(t, resp.Teams, 1)
+	require.Equal(t, 0.25, resp.Teams[0].Gini)
+}
+
+func TestHandler_RejectsInvalidTime(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/fairness?from=last-sprint", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.False(t, useCase.called)
+}
+
+func TestHandler_ExportsReportCSV(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/fairness", nil)
+	req.Header.Set("Accept", "text/csv, application/json")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "team_name,active_members,total_assignments,fair_share,gini,max_min_ratio,"+
+		"most_above_user_id,most_above_assigned,most_below_user_id,most_below_assigned\n"+
+		"backend,2,4,2,0.25,,u1,3,u2,1\n", rec.Body.String())
+}
+
+func TestHandler_ExportsReportNDJSON(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/fairness", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var team domain.TeamFairness
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &team))
+	require.Equal(t, "backend", team.TeamName)
+	require.Equal(t, "u2", team.MostBelow.UserID)
+}
@@ -0,0 +1,11 @@
+package statslatency
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	LatencyStats(ctx context.Context, filter domain.LatencyFilter) (domain.LatencyStats, error)
+}
@@ -0,0 +1,87 @@
+package statslatency
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+)
+
+// latencyRow — строка выгрузки задержек в CSV и NDJSON: перцентили одной группы одной метрики.
+type latencyRow struct {
+	Metric string `json:"metric"` // time_to_merge или assignment_to_decision
+	Group  string `json:"group"`  // team или reviewer
+	Key    string `json:"key"`    // Команда или идентификатор ревьювера
+	domain.LatencyPercentiles
+}
+
+var latencyHeader = []string{"metric", "group", "key", "count", "p50_seconds", "p90_seconds", "p99_seconds"}
+
+// Handler реализует GET /stats/latency. Для Accept: text/csv и application/x-ndjson
+// отдаёт перцентили плоскими строками.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Get("/latency", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	filter, err := common.ParseLatencyFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	stats, err := h.useCase.LatencyStats(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+	format := common.NegotiateFormat(r)
+	if format == common.FormatJSON {
+		common.RespondJSON(w, http.StatusOK, stats)
+		return nil
+	}
+	rows := common.NewTableWriter(w, format, latencyHeader, latencyRecord)
+	return rows.Close(r.Context(), writeLatencyRows(stats, rows.Write))
+}
+
+func writeLatencyRows(stats domain.LatencyStats, write func(latencyRow) error) error {
+	for _, metric := range []struct {
+		name      string
+		breakdown domain.LatencyBreakdown
+	}{
+		{"time_to_merge", stats.TimeToMerge},
+		{"assignment_to_decision", stats.AssignmentToDecision},
+	} {
+		for _, team := range metric.breakdown.PerTeam {
+			if err := write(latencyRow{metric.name, "team", team.TeamName, team.LatencyPercentiles}); err != nil {
+				return err
+			}
+		}
+		for _, reviewer := range metric.breakdown.PerReviewer {
+			if err := write(latencyRow{metric.name, "reviewer", reviewer.ReviewerID, reviewer.LatencyPercentiles}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func latencyRecord(row latencyRow) []string {
+	return []string{
+		row.Metric,
+		row.Group,
+		row.Key,
+		strconv.FormatInt(row.Count, 10),
+		strconv.FormatFloat(row.P50, 'f', -1, 64),
+		strconv.FormatFloat(row.P90, 'f', -1, 64),
+		strconv.FormatFloat(row.P99, 'f', -1, 64),
+	}
+}
@@ -0,0 +1,105 @@
+package statslatency
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	called bool
+	filter domain.LatencyFilter
+}
+
+func (s *stubUseCase) LatencyStats(ctx context.Context, filter domain.LatencyFilter) (domain.LatencyStats, error) {
+	s.called = true
+	s.filter = filter
+	return domain.LatencyStats{TimeToMerge: domain.LatencyBreakdown{
+		PerTeam: []domain.TeamLatency{{TeamName: "backend", LatencyPercentiles: domain.LatencyPercentiles{Count: 3, P50: 60, P90: 120, P99: 180}}},
+	}}, nil
+}
+
+func TestHandler_ReturnsLatency(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/latency?team_name=backend&from=2025-01-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "backend", useCase.filter.TeamName)
+	require.NotNil(t, useCase.filter.From)
+
+	var resp map[string]map[string][]map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	team := resp["time_to_merge"]["per_team"][0]
+	require.Equal(t, "backend", team["team_name"])
+	require.Equal(t, 120.0, team["p90_seconds"])
+}
+
+func TestHandler_RejectsInvalidRange(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/latency?from=2025-01-02T00:00:00Z&to=2025-01-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.False(t, useCase.called)
+}
+
+func TestHandler_ExportsLatencyCSV(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/latency", nil)
+	req.Header.Set("Accept", "text/csv")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	require.Equal(t, "metric,group,key,count,p50_seconds,p90_seconds,p99_seconds\n"+
+		"time_to_merge,team,backend,3,60,120,180\n", rec.Body.String())
+}
+
+func TestHandler_ExportsLatencyNDJSON(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/latency", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"metric":"time_to_merge","group":"team","key":"backend","count":3,"p50_seconds":60,"p90_seconds":120,"p99_seconds":180}`,
+		rec.Body.String())
+}
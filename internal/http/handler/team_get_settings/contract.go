@@ -0,0 +1,11 @@
+package teamgetsettings
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	GetTeamSettings(ctx context.Context, teamName string) (domain.TeamSettings, error)
+}
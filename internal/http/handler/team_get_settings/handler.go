@@ -0,0 +1,36 @@
+package teamgetsettings
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+)
+
+// Handler реализует GET /team/getSettings.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Get("/getSettings", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	name := r.URL.Query().Get("team_name")
+	if name == "" {
+		return common.NewBadRequestError("VALIDATION_ERROR", "team_name обязателен")
+	}
+	settings, err := h.useCase.GetTeamSettings(r.Context(), name)
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, map[string]domain.TeamSettings{"settings": settings})
+	return nil
+}
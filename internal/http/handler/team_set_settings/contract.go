@@ -0,0 +1,12 @@
+package teamsetsettings
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+type UseCase interface {
+	UpdateTeamSettings(ctx context.Context, teamName string, update service.TeamSettingsUpdate) (domain.TeamSettings, error)
+}
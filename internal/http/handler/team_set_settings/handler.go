@@ -0,0 +1,106 @@
+package teamsetsettings
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+// request содержит изменяемые настройки; отсутствующие поля не меняются.
+type request struct {
+	TeamName          string                   `json:"team_name"`
+	ReviewerStrategy  *domain.ReviewerStrategy `json:"reviewer_strategy"`
+	RequiredReviewers *int                     `json:"required_reviewers"`
+	FallbackTeams     *[]string                `json:"fallback_teams"`
+	MaxOpenReviews    *int                     `json:"max_open_reviews"`
+	MergePolicy       *mergePolicyRequest      `json:"merge_policy"`
+	ReviewSLA         *reviewSLARequest        `json:"review_sla"`
+}
+
+// mergePolicyRequest содержит изменяемые поля политики merge; отсутствующие поля не меняются.
+type mergePolicyRequest struct {
+	RequiredApprovals       *int    `json:"required_approvals"`
+	BlockOnChangesRequested *bool   `json:"block_on_changes_requested"`
+	RequiredRole            *string `json:"required_role"`
+}
+
+// reviewSLARequest содержит изменяемые поля SLA на ревью; отсутствующие поля не меняются.
+type reviewSLARequest struct {
+	Hours      *int                  `json:"hours"`
+	Escalation *domain.SLAEscalation `json:"escalation"`
+}
+
+// Handler реализует POST /team/setSettings.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Post("/setSettings", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return common.NewBadRequestError("INVALID_BODY", "не удалось прочитать тело запроса")
+	}
+	if req.TeamName == "" {
+		return common.NewBadRequestError("VALIDATION_ERROR", "team_name обязателен")
+	}
+	if req.RequiredReviewers != nil && service.ValidateRequiredReviewers(*req.RequiredReviewers) != nil {
+		return common.NewBadRequestError("VALIDATION_ERROR",
+			fmt.Sprintf("required_reviewers должен быть от 0 до %d", service.MaxRequiredReviewers))
+	}
+	if req.FallbackTeams != nil {
+		if err := service.ValidateFallbackTeams(req.TeamName, *req.FallbackTeams); err != nil {
+			return common.NewBadRequestError("VALIDATION_ERROR", "fallback_teams: "+err.Error())
+		}
+	}
+	if req.MaxOpenReviews != nil && service.ValidateMaxOpenReviews(*req.MaxOpenReviews) != nil {
+		return common.NewBadRequestError("VALIDATION_ERROR", "max_open_reviews не может быть отрицательным")
+	}
+	var mergePolicy *service.MergePolicyUpdate
+	if req.MergePolicy != nil {
+		mergePolicy = &service.MergePolicyUpdate{
+			RequiredApprovals:       req.MergePolicy.RequiredApprovals,
+			BlockOnChangesRequested: req.MergePolicy.BlockOnChangesRequested,
+			RequiredRole:            req.MergePolicy.RequiredRole,
+		}
+		if err := service.ValidateMergePolicyUpdate(*mergePolicy); err != nil {
+			return common.NewBadRequestError("VALIDATION_ERROR", "merge_policy: "+err.Error())
+		}
+	}
+	var reviewSLA *service.ReviewSLAUpdate
+	if req.ReviewSLA != nil {
+		reviewSLA = &service.ReviewSLAUpdate{
+			Hours:      req.ReviewSLA.Hours,
+			Escalation: req.ReviewSLA.Escalation,
+		}
+		if err := service.ValidateReviewSLAUpdate(*reviewSLA); err != nil {
+			return common.NewBadRequestError("VALIDATION_ERROR", "review_sla: "+err.Error())
+		}
+	}
+	settings, err := h.useCase.UpdateTeamSettings(r.Context(), req.TeamName, service.TeamSettingsUpdate{
+		ReviewerStrategy:  req.ReviewerStrategy,
+		RequiredReviewers: req.RequiredReviewers,
+		FallbackTeams:     req.FallbackTeams,
+		MaxOpenReviews:    req.MaxOpenReviews,
+		MergePolicy:       mergePolicy,
+		ReviewSLA:         reviewSLA,
+	})
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, map[string]domain.TeamSettings{"settings": settings})
+	return nil
+}
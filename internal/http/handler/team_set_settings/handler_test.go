@@ -0,0 +1,250 @@
+package teamsetsettings
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+type stubUseCase struct {
+	teamName string
+	update   service.TeamSettingsUpdate
+}
+
+func (s *stubUseCase) UpdateTeamSettings(ctx context.Context, teamName string, update service.TeamSettingsUpdate) (domain.TeamSettings, error) {
+	s.teamName = teamName
+	s.update = update
+	return domain.TeamSettings{TeamName: teamName}, nil
+}
+
+func TestHandler_ValidatesTeamName(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/setSettings", bytes.NewBufferString(`{}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_PassesStrategy(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	body := `{"team_name":"backend","reviewer_strategy":"round_robin"}`
+	req := httptest.NewRequest(http.MethodPost, "/setSettings", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "backend", useCase.teamName)
+	require.NotNil(t, useCase.update.ReviewerStrategy)
+	require.Equal(t, domain.ReviewerStrategyRoundRobin, *useCase.update.ReviewerStrategy)
+}
+
+func TestHandler_PassesRequiredReviewers(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	body := `{"team_name":"platform","required_reviewers":3}`
+	req := httptest.NewRequest(http.MethodPost, "/setSettings", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Nil(t, useCase.update.ReviewerStrategy)
+	require.NotNil(t, useCase.update.RequiredReviewers)
+	require.Equal(t, 3, *useCase.update.RequiredReviewers)
+}
+
+func TestHandler_RejectsInvalidRequiredReviewers(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	body := `{"team_name":"platform","required_reviewers":-1}`
+	req := httptest.NewRequest(http.MethodPost, "/setSettings", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Empty(t, useCase.teamName)
+}
+
+func TestHandler_PassesFallbackTeams(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	body := `{"team_name":"tiny","fallback_teams":["platform","backend"]}`
+	req := httptest.NewRequest(http.MethodPost, "/setSettings", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, useCase.update.FallbackTeams)
+	require.Equal(t, []string{"platform", "backend"}, *useCase.update.FallbackTeams)
+}
+
+func TestHandler_RejectsSelfFallback(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	body := `{"team_name":"tiny","fallback_teams":["tiny"]}`
+	req := httptest.NewRequest(http.MethodPost, "/setSettings", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Empty(t, useCase.teamName)
+}
+
+func TestHandler_PassesMaxOpenReviews(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	body := `{"team_name":"platform","max_open_reviews":4}`
+	req := httptest.NewRequest(http.MethodPost, "/setSettings", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, useCase.update.MaxOpenReviews)
+	require.Equal(t, 4, *useCase.update.MaxOpenReviews)
+}
+
+func TestHandler_RejectsNegativeMaxOpenReviews(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	body := `{"team_name":"platform","max_open_reviews":-1}`
+	req := httptest.NewRequest(http.MethodPost, "/setSettings", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Empty(t, useCase.teamName)
+}
+
+func TestHandler_PassesMergePolicy(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	body := `{"team_name":"backend","merge_policy":{"required_approvals":2,"block_on_changes_requested":true}}`
+	req := httptest.NewRequest(http.MethodPost, "/setSettings", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, useCase.update.MergePolicy)
+	require.Equal(t, 2, *useCase.update.MergePolicy.RequiredApprovals)
+	require.True(t, *useCase.update.MergePolicy.BlockOnChangesRequested)
+	require.Nil(t, useCase.update.MergePolicy.RequiredRole)
+}
+
+func TestHandler_RejectsInvalidMergePolicy(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	body := `{"team_name":"backend","merge_policy":{"required_approvals":-1}}`
+	req := httptest.NewRequest(http.MethodPost, "/setSettings", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Empty(t, useCase.teamName)
+}
+
+func TestHandler_PassesReviewSLA(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	body := `{"team_name":"backend","review_sla":{"hours":24,"escalation":"REASSIGN"}}`
+	req := httptest.NewRequest(http.MethodPost, "/setSettings", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, useCase.update.ReviewSLA)
+	require.Equal(t, 24, *useCase.update.ReviewSLA.Hours)
+	require.Equal(t, domain.SLAEscalationReassign, *useCase.update.ReviewSLA.Escalation)
+}
+
+func TestHandler_RejectsUnknownSLAEscalation(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	body := `{"team_name":"backend","review_sla":{"escalation":"PAGE"}}`
+	req := httptest.NewRequest(http.MethodPost, "/setSettings", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Empty(t, useCase.teamName)
+}
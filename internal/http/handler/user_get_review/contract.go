@@ -7,5 +7,5 @@ import (
 )
 
 type UseCase interface {
-	ListReviewAssignments(ctx context.Context, userID string) ([]domain.PullRequestShort, error)
+	ListReviewAssignments(ctx context.Context, filter domain.ReviewAssignmentFilter) (domain.ReviewAssignmentPage, error)
 }
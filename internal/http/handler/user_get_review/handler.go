@@ -8,7 +8,8 @@ import (
 	"pr-reviewer-service_Avito/internal/http/handler/common"
 )
 
-// Handler реализует GET /users/getReview.
+// Handler реализует GET /users/getReview: страницу PR, на которые назначен пользователь,
+// с фильтром по статусам (по умолчанию OPEN) и общим количеством.
 type Handler struct {
 	useCase UseCase
 }
@@ -22,17 +23,14 @@ func (h *Handler) Register(router chi.Router) {
 }
 
 func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
-	userID := r.URL.Query().Get("user_id")
-	if userID == "" {
-		return common.NewBadRequestError("VALIDATION_ERROR", "user_id обязателен")
+	filter, err := common.ParseReviewAssignmentFilter(r.URL.Query())
+	if err != nil {
+		return err
 	}
-	assignments, err := h.useCase.ListReviewAssignments(r.Context(), userID)
+	page, err := h.useCase.ListReviewAssignments(r.Context(), filter)
 	if err != nil {
 		return err
 	}
-	common.RespondJSON(w, http.StatusOK, map[string]any{
-		"user_id":       userID,
-		"pull_requests": assignments,
-	})
+	common.RespondJSON(w, http.StatusOK, page)
 	return nil
 }
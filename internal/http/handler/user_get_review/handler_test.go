@@ -2,6 +2,7 @@ package usergetreview
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,12 +14,17 @@ import (
 )
 
 type stubUseCase struct {
-	userID string
+	filter domain.ReviewAssignmentFilter
 }
 
-func (s *stubUseCase) ListReviewAssignments(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
-	s.userID = userID
-	return []domain.PullRequestShort{}, nil
+func (s *stubUseCase) ListReviewAssignments(ctx context.Context, filter domain.ReviewAssignmentFilter) (domain.ReviewAssignmentPage, error) {
+	s.filter = filter
+	return domain.ReviewAssignmentPage{
+		UserID:       filter.UserID,
+		PullRequests: []domain.PullRequestShort{{ID: "pr-1", AssignedReviewers: []string{"u1", "u2"}}},
+		Total:        3,
+		NextCursor:   "next",
+	}, nil
 }
 
 func TestHandler_ValidatesUserID(t *testing.T) {
@@ -50,5 +56,54 @@ func TestHandler_PassesQueryParam(t *testing.T) {
 	router.ServeHTTP(rec, req)
 
 	require.Equal(t, http.StatusOK, rec.Code)
-	require.Equal(t, "u1", useCase.userID)
+	require.Equal(t, "u1", useCase.filter.UserID)
+	require.Empty(t, useCase.filter.Statuses)
+}
+
+func TestHandler_ReturnsPage(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/getReview?user_id=u1&status=OPEN,DRAFT&include=reviewers&limit=1", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, []domain.PRStatus{domain.PRStatusOpen, domain.PRStatusDraft}, useCase.filter.Statuses)
+	require.True(t, useCase.filter.IncludeReviewers)
+	require.Equal(t, 1, useCase.filter.Limit)
+
+	var resp struct {
+		UserID       string `json:"user_id"`
+		PullRequests []struct {
+			AssignedReviewers []string `json:"assigned_reviewers"`
+		} `json:"pull_requests"`
+		Total      int64  `json:"total"`
+		NextCursor string `json:"next_cursor"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, "u1", resp.UserID)
+	require.Equal(t, []string{"u1", "u2"}, resp.PullRequests[0].AssignedReviewers)
+	require.Equal(t, int64(3), resp.Total)
+	require.Equal(t, "next", resp.NextCursor)
+}
+
+func TestHandler_RejectsUnknownStatus(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/getReview?user_id=u1&status=STALE", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
 }
@@ -0,0 +1,11 @@
+package usersetmaxopenreviews
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error)
+}
@@ -0,0 +1,50 @@
+package usersetmaxopenreviews
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+// request задаёт персональный лимит; 0 возвращает значение по умолчанию команды.
+type request struct {
+	UserID         string `json:"user_id"`
+	MaxOpenReviews int    `json:"max_open_reviews"`
+}
+
+// Handler реализует POST /users/setMaxOpenReviews.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Post("/setMaxOpenReviews", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return common.NewBadRequestError("INVALID_BODY", "не удалось прочитать тело запроса")
+	}
+	if req.UserID == "" {
+		return common.NewBadRequestError("VALIDATION_ERROR", "user_id обязателен")
+	}
+	if service.ValidateMaxOpenReviews(req.MaxOpenReviews) != nil {
+		return common.NewBadRequestError("VALIDATION_ERROR", "max_open_reviews не может быть отрицательным")
+	}
+	user, err := h.useCase.SetUserMaxOpenReviews(r.Context(), req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, map[string]domain.User{"user": user})
+	return nil
+}
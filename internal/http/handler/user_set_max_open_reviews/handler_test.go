@@ -0,0 +1,82 @@
+package usersetmaxopenreviews
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	input struct {
+		id    string
+		limit int
+	}
+	err error
+}
+
+func (s *stubUseCase) SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error) {
+	s.input.id = userID
+	s.input.limit = maxOpenReviews
+	return domain.User{ID: userID, MaxOpenReviews: maxOpenReviews}, s.err
+}
+
+func TestHandler_ValidatesPayload(t *testing.T) {
+	t.Parallel()
+
+	cases := []string{`{}`, `{"user_id":"u1","max_open_reviews":-2}`}
+	for _, body := range cases {
+		useCase := &stubUseCase{}
+		handler := New(useCase)
+		router := chi.NewRouter()
+		handler.Register(router)
+
+		req := httptest.NewRequest(http.MethodPost, "/setMaxOpenReviews", bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code, body)
+		require.Empty(t, useCase.input.id)
+	}
+}
+
+func TestHandler_PassesPayload(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/setMaxOpenReviews", bytes.NewBufferString(`{"user_id":"u1","max_open_reviews":3}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "u1", useCase.input.id)
+	require.Equal(t, 3, useCase.input.limit)
+	require.Contains(t, rec.Body.String(), `"max_open_reviews":3`)
+}
+
+func TestHandler_UserNotFound(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{err: domain.ErrUserNotFound})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/setMaxOpenReviews", bytes.NewBufferString(`{"user_id":"ghost","max_open_reviews":1}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
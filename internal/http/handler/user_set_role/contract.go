@@ -0,0 +1,11 @@
+package usersetrole
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	SetUserRole(ctx context.Context, userID, role string) (domain.User, error)
+}
@@ -0,0 +1,50 @@
+package usersetrole
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+// request задаёт роль пользователя; пустая строка снимает роль.
+type request struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+// Handler реализует POST /users/setRole.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Post("/setRole", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return common.NewBadRequestError("INVALID_BODY", "не удалось прочитать тело запроса")
+	}
+	if req.UserID == "" {
+		return common.NewBadRequestError("VALIDATION_ERROR", "user_id обязателен")
+	}
+	if err := service.ValidateRole(req.Role); err != nil {
+		return common.NewBadRequestError("VALIDATION_ERROR", "role: "+err.Error())
+	}
+	user, err := h.useCase.SetUserRole(r.Context(), req.UserID, req.Role)
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, map[string]domain.User{"user": user})
+	return nil
+}
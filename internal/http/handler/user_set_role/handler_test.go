@@ -0,0 +1,66 @@
+package usersetrole
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	input struct {
+		id   string
+		role string
+	}
+}
+
+func (s *stubUseCase) SetUserRole(ctx context.Context, userID, role string) (domain.User, error) {
+	s.input.id = userID
+	s.input.role = role
+	return domain.User{ID: userID, Role: role}, nil
+}
+
+func TestHandler_ValidatesPayload(t *testing.T) {
+	t.Parallel()
+
+	cases := []string{`{"role":"lead"}`, `{"user_id":"u1","role":" lead"}`}
+	for _, body := range cases {
+		useCase := &stubUseCase{}
+		handler := New(useCase)
+		router := chi.NewRouter()
+		handler.Register(router)
+
+		req := httptest.NewRequest(http.MethodPost, "/setRole", bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code, body)
+		require.Empty(t, useCase.input.id)
+	}
+}
+
+func TestHandler_PassesPayload(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/setRole", bytes.NewBufferString(`{"user_id":"u1","role":"lead"}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "u1", useCase.input.id)
+	require.Equal(t, "lead", useCase.input.role)
+	require.Contains(t, rec.Body.String(), `"role":"lead"`)
+}
@@ -0,0 +1,62 @@
+package webhookcreate
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+type request struct {
+	URL        string             `json:"url"`
+	Secret     string             `json:"secret"`
+	EventTypes []domain.EventType `json:"event_types"`
+	Teams      []string           `json:"teams"`
+	IsActive   *bool              `json:"is_active"`
+}
+
+// Handler реализует POST /webhooks/create.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Post("/create", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return common.NewBadRequestError("INVALID_BODY", "не удалось прочитать тело запроса")
+	}
+	if err := service.ValidateWebhookURL(req.URL); err != nil {
+		return common.NewBadRequestError("VALIDATION_ERROR", "url должен быть абсолютным http(s)-адресом")
+	}
+	if err := service.ValidateEventTypes(req.EventTypes); err != nil {
+		return common.NewBadRequestError("VALIDATION_ERROR", "event_types: "+err.Error())
+	}
+	if err := service.ValidateWebhookTeams(req.Teams); err != nil {
+		return common.NewBadRequestError("VALIDATION_ERROR", "teams: "+err.Error())
+	}
+	webhook := domain.Webhook{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		Teams:      req.Teams,
+		IsActive:   req.IsActive == nil || *req.IsActive,
+	}
+	created, err := h.useCase.CreateWebhook(r.Context(), webhook)
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusCreated, map[string]domain.Webhook{"webhook": created})
+	return nil
+}
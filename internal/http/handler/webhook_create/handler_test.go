@@ -0,0 +1,70 @@
+package webhookcreate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	webhook domain.Webhook
+}
+
+func (s *stubUseCase) CreateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	s.webhook = webhook
+	webhook.ID = 1
+	webhook.Secret = "generated"
+	return webhook, nil
+}
+
+func TestHandler_ValidatesRequest(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	for _, body := range []string{
+		`{"url":"not a url"}`,
+		`{"url":"https://example.com","event_types":["PUSHED"]}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/create", bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+}
+
+func TestHandler_CreatesActiveWebhookAndReturnsSecret(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/create",
+		bytes.NewBufferString(`{"url":"https://example.com/hook","event_types":["ASSIGNED"],"teams":["backend"]}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.True(t, useCase.webhook.IsActive)
+	require.Equal(t, []domain.EventType{domain.EventAssigned}, useCase.webhook.EventTypes)
+	require.Equal(t, []string{"backend"}, useCase.webhook.Teams)
+
+	var resp map[string]domain.Webhook
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, "generated", resp["webhook"].Secret)
+}
@@ -0,0 +1,7 @@
+package webhookdelete
+
+import "context"
+
+type UseCase interface {
+	DeleteWebhook(ctx context.Context, id int64) error
+}
@@ -0,0 +1,42 @@
+package webhookdelete
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+)
+
+type request struct {
+	WebhookID int64 `json:"webhook_id"`
+}
+
+// Handler реализует POST /webhooks/delete.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Post("/delete", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return common.NewBadRequestError("INVALID_BODY", "не удалось прочитать тело запроса")
+	}
+	if req.WebhookID <= 0 {
+		return common.NewBadRequestError("VALIDATION_ERROR", "webhook_id обязателен")
+	}
+	if err := h.useCase.DeleteWebhook(r.Context(), req.WebhookID); err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, map[string]int64{"webhook_id": req.WebhookID})
+	return nil
+}
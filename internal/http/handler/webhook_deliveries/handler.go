@@ -0,0 +1,55 @@
+package webhookdeliveries
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+// Handler реализует GET /webhooks/deliveries.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Get("/deliveries", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	query := r.URL.Query()
+	filter := domain.WebhookDeliveryFilter{Status: domain.WebhookDeliveryStatus(query.Get("status"))}
+	if raw := query.Get("webhook_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id <= 0 {
+			return common.NewBadRequestError("VALIDATION_ERROR", "webhook_id должен быть положительным числом")
+		}
+		filter.WebhookID = id
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 || limit > service.MaxWebhookDeliveriesLimit {
+			return common.NewBadRequestError("VALIDATION_ERROR",
+				fmt.Sprintf("limit должен быть от 1 до %d", service.MaxWebhookDeliveriesLimit))
+		}
+		filter.Limit = limit
+	}
+	if err := service.ValidateWebhookDeliveryFilter(filter); err != nil {
+		return common.NewBadRequestError("VALIDATION_ERROR", "status должен быть PENDING, DELIVERED или FAILED")
+	}
+	deliveries, err := h.useCase.ListWebhookDeliveries(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, map[string][]domain.WebhookDelivery{"deliveries": deliveries})
+	return nil
+}
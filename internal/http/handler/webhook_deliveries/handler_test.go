@@ -0,0 +1,57 @@
+package webhookdeliveries
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	filter domain.WebhookDeliveryFilter
+}
+
+func (s *stubUseCase) ListWebhookDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	s.filter = filter
+	return []domain.WebhookDelivery{{ID: 1, WebhookID: filter.WebhookID, Status: domain.WebhookDeliveryFailed}}, nil
+}
+
+func TestHandler_ValidatesQuery(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	for _, query := range []string{"webhook_id=abc", "limit=0", "limit=100000", "status=LOST"} {
+		req := httptest.NewRequest(http.MethodGet, "/deliveries?"+query, nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+func TestHandler_PassesFilter(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/deliveries?webhook_id=2&status=FAILED&limit=10", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, domain.WebhookDeliveryFilter{WebhookID: 2, Status: domain.WebhookDeliveryFailed, Limit: 10}, useCase.filter)
+	require.Contains(t, rec.Body.String(), `"delivery_id":1`)
+}
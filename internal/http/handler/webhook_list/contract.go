@@ -0,0 +1,11 @@
+package webhooklist
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	ListWebhooks(ctx context.Context) ([]domain.Webhook, error)
+}
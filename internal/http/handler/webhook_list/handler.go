@@ -0,0 +1,32 @@
+package webhooklist
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+)
+
+// Handler реализует GET /webhooks/list.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Get("/list", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	webhooks, err := h.useCase.ListWebhooks(r.Context())
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, map[string][]domain.Webhook{"webhooks": webhooks})
+	return nil
+}
@@ -0,0 +1,36 @@
+package webhooklist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct{}
+
+func (stubUseCase) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	return []domain.Webhook{{ID: 1, URL: "https://example.com", IsActive: true}}, nil
+}
+
+func TestHandler_ReturnsWebhooksWithoutSecrets(t *testing.T) {
+	t.Parallel()
+
+	handler := New(stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/list", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"webhook_id":1`)
+	require.NotContains(t, rec.Body.String(), "secret")
+}
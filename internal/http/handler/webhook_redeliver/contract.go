@@ -0,0 +1,11 @@
+package webhookredeliver
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type UseCase interface {
+	RedeliverWebhook(ctx context.Context, deliveryID int64) (domain.WebhookDelivery, error)
+}
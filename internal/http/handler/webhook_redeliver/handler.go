@@ -0,0 +1,45 @@
+package webhookredeliver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+)
+
+type request struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+// Handler реализует POST /webhooks/redeliver.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Post("/redeliver", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return common.NewBadRequestError("INVALID_BODY", "не удалось прочитать тело запроса")
+	}
+	if req.DeliveryID <= 0 {
+		return common.NewBadRequestError("VALIDATION_ERROR", "delivery_id обязателен")
+	}
+	delivery, err := h.useCase.RedeliverWebhook(r.Context(), req.DeliveryID)
+	if err != nil {
+		return err
+	}
+	// Доставка выполняется фоновым воркером, поэтому ответ — 202
+	common.RespondJSON(w, http.StatusAccepted, map[string]domain.WebhookDelivery{"delivery": delivery})
+	return nil
+}
@@ -0,0 +1,72 @@
+package webhookredeliver
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type stubUseCase struct {
+	id  int64
+	err error
+}
+
+func (s *stubUseCase) RedeliverWebhook(ctx context.Context, deliveryID int64) (domain.WebhookDelivery, error) {
+	s.id = deliveryID
+	return domain.WebhookDelivery{ID: deliveryID, Status: domain.WebhookDeliveryPending}, s.err
+}
+
+func TestHandler_ValidatesRequest(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/redeliver", bytes.NewBufferString(`{"delivery_id":0}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_QueuesDelivery(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/redeliver", bytes.NewBufferString(`{"delivery_id":9}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	require.Equal(t, int64(9), useCase.id)
+	require.Contains(t, rec.Body.String(), `"status":"PENDING"`)
+}
+
+func TestHandler_MapsNotFound(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{err: domain.ErrDeliveryNotFound})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/redeliver", bytes.NewBufferString(`{"delivery_id":9}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,12 @@
+package webhookupdate
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+type UseCase interface {
+	UpdateWebhook(ctx context.Context, id int64, update service.WebhookUpdate) (domain.Webhook, error)
+}
@@ -0,0 +1,70 @@
+package webhookupdate
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/http/handler/common"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+// request содержит изменяемые поля подписки; отсутствующие поля не меняются.
+type request struct {
+	WebhookID  int64               `json:"webhook_id"`
+	URL        *string             `json:"url"`
+	Secret     *string             `json:"secret"`
+	EventTypes *[]domain.EventType `json:"event_types"`
+	Teams      *[]string           `json:"teams"`
+	IsActive   *bool               `json:"is_active"`
+}
+
+// Handler реализует POST /webhooks/update.
+type Handler struct {
+	useCase UseCase
+}
+
+func New(useCase UseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func (h *Handler) Register(router chi.Router) {
+	router.Post("/update", common.WithErrorHandling(h.handle))
+}
+
+func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return common.NewBadRequestError("INVALID_BODY", "не удалось прочитать тело запроса")
+	}
+	if req.WebhookID <= 0 {
+		return common.NewBadRequestError("VALIDATION_ERROR", "webhook_id обязателен")
+	}
+	if req.URL != nil && service.ValidateWebhookURL(*req.URL) != nil {
+		return common.NewBadRequestError("VALIDATION_ERROR", "url должен быть абсолютным http(s)-адресом")
+	}
+	if req.EventTypes != nil {
+		if err := service.ValidateEventTypes(*req.EventTypes); err != nil {
+			return common.NewBadRequestError("VALIDATION_ERROR", "event_types: "+err.Error())
+		}
+	}
+	if req.Teams != nil {
+		if err := service.ValidateWebhookTeams(*req.Teams); err != nil {
+			return common.NewBadRequestError("VALIDATION_ERROR", "teams: "+err.Error())
+		}
+	}
+	webhook, err := h.useCase.UpdateWebhook(r.Context(), req.WebhookID, service.WebhookUpdate{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		Teams:      req.Teams,
+		IsActive:   req.IsActive,
+	})
+	if err != nil {
+		return err
+	}
+	common.RespondJSON(w, http.StatusOK, map[string]domain.Webhook{"webhook": webhook})
+	return nil
+}
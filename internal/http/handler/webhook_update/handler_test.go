@@ -0,0 +1,84 @@
+package webhookupdate
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/service"
+)
+
+type stubUseCase struct {
+	id     int64
+	update service.WebhookUpdate
+	err    error
+}
+
+func (s *stubUseCase) UpdateWebhook(ctx context.Context, id int64, update service.WebhookUpdate) (domain.Webhook, error) {
+	s.id = id
+	s.update = update
+	return domain.Webhook{ID: id}, s.err
+}
+
+func TestHandler_ValidatesRequest(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	for _, body := range []string{
+		`{"is_active":false}`,
+		`{"webhook_id":1,"url":"mailto:a@b.c"}`,
+		`{"webhook_id":1,"event_types":["PUSHED"]}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/update", bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+}
+
+func TestHandler_PassesOnlyProvidedFields(t *testing.T) {
+	t.Parallel()
+
+	useCase := &stubUseCase{}
+	handler := New(useCase)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/update", bytes.NewBufferString(`{"webhook_id":3,"is_active":false}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, int64(3), useCase.id)
+	require.NotNil(t, useCase.update.IsActive)
+	require.False(t, *useCase.update.IsActive)
+	require.Nil(t, useCase.update.URL)
+	require.Nil(t, useCase.update.EventTypes)
+}
+
+func TestHandler_MapsNotFound(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&stubUseCase{err: domain.ErrWebhookNotFound})
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/update", bytes.NewBufferString(`{"webhook_id":3}`))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -28,15 +28,9 @@ func PanicMiddleware(next http.Handler) http.Handler {
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusInternalServerError)
 
-				response := api.ErrorResponse{
-					Error: struct {
-						Code    api.ErrorResponseErrorCode `json:"code"`
-						Message string                     `json:"message"`
-					}{
-						Code:    api.ErrorResponseErrorCode("UNKNOWN"),
-						Message: "Internal server error",
-					},
-				}
+				var response api.ErrorResponse
+				response.Error.Code = api.ErrorResponseErrorCode("UNKNOWN")
+				response.Error.Message = "Internal server error"
 				_ = json.NewEncoder(w).Encode(response)
 			}
 		}()
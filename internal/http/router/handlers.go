@@ -10,14 +10,37 @@ import (
 
 	addteam "pr-reviewer-service_Avito/internal/http/handler/add_team"
 	"pr-reviewer-service_Avito/internal/http/handler/common"
+	eventsexport "pr-reviewer-service_Avito/internal/http/handler/events_export"
+	eventslist "pr-reviewer-service_Avito/internal/http/handler/events_list"
 	getteam "pr-reviewer-service_Avito/internal/http/handler/get_team"
+	githubwebhook "pr-reviewer-service_Avito/internal/http/handler/github_webhook"
+	pullrequestaddreviewer "pr-reviewer-service_Avito/internal/http/handler/pull_request_add_reviewer"
+	pullrequestclose "pr-reviewer-service_Avito/internal/http/handler/pull_request_close"
 	pullrequestcreate "pr-reviewer-service_Avito/internal/http/handler/pull_request_create"
+	pullrequestlist "pr-reviewer-service_Avito/internal/http/handler/pull_request_list"
 	pullrequestmerge "pr-reviewer-service_Avito/internal/http/handler/pull_request_merge"
+	pullrequestready "pr-reviewer-service_Avito/internal/http/handler/pull_request_ready"
 	pullrequestreassign "pr-reviewer-service_Avito/internal/http/handler/pull_request_reassign"
+	pullrequestremovereviewer "pr-reviewer-service_Avito/internal/http/handler/pull_request_remove_reviewer"
+	pullrequestreopen "pr-reviewer-service_Avito/internal/http/handler/pull_request_reopen"
+	pullrequestreview "pr-reviewer-service_Avito/internal/http/handler/pull_request_review"
+	pullrequesttimeline "pr-reviewer-service_Avito/internal/http/handler/pull_request_timeline"
 	statsassignments "pr-reviewer-service_Avito/internal/http/handler/stats_assignments"
+	statsfairness "pr-reviewer-service_Avito/internal/http/handler/stats_fairness"
+	statslatency "pr-reviewer-service_Avito/internal/http/handler/stats_latency"
 	teamdeactivate "pr-reviewer-service_Avito/internal/http/handler/team_deactivate"
+	teamgetsettings "pr-reviewer-service_Avito/internal/http/handler/team_get_settings"
+	teamsetsettings "pr-reviewer-service_Avito/internal/http/handler/team_set_settings"
 	usergetreview "pr-reviewer-service_Avito/internal/http/handler/user_get_review"
 	usersetactivity "pr-reviewer-service_Avito/internal/http/handler/user_set_activity"
+	usersetmaxopenreviews "pr-reviewer-service_Avito/internal/http/handler/user_set_max_open_reviews"
+	usersetrole "pr-reviewer-service_Avito/internal/http/handler/user_set_role"
+	webhookcreate "pr-reviewer-service_Avito/internal/http/handler/webhook_create"
+	webhookdelete "pr-reviewer-service_Avito/internal/http/handler/webhook_delete"
+	webhookdeliveries "pr-reviewer-service_Avito/internal/http/handler/webhook_deliveries"
+	webhooklist "pr-reviewer-service_Avito/internal/http/handler/webhook_list"
+	webhookredeliver "pr-reviewer-service_Avito/internal/http/handler/webhook_redeliver"
+	webhookupdate "pr-reviewer-service_Avito/internal/http/handler/webhook_update"
 	"pr-reviewer-service_Avito/internal/http/middleware"
 	"pr-reviewer-service_Avito/internal/http/swagger"
 	"pr-reviewer-service_Avito/internal/service"
@@ -64,6 +87,9 @@ func (h *Handler) Router() http.Handler {
 	h.registerUserRoutes(r)
 	h.registerPullRequestRoutes(r)
 	h.registerStatsRoutes(r)
+	h.registerEventRoutes(r)
+	h.registerWebhookRoutes(r)
+	h.registerIntegrationRoutes(r)
 
 	return r
 }
@@ -73,12 +99,16 @@ func (h *Handler) registerTeamRoutes(r chi.Router) {
 		addteam.New(h.service).Register(router)
 		getteam.New(h.service).Register(router)
 		teamdeactivate.New(h.service).Register(router)
+		teamgetsettings.New(h.service).Register(router)
+		teamsetsettings.New(h.service).Register(router)
 	})
 }
 
 func (h *Handler) registerUserRoutes(r chi.Router) {
 	r.Route("/users", func(router chi.Router) {
 		usersetactivity.New(h.service).Register(router)
+		usersetmaxopenreviews.New(h.service).Register(router)
+		usersetrole.New(h.service).Register(router)
 		usergetreview.New(h.service).Register(router)
 	})
 }
@@ -88,11 +118,45 @@ func (h *Handler) registerPullRequestRoutes(r chi.Router) {
 		pullrequestcreate.New(h.service).Register(router)
 		pullrequestmerge.New(h.service).Register(router)
 		pullrequestreassign.New(h.service).Register(router)
+		pullrequestaddreviewer.New(h.service).Register(router)
+		pullrequestremovereviewer.New(h.service).Register(router)
+		pullrequestreview.New(h.service).Register(router)
+		pullrequestready.New(h.service).Register(router)
+		pullrequestclose.New(h.service).Register(router)
+		pullrequestreopen.New(h.service).Register(router)
+		pullrequesttimeline.New(h.service).Register(router)
+		pullrequestlist.New(h.service).Register(router)
 	})
 }
 
 func (h *Handler) registerStatsRoutes(r chi.Router) {
 	r.Route("/stats", func(router chi.Router) {
 		statsassignments.New(h.service).Register(router)
+		statslatency.New(h.service).Register(router)
+		statsfairness.New(h.service).Register(router)
+	})
+}
+
+func (h *Handler) registerEventRoutes(r chi.Router) {
+	r.Route("/events", func(router chi.Router) {
+		eventslist.New(h.service).Register(router)
+		eventsexport.New(h.service).Register(router)
+	})
+}
+
+func (h *Handler) registerWebhookRoutes(r chi.Router) {
+	r.Route("/webhooks", func(router chi.Router) {
+		webhookcreate.New(h.service).Register(router)
+		webhooklist.New(h.service).Register(router)
+		webhookupdate.New(h.service).Register(router)
+		webhookdelete.New(h.service).Register(router)
+		webhookdeliveries.New(h.service).Register(router)
+		webhookredeliver.New(h.service).Register(router)
+	})
+}
+
+func (h *Handler) registerIntegrationRoutes(r chi.Router) {
+	r.Route("/integrations/github", func(router chi.Router) {
+		githubwebhook.New(h.service).Register(router)
 	})
 }
@@ -0,0 +1,38 @@
+package codehost
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Client передаёт назначения ревьюверов на платформу хостинга кода.
+type Client interface {
+	// RequestReviewers запрашивает ревью PR у пользователей logins.
+	RequestReviewers(ctx context.Context, pr PullRequestRef, logins []string) error
+	// RemoveReviewRequests отзывает запросы ревью у пользователей logins.
+	RemoveReviewRequests(ctx context.Context, pr PullRequestRef, logins []string) error
+	// Comment оставляет комментарий в обсуждении PR.
+	Comment(ctx context.Context, pr PullRequestRef, body string) error
+}
+
+// PullRequestRef указывает на PR на платформе.
+type PullRequestRef struct {
+	Repository string // owner/repo
+	Number     int
+}
+
+// StatusError — ответ платформы с кодом не 2xx.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("code host responded %d: %s", e.StatusCode, e.Body)
+}
+
+// Temporary сообщает, имеет ли смысл повторить запрос позже: лимит запросов или ошибка на стороне платформы.
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
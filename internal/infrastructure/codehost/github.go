@@ -0,0 +1,102 @@
+package codehost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GitHubAPIVersion — версия REST API GitHub, под которую написан клиент.
+const GitHubAPIVersion = "2022-11-28"
+
+// RetryPolicy задаёт повторы временных ошибок: до MaxAttempts попыток с задержкой Backoff·2^(n-1).
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+type githubImpl struct {
+	baseURL string
+	token   string
+	client  *http.Client
+	retry   RetryPolicy
+}
+
+// NewGitHub создаёт клиент REST API GitHub. baseURL — адрес API (https://api.github.com
+// или адрес GitHub Enterprise), token — токен с правом записи в pull request'ы.
+func NewGitHub(baseURL, token string, timeout time.Duration, retry RetryPolicy) Client {
+	return &githubImpl{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: timeout},
+		retry:   retry,
+	}
+}
+
+func (g *githubImpl) RequestReviewers(ctx context.Context, pr PullRequestRef, logins []string) error {
+	return g.do(ctx, http.MethodPost, g.pullPath(pr)+"/requested_reviewers", map[string][]string{"reviewers": logins})
+}
+
+func (g *githubImpl) RemoveReviewRequests(ctx context.Context, pr PullRequestRef, logins []string) error {
+	return g.do(ctx, http.MethodDelete, g.pullPath(pr)+"/requested_reviewers", map[string][]string{"reviewers": logins})
+}
+
+func (g *githubImpl) Comment(ctx context.Context, pr PullRequestRef, body string) error {
+	// Комментарии к PR в GitHub — это комментарии к issue с тем же номером
+	path := "/repos/" + pr.Repository + "/issues/" + strconv.Itoa(pr.Number) + "/comments"
+	return g.do(ctx, http.MethodPost, path, map[string]string{"body": body})
+}
+
+func (g *githubImpl) pullPath(pr PullRequestRef) string {
+	return "/repos/" + pr.Repository + "/pulls/" + strconv.Itoa(pr.Number)
+}
+
+// do выполняет запрос, повторяя его при сетевых ошибках и временных ответах (429, 5xx).
+func (g *githubImpl) do(ctx context.Context, method, path string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	delay := g.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		err = g.send(ctx, method, path, body)
+		var statusErr *StatusError
+		if err == nil || (errors.As(err, &statusErr) && !statusErr.Temporary()) || attempt >= g.retry.MaxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (g *githubImpl) send(ctx context.Context, method, path string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, method, g.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("X-GitHub-Api-Version", GitHubAPIVersion)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package codehost
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testRetry = RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+func TestGitHubRequestReviewers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/repos/acme/api/pulls/12/requested_reviewers", r.URL.Path)
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.Equal(t, GitHubAPIVersion, r.Header.Get("X-GitHub-Api-Version"))
+		var body map[string][]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, []string{"alice", "bob"}, body["reviewers"])
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	client := NewGitHub(srv.URL+"/", "token", time.Second, testRetry)
+	require.NoError(t, client.RequestReviewers(context.Background(), PullRequestRef{Repository: "acme/api", Number: 12}, []string{"alice", "bob"}))
+}
+
+func TestGitHubRemoveReviewRequestsAndComment(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewGitHub(srv.URL, "token", time.Second, testRetry)
+	pr := PullRequestRef{Repository: "acme/api", Number: 12}
+	require.NoError(t, client.RemoveReviewRequests(context.Background(), pr, []string{"alice"}))
+	require.NoError(t, client.Comment(context.Background(), pr, "hello"))
+	require.Equal(t, []string{
+		"DELETE /repos/acme/api/pulls/12/requested_reviewers",
+		"POST /repos/acme/api/issues/12/comments",
+	}, calls)
+}
+
+func TestGitHubRetriesTemporaryErrors(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	client := NewGitHub(srv.URL, "token", time.Second, testRetry)
+	require.NoError(t, client.Comment(context.Background(), PullRequestRef{Repository: "acme/api", Number: 1}, "hi"))
+	require.Equal(t, int32(3), attempts.Load())
+}
+
+func TestGitHubDoesNotRetryPermanentErrors(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"Reviews may only be requested from collaborators."}`))
+	}))
+	defer srv.Close()
+
+	client := NewGitHub(srv.URL, "token", time.Second, testRetry)
+	err := client.RequestReviewers(context.Background(), PullRequestRef{Repository: "acme/api", Number: 1}, []string{"x"})
+
+	var statusErr *StatusError
+	require.True(t, errors.As(err, &statusErr))
+	require.Equal(t, http.StatusUnprocessableEntity, statusErr.StatusCode)
+	require.False(t, statusErr.Temporary())
+	require.Equal(t, int32(1), attempts.Load())
+}
+
+func TestGitHubGivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	client := NewGitHub(srv.URL, "token", time.Second, testRetry)
+	err := client.Comment(context.Background(), PullRequestRef{Repository: "acme/api", Number: 1}, "hi")
+
+	var statusErr *StatusError
+	require.True(t, errors.As(err, &statusErr))
+	require.True(t, statusErr.Temporary())
+	require.Equal(t, int32(3), attempts.Load())
+}
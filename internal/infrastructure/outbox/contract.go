@@ -0,0 +1,24 @@
+package outbox
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+// Имена приёмников в конфигурации outbox.sinks.
+const (
+	SinkLog    = "log"    // Журнал сервиса
+	SinkGitHub = "github" // Запросы ревью и комментарии в GitHub
+)
+
+// Sink получает опубликованные записи outbox.
+type Sink interface {
+	// Name возвращает имя приёмника для логов и метрик.
+	Name() string
+	// Publish получает ещё не принятые приёмником сообщения в порядке записи и возвращает, сколько первых
+	// из них обработано. Необработанный остаток будет отправлен повторно, поэтому приёмник должен быть
+	// идемпотентным по ID сообщения. Приёмник должен завершаться при отмене ctx: срок ctx совпадает
+	// со сроком аренды relay, после которого те же сообщения может опубликовать другой экземпляр.
+	Publish(ctx context.Context, messages []domain.OutboxMessage) (int, error)
+}
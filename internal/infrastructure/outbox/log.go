@@ -0,0 +1,34 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+type logSinkImpl struct {
+	logger *slog.Logger
+}
+
+// NewLogSink создаёт приёмник, который пишет каждое сообщение в logger на уровне INFO.
+func NewLogSink(logger *slog.Logger) Sink {
+	return &logSinkImpl{logger: logger}
+}
+
+func (s *logSinkImpl) Name() string {
+	return SinkLog
+}
+
+func (s *logSinkImpl) Publish(ctx context.Context, messages []domain.OutboxMessage) (int, error) {
+	for _, msg := range messages {
+		s.logger.InfoContext(ctx, "outbox message",
+			"id", msg.ID,
+			"aggregate_type", msg.AggregateType,
+			"aggregate_id", msg.AggregateID,
+			"event_type", msg.EventType,
+			"payload", string(msg.Payload),
+			"created_at", msg.CreatedAt)
+	}
+	return len(messages), nil
+}
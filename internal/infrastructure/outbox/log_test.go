@@ -0,0 +1,38 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestLogSinkWritesEveryMessage(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewLogSink(slog.New(slog.NewJSONHandler(&buf, nil)))
+	require.Equal(t, SinkLog, sink.Name())
+
+	createdAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	done, err := sink.Publish(context.Background(), []domain.OutboxMessage{
+		{ID: 1, AggregateType: domain.OutboxAggregateTeam, AggregateID: "backend", EventType: domain.OutboxTeamCreated,
+			Payload: json.RawMessage(`{"team_name":"backend"}`), CreatedAt: createdAt},
+		{ID: 2, AggregateType: domain.OutboxAggregateUser, AggregateID: "u1", EventType: domain.OutboxUserActivityChanged,
+			Payload: json.RawMessage(`{"user_id":"u1","is_active":false}`), CreatedAt: createdAt},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, done)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(lines[1], &record))
+	require.Equal(t, float64(2), record["id"])
+	require.Equal(t, "u1", record["aggregate_id"])
+	require.Equal(t, domain.OutboxUserActivityChanged, record["event_type"])
+}
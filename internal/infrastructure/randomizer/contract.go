@@ -3,4 +3,6 @@ package randomizer
 // Randomizer предоставляет абстракцию для рандомизации.
 type Randomizer interface {
 	Shuffle(n int, swap func(i, j int))
+	// Intn возвращает случайное число в диапазоне [0, n).
+	Intn(n int) int
 }
@@ -29,3 +29,14 @@ func (r *randomizerImpl) Shuffle(n int, swap func(i, j int)) {
 	defer r.mu.Unlock()
 	r.rnd.Shuffle(n, swap)
 }
+
+// Intn возвращает случайное число в диапазоне [0, n). Для n <= 0 возвращает 0.
+// Потокобезопасна благодаря мьютексу.
+func (r *randomizerImpl) Intn(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Intn(n)
+}
@@ -27,3 +27,13 @@ func TestShuffleNoopForShortSlices(t *testing.T) {
 	})
 	require.Equal(t, []int{1}, values)
 }
+
+func TestIntnStaysInRange(t *testing.T) {
+	r := New()
+	for i := 0; i < 100; i++ {
+		v := r.Intn(3)
+		require.GreaterOrEqual(t, v, 0)
+		require.Less(t, v, 3)
+	}
+	require.Equal(t, 0, r.Intn(0))
+}
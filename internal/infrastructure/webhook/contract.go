@@ -0,0 +1,18 @@
+package webhook
+
+import "context"
+
+// Sender отправляет подписанное тело события на URL подписчика.
+type Sender interface {
+	// Send возвращает HTTP-код ответа получателя; ошибка означает, что ответ не получен.
+	Send(ctx context.Context, req Request) (int, error)
+}
+
+// Request описывает одну попытку доставки.
+type Request struct {
+	URL        string
+	Secret     string
+	EventType  string
+	DeliveryID int64
+	Body       []byte
+}
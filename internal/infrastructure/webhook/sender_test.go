@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignMatchesKnownVector(t *testing.T) {
+	// Пример из документации GitHub по проверке подписи webhook'ов
+	require.Equal(t,
+		"sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17",
+		Sign("It's a Secret to Everybody", []byte("Hello, World!")))
+}
+
+func TestSendSignsBody(t *testing.T) {
+	body := []byte(`{"event_id":1}`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, body, got)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, "ASSIGNED", r.Header.Get(HeaderEvent))
+		require.Equal(t, "42", r.Header.Get(HeaderDelivery))
+		require.Equal(t, Sign("secret", body), r.Header.Get(HeaderSignature))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	code, err := New(time.Second).Send(context.Background(), Request{
+		URL: srv.URL, Secret: "secret", EventType: "ASSIGNED", DeliveryID: 42, Body: body,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, code)
+}
+
+func TestSendReturnsErrorOnTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	_, err := New(20*time.Millisecond).Send(context.Background(), Request{URL: srv.URL, Body: []byte(`{}`)})
+	require.Error(t, err)
+}
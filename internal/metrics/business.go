@@ -1,8 +1,12 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"pr-reviewer-service_Avito/internal/domain"
 )
 
 var (
@@ -12,14 +16,59 @@ var (
 	usersProcessed = promauto.NewCounter(
 		prometheusCounterOpts("users_processed_total", "Total number of users processed via API"),
 	)
-	prCreated = promauto.NewCounter(
-		prometheusCounterOpts("pull_requests_created_total", "Total number of created pull requests"),
+	prCreated = promauto.NewCounterVec(
+		prometheusCounterOpts("pull_requests_created_total", "Total number of created pull requests by author team"),
+		[]string{"team"},
+	)
+	prMerged = promauto.NewCounterVec(
+		prometheusCounterOpts("pull_requests_merged_total", "Total number of merged pull requests by author team"),
+		[]string{"team"},
+	)
+	reassignments = promauto.NewCounterVec(
+		prometheusCounterOpts("reviewer_reassignments_total", "Total reviewer reassignments by pull request author team and event source"),
+		[]string{"team", "source"},
+	)
+	reviewsSubmitted = promauto.NewCounter(
+		prometheusCounterOpts("reviews_submitted_total", "Total number of submitted reviewer decisions"),
+	)
+	prStatusChanges = promauto.NewCounterVec(
+		prometheusCounterOpts("pull_request_status_changes_total", "Total pull request status transitions by target status"),
+		[]string{"status"},
+	)
+	slaEscalations = promauto.NewCounterVec(
+		prometheusCounterOpts("review_sla_escalations_total", "Total review SLA breaches by escalation action"),
+		[]string{"escalation"},
+	)
+	webhookAttempts = promauto.NewCounterVec(
+		prometheusCounterOpts("webhook_delivery_attempts_total", "Total webhook delivery attempts by resulting delivery status"),
+		[]string{"status"},
+	)
+	outboxPublished = promauto.NewCounterVec(
+		prometheusCounterOpts("outbox_messages_published_total", "Total outbox messages published by sink"),
+		[]string{"sink"},
+	)
+	timeToMerge = promauto.NewHistogramVec(
+		prometheusHistogramOpts("pull_request_time_to_merge_seconds", "Time from pull request creation to merge by author team"),
+		[]string{"team"},
 	)
-	reassignments = promauto.NewCounter(
-		prometheusCounterOpts("reviewer_reassignments_total", "Total reviewer reassignments"),
+	reviewDecisionLatency = promauto.NewHistogramVec(
+		prometheusHistogramOpts("review_decision_latency_seconds",
+			"Time from reviewer assignment to the reviewer's first decision by pull request author team"),
+		[]string{"team"},
+	)
+	openPullRequests = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{Name: "open_pull_requests", Help: "Current number of open pull requests by author team"},
+		[]string{"team"},
+	)
+	openReviews = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{Name: "reviewer_open_reviews", Help: "Current number of open pull requests assigned to a reviewer"},
+		[]string{"team", "reviewer"},
 	)
 )
 
+// latencyBuckets — границы гистограмм задержек ревью: от минуты до ~23 суток.
+var latencyBuckets = prometheus.ExponentialBuckets(60, 2, 16)
+
 // IncTeamsCreated увеличивает счётчик созданных команд.
 func IncTeamsCreated() {
 	teamsCreated.Inc()
@@ -33,14 +82,70 @@ func AddUsersProcessed(delta int) {
 	usersProcessed.Add(float64(delta))
 }
 
-// IncPullRequestsCreated увеличивает счётчик созданных PR.
-func IncPullRequestsCreated() {
-	prCreated.Inc()
+// IncPullRequestsCreated увеличивает счётчик созданных PR авторов команды team.
+func IncPullRequestsCreated(team string) {
+	prCreated.WithLabelValues(team).Inc()
+}
+
+// IncPullRequestsMerged увеличивает счётчик смерженных PR авторов команды team.
+func IncPullRequestsMerged(team string) {
+	prMerged.WithLabelValues(team).Inc()
 }
 
-// IncReassignments увеличивает счётчик выполненных переназначений.
-func IncReassignments() {
-	reassignments.Inc()
+// IncReassignments увеличивает счётчик переназначений на PR авторов команды team, выполненных из источника source.
+func IncReassignments(team, source string) {
+	reassignments.WithLabelValues(team, source).Inc()
+}
+
+// IncReviewsSubmitted увеличивает счётчик решений ревьюверов.
+func IncReviewsSubmitted() {
+	reviewsSubmitted.Inc()
+}
+
+// IncPullRequestStatusChanges увеличивает счётчик переходов PR в статус status.
+func IncPullRequestStatusChanges(status domain.PRStatus) {
+	prStatusChanges.WithLabelValues(string(status)).Inc()
+}
+
+// IncSLAEscalations увеличивает счётчик нарушений SLA на ревью, обработанных действием escalation.
+func IncSLAEscalations(escalation domain.SLAEscalation) {
+	slaEscalations.WithLabelValues(string(escalation)).Inc()
+}
+
+// IncWebhookAttempts увеличивает счётчик попыток доставки webhook'ов, после которых доставка перешла в status.
+func IncWebhookAttempts(status domain.WebhookDeliveryStatus) {
+	webhookAttempts.WithLabelValues(string(status)).Inc()
+}
+
+// AddOutboxPublished увеличивает счётчик записей outbox, опубликованных в приёмник sink.
+func AddOutboxPublished(sink string, delta int) {
+	if delta <= 0 {
+		return
+	}
+	outboxPublished.WithLabelValues(sink).Add(float64(delta))
+}
+
+// ObserveTimeToMerge записывает время от создания до merge PR команды team.
+func ObserveTimeToMerge(team string, d time.Duration) {
+	timeToMerge.WithLabelValues(team).Observe(d.Seconds())
+}
+
+// ObserveReviewDecisionLatency записывает время от назначения ревьювера до его первого решения по PR команды team.
+func ObserveReviewDecisionLatency(team string, d time.Duration) {
+	reviewDecisionLatency.WithLabelValues(team).Observe(d.Seconds())
+}
+
+// SetWorkload заменяет значения gauge'ей текущей нагрузки снимком workload.
+// Команды и ревьюверы, которых нет в снимке, перестают экспортироваться.
+func SetWorkload(workload domain.Workload) {
+	openPullRequests.Reset()
+	for _, team := range workload.Teams {
+		openPullRequests.WithLabelValues(team.TeamName).Set(float64(team.OpenPullRequests))
+	}
+	openReviews.Reset()
+	for _, reviewer := range workload.Reviewers {
+		openReviews.WithLabelValues(reviewer.TeamName, reviewer.ReviewerID).Set(float64(reviewer.OpenReviews))
+	}
 }
 
 func prometheusCounterOpts(name, help string) prometheus.CounterOpts {
@@ -49,3 +154,11 @@ func prometheusCounterOpts(name, help string) prometheus.CounterOpts {
 		Help: help,
 	}
 }
+
+func prometheusHistogramOpts(name, help string) prometheus.HistogramOpts {
+	return prometheus.HistogramOpts{
+		Name:    name,
+		Help:    help,
+		Buckets: latencyBuckets,
+	}
+}
@@ -2,9 +2,12 @@ package metrics
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
 )
 
 func TestBusinessCounters(t *testing.T) {
@@ -12,13 +15,39 @@ func TestBusinessCounters(t *testing.T) {
 	IncTeamsCreated()
 	require.Equal(t, beforeTeams+1, testutil.ToFloat64(teamsCreated))
 
-	beforePR := testutil.ToFloat64(prCreated)
-	IncPullRequestsCreated()
-	require.Equal(t, beforePR+1, testutil.ToFloat64(prCreated))
+	created := prCreated.WithLabelValues("backend")
+	beforePR := testutil.ToFloat64(created)
+	IncPullRequestsCreated("backend")
+	require.Equal(t, beforePR+1, testutil.ToFloat64(created))
+
+	merged := prMerged.WithLabelValues("backend")
+	beforeMerged := testutil.ToFloat64(merged)
+	IncPullRequestsMerged("backend")
+	require.Equal(t, beforeMerged+1, testutil.ToFloat64(merged))
+
+	reassigned := reassignments.WithLabelValues("backend", "MANUAL_REASSIGN")
+	beforeReassign := testutil.ToFloat64(reassigned)
+	IncReassignments("backend", "MANUAL_REASSIGN")
+	require.Equal(t, beforeReassign+1, testutil.ToFloat64(reassigned))
+
+	beforeReviews := testutil.ToFloat64(reviewsSubmitted)
+	IncReviewsSubmitted()
+	require.Equal(t, beforeReviews+1, testutil.ToFloat64(reviewsSubmitted))
 
-	beforeReassign := testutil.ToFloat64(reassignments)
-	IncReassignments()
-	require.Equal(t, beforeReassign+1, testutil.ToFloat64(reassignments))
+	closed := prStatusChanges.WithLabelValues(string(domain.PRStatusClosed))
+	beforeClosed := testutil.ToFloat64(closed)
+	IncPullRequestStatusChanges(domain.PRStatusClosed)
+	require.Equal(t, beforeClosed+1, testutil.ToFloat64(closed))
+
+	escalated := slaEscalations.WithLabelValues(string(domain.SLAEscalationReassign))
+	beforeSLA := testutil.ToFloat64(escalated)
+	IncSLAEscalations(domain.SLAEscalationReassign)
+	require.Equal(t, beforeSLA+1, testutil.ToFloat64(escalated))
+
+	failed := webhookAttempts.WithLabelValues(string(domain.WebhookDeliveryFailed))
+	beforeFailed := testutil.ToFloat64(failed)
+	IncWebhookAttempts(domain.WebhookDeliveryFailed)
+	require.Equal(t, beforeFailed+1, testutil.ToFloat64(failed))
 }
 
 func TestAddUsersProcessedIgnoresNonPositive(t *testing.T) {
@@ -28,3 +57,36 @@ func TestAddUsersProcessedIgnoresNonPositive(t *testing.T) {
 	AddUsersProcessed(2)
 	require.Equal(t, before+2, testutil.ToFloat64(usersProcessed))
 }
+
+func TestAddOutboxPublishedCountsBySink(t *testing.T) {
+	logSink := outboxPublished.WithLabelValues("log")
+	before := testutil.ToFloat64(logSink)
+	AddOutboxPublished("log", 0)
+	require.Equal(t, before, testutil.ToFloat64(logSink))
+	AddOutboxPublished("log", 3)
+	require.Equal(t, before+3, testutil.ToFloat64(logSink))
+}
+
+func TestLatencyHistogramsObserveSeconds(t *testing.T) {
+	before := testutil.CollectAndCount(timeToMerge)
+	ObserveTimeToMerge("latency-test", 90*time.Minute)
+	require.Equal(t, before+1, testutil.CollectAndCount(timeToMerge))
+
+	before = testutil.CollectAndCount(reviewDecisionLatency)
+	ObserveReviewDecisionLatency("latency-test", time.Hour)
+	require.Equal(t, before+1, testutil.CollectAndCount(reviewDecisionLatency))
+}
+
+func TestSetWorkloadReplacesSnapshot(t *testing.T) {
+	SetWorkload(domain.Workload{
+		Teams:     []domain.TeamWorkload{{TeamName: "backend", OpenPullRequests: 3}, {TeamName: "frontend", OpenPullRequests: 1}},
+		Reviewers: []domain.ReviewerWorkload{{ReviewerID: "u1", TeamName: "backend", OpenReviews: 2}},
+	})
+	require.Equal(t, 3.0, testutil.ToFloat64(openPullRequests.WithLabelValues("backend")))
+	require.Equal(t, 2.0, testutil.ToFloat64(openReviews.WithLabelValues("backend", "u1")))
+
+	SetWorkload(domain.Workload{Teams: []domain.TeamWorkload{{TeamName: "backend", OpenPullRequests: 2}}})
+	require.Equal(t, 1, testutil.CollectAndCount(openPullRequests))
+	require.Equal(t, 2.0, testutil.ToFloat64(openPullRequests.WithLabelValues("backend")))
+	require.Equal(t, 0, testutil.CollectAndCount(openReviews))
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+// ListEvents возвращает события журнала назначений, подходящие под фильтр, в порядке id.
+func (s *Storage) ListEvents(ctx context.Context, filter domain.EventFilter) ([]domain.AssignmentEvent, error) {
+	events := []domain.AssignmentEvent{}
+	err := s.StreamEvents(ctx, filter, func(event domain.AssignmentEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	return events, err
+}
+
+// StreamEvents передаёт в fn события журнала назначений, подходящие под фильтр, в порядке id,
+// не загружая выборку в память целиком. Ошибка fn прекращает чтение и возвращается.
+func (s *Storage) StreamEvents(ctx context.Context, filter domain.EventFilter, fn func(domain.AssignmentEvent) error) error {
+	query := s.sb.
+		Select("e.id", "e.pull_request_id", "e.reviewer_id", "e.event_type", "e.source", "a.team_name", "e.created_at").
+		From("review_assignment_events e").
+		Join("pull_requests p ON p.pull_request_id=e.pull_request_id").
+		Join("users a ON a.user_id=p.author_id").
+		Where(squirrel.Gt{"e.id": filter.AfterID}).
+		OrderBy("e.id")
+	if filter.PullRequestID != "" {
+		query = query.Where(squirrel.Eq{"e.pull_request_id": filter.PullRequestID})
+	}
+	if filter.ReviewerID != "" {
+		query = query.Where(squirrel.Eq{"e.reviewer_id": filter.ReviewerID})
+	}
+	if filter.TeamName != "" {
+		query = query.Where(squirrel.Eq{"a.team_name": filter.TeamName})
+	}
+	if filter.EventType != "" {
+		query = query.Where(squirrel.Eq{"e.event_type": string(filter.EventType)})
+	}
+	if filter.Source != "" {
+		query = query.Where(squirrel.Eq{"e.source": filter.Source})
+	}
+	if filter.From != nil {
+		query = query.Where(squirrel.GtOrEq{"e.created_at": *filter.From})
+	}
+	if filter.To != nil {
+		query = query.Where(squirrel.Lt{"e.created_at": *filter.To})
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(uint64(filter.Limit))
+	}
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to query events", "error", err)
+		return fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var event domain.AssignmentEvent
+		if err := rows.Scan(&event.ID, &event.PullRequestID, &event.ReviewerID, &event.EventType, &event.Source,
+			&event.TeamName, &event.CreatedAt); err != nil {
+			return fmt.Errorf("%w: %v", ErrScanResult, err)
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+var eventRowColumns = []string{"id", "pull_request_id", "reviewer_id", "event_type", "source", "team_name", "created_at"}
+
+func TestStorageListEventsAppliesFilters(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	createdAt := from.Add(time.Hour)
+	reviewer := "u2"
+	mock.ExpectQuery(`SELECT e.id, .* FROM review_assignment_events e JOIN pull_requests p .* JOIN users a .*`+
+		`WHERE e.id > \$1 AND e.pull_request_id = \$2 AND e.reviewer_id = \$3 AND a.team_name = \$4 `+
+		`AND e.event_type = \$5 AND e.source = \$6 AND e.created_at >= \$7 AND e.created_at < \$8 ORDER BY e.id LIMIT 3`).
+		WithArgs(int64(10), "pr-1", "u2", "backend", "ASSIGNED", "AUTO", from, to).
+		WillReturnRows(pgxmock.NewRows(eventRowColumns).
+			AddRow(int64(11), "pr-1", &reviewer, domain.EventAssigned, "AUTO", "backend", createdAt))
+
+	events, err := storage.ListEvents(ctx, domain.EventFilter{
+		PullRequestID: "pr-1", ReviewerID: "u2", TeamName: "backend", EventType: domain.EventAssigned, Source: "AUTO",
+		From: &from, To: &to, AfterID: 10, Limit: 3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []domain.AssignmentEvent{{
+		ID: 11, PullRequestID: "pr-1", ReviewerID: &reviewer, EventType: domain.EventAssigned,
+		Source: "AUTO", TeamName: "backend", CreatedAt: createdAt,
+	}}, events)
+}
+
+func TestStorageStreamEventsWithoutFilters(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`WHERE e.id > \$1 ORDER BY e.id$`).WithArgs(int64(0)).
+		WillReturnRows(pgxmock.NewRows(eventRowColumns).
+			AddRow(int64(1), "pr-1", nil, domain.EventMerged, "STATUS_CHANGE", "backend", createdAt).
+			AddRow(int64(2), "pr-2", nil, domain.EventClosed, "STATUS_CHANGE", "backend", createdAt))
+
+	var ids []int64
+	err := storage.StreamEvents(ctx, domain.EventFilter{}, func(event domain.AssignmentEvent) error {
+		ids = append(ids, event.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2}, ids)
+}
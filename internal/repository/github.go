@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+)
+
+// ClaimGitHubDelivery регистрирует входящую доставку GitHub. Возвращает false, если доставка
+// с таким deliveryID уже была принята.
+func (s *Storage) ClaimGitHubDelivery(ctx context.Context, deliveryID, event, action string) (bool, error) {
+	cmd, err := s.conn(ctx).Exec(ctx, `
+		INSERT INTO github_deliveries (delivery_id, event, action, received_at)
+		VALUES ($1,$2,$3,$4)
+		ON CONFLICT (delivery_id) DO NOTHING
+	`, deliveryID, event, action, s.nower.Now())
+	if err != nil {
+		return false, err
+	}
+	return cmd.RowsAffected() == 1, nil
+}
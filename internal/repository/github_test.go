@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageClaimGitHubDelivery(t *testing.T) {
+	storage, mock, n := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectExec(`INSERT INTO github_deliveries .*ON CONFLICT \(delivery_id\) DO NOTHING`).
+		WithArgs("d-1", "pull_request", "opened", n.now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`INSERT INTO github_deliveries`).
+		WithArgs("d-1", "pull_request", "opened", n.now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+
+	claimed, err := storage.ClaimGitHubDelivery(ctx, "d-1", "pull_request", "opened")
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = storage.ClaimGitHubDelivery(ctx, "d-1", "pull_request", "opened")
+	require.NoError(t, err)
+	require.False(t, claimed)
+}
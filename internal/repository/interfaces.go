@@ -2,32 +2,36 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"pr-reviewer-service_Avito/internal/domain"
 )
 
-// TransactionalRepository позволяет выполнять операции в транзакции.
-type TransactionalRepository interface {
-	WithTransaction(ctx context.Context, fn func(Repository) error) error
-}
-
 // Repository объединяет все доменные репозитории.
 type Repository interface {
 	TeamRepository
 	UserRepository
 	PRRepository
 	StatsRepository
+	WebhookRepository
+	OutboxRepository
+	GitHubRepository
+	EventRepository
 }
 
 // TeamRepository содержит операции для работы с командами.
 type TeamRepository interface {
 	CreateTeam(ctx context.Context, team domain.Team) (domain.Team, error)
 	GetTeam(ctx context.Context, teamName string) (domain.Team, error)
+	GetTeamSettings(ctx context.Context, teamName string) (domain.TeamSettings, error)
+	UpdateTeamSettings(ctx context.Context, settings domain.TeamSettings) (domain.TeamSettings, error)
 }
 
 // UserRepository содержит операции для работы с пользователями.
 type UserRepository interface {
 	SetUserActivity(ctx context.Context, userID string, active bool) (domain.User, error)
+	SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error)
+	SetUserRole(ctx context.Context, userID, role string) (domain.User, error)
 	GetUserByID(ctx context.Context, userID string) (domain.User, error)
 	ListActiveTeamMembers(ctx context.Context, teamName string, exclude []string) ([]domain.User, error)
 	DeactivateUsers(ctx context.Context, userIDs []string) ([]domain.User, error)
@@ -38,14 +42,64 @@ type PRRepository interface {
 	CreatePullRequest(ctx context.Context, pr domain.PullRequest, reviewers []string) (domain.PullRequest, error)
 	UpdatePRStatus(ctx context.Context, prID string, status domain.PRStatus) (domain.PullRequest, error)
 	GetPullRequest(ctx context.Context, prID string) (domain.PullRequest, error)
+	LockPullRequest(ctx context.Context, prID string) error
+	ListPullRequests(ctx context.Context, filter domain.PullRequestFilter) ([]domain.PullRequestShort, error)
+	MarkPRReady(ctx context.Context, prID string, reviewers []string) (domain.PullRequest, error)
+	ClosePullRequest(ctx context.Context, prID string) (domain.PullRequest, error)
+	ReopenPullRequest(ctx context.Context, prID string, reviewers []string) (domain.PullRequest, error)
 	ReplaceReviewer(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error)
-	ListReviewAssignments(ctx context.Context, userID string) ([]domain.PullRequestShort, error)
+	AddReviewers(ctx context.Context, prID string, reviewers []string, source string) (domain.PullRequest, error)
+	SubmitReview(ctx context.Context, prID, reviewerID string, decision domain.ReviewDecision) (domain.PullRequest, error)
+	ListReviewAssignments(ctx context.Context, filter domain.ReviewAssignmentFilter) ([]domain.PullRequestShort, error)
+	CountReviewAssignments(ctx context.Context, filter domain.ReviewAssignmentFilter) (int64, error)
 	ListOpenPRsByReviewer(ctx context.Context, reviewerIDs []string) (map[string][]string, error)
+	ListPendingReviews(ctx context.Context, assignedBefore time.Time) ([]domain.PendingReview, error)
+	RecordSLABreach(ctx context.Context, prID, reviewerID, source string) error
 }
 
 // StatsRepository содержит операции для получения статистики.
 type StatsRepository interface {
-	FetchAssignmentStats(ctx context.Context) (domain.AssignmentStats, error)
+	FetchAssignmentStats(ctx context.Context, filter domain.StatsFilter) (domain.AssignmentStats, error)
+	StreamUserAssignmentStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.UserAssignmentStat) error) error
+	StreamPullRequestStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.PRAssignmentStat) error) error
+	StreamSourceStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.SourceAssignmentStat) error) error
+	FetchLatencyStats(ctx context.Context, filter domain.LatencyFilter) (domain.LatencyStats, error)
+	FetchMemberAssignments(ctx context.Context, filter domain.FairnessFilter) ([]domain.MemberAssignments, error)
+	FetchUserAssignmentStats(ctx context.Context, userIDs []string) ([]domain.UserAssignmentStat, error)
+	FetchWorkload(ctx context.Context) (domain.Workload, error)
+}
+
+// WebhookRepository содержит операции для работы с подписками на webhook'и и их доставками.
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error)
+	GetWebhook(ctx context.Context, id int64) (domain.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ClaimWebhookDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error)
+	SaveWebhookAttempt(ctx context.Context, delivery domain.WebhookDelivery) error
+	ListWebhookDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)
+	RedeliverWebhookDelivery(ctx context.Context, id int64, now time.Time) (domain.WebhookDelivery, error)
+}
+
+// OutboxRepository содержит операции relay transactional outbox.
+type OutboxRepository interface {
+	AcquireOutboxLease(ctx context.Context, token string, now time.Time, ttl time.Duration) (bool, error)
+	ReleaseOutboxLease(ctx context.Context, token string, now time.Time) error
+	ListUnpublishedOutbox(ctx context.Context, limit int) ([]domain.OutboxMessage, error)
+	MarkOutboxSinkPublished(ctx context.Context, ids []int64, sink string, sinks []string, publishedAt time.Time) error
+	MarkOutboxPublished(ctx context.Context, ids []int64, publishedAt time.Time) error
+}
+
+// GitHubRepository содержит операции учёта входящих webhook'ов GitHub.
+type GitHubRepository interface {
+	ClaimGitHubDelivery(ctx context.Context, deliveryID, event, action string) (bool, error)
+}
+
+// EventRepository содержит операции чтения журнала назначений.
+type EventRepository interface {
+	ListEvents(ctx context.Context, filter domain.EventFilter) ([]domain.AssignmentEvent, error)
+	StreamEvents(ctx context.Context, filter domain.EventFilter, fn func(domain.AssignmentEvent) error) error
 }
 
 // HealthChecker описывает метод проверки соединения.
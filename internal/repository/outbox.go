@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+// withOutbox дополняет вставку события в review_assignment_events записью outbox и доставками
+// webhook'ов в том же запросе. Доставки создаются для активных подписок, чьи фильтры по типу события
+// и команде автора PR подходят событию, поэтому событие не может разминуться с подписками,
+// даже если его транзакция фиксируется позже транзакций с бо́льшими id.
+// insertEvent — INSERT ... VALUES (...) без RETURNING.
+func withOutbox(insertEvent string) string {
+	return withOutboxPayload(insertEvent, "")
+}
+
+// withReplacementOutbox — withOutbox для назначения, заменяющего снятого ревьювера: payload outbox
+// дополнительно содержит replaces — user_id снятого ревьювера из параметра $4. Так замена видна
+// приёмнику в одной записи, независимо от того, в какие пачки попадут соседние.
+func withReplacementOutbox(insertEvent string) string {
+	return withOutboxPayload(insertEvent, `,
+			'replaces', $4::text`)
+}
+
+// withOutboxPayload собирает запрос withOutbox; extraPayload дописывается в конец jsonb_build_object
+// payload outbox.
+func withOutboxPayload(insertEvent, extraPayload string) string {
+	return `
+		WITH e AS (` + insertEvent + `
+			RETURNING id, pull_request_id, reviewer_id, event_type, source, created_at
+		), deliveries AS (
+			INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+			SELECT w.id, e.id, e.event_type, jsonb_build_object(
+				'event_id', e.id,
+				'event_type', e.event_type,
+				'pull_request_id', e.pull_request_id,
+				'reviewer_id', e.reviewer_id,
+				'source', e.source,
+				'team_name', a.team_name,
+				'created_at', e.created_at
+			)
+			FROM e
+			JOIN pull_requests p ON p.pull_request_id=e.pull_request_id
+			JOIN users a ON a.user_id=p.author_id
+			JOIN webhook_subscriptions w ON w.is_active
+				AND (cardinality(w.event_types) = 0 OR e.event_type = ANY(w.event_types))
+				AND (cardinality(w.teams) = 0 OR a.team_name = ANY(w.teams))
+		)
+		INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload, created_at)
+		SELECT '` + domain.OutboxAggregatePullRequest + `', e.pull_request_id, e.event_type, jsonb_build_object(
+			'event_id', e.id,
+			'pull_request_id', e.pull_request_id,
+			'reviewer_id', e.reviewer_id,
+			'event_type', e.event_type,
+			'source', e.source,
+			'created_at', e.created_at` + extraPayload + `
+		), e.created_at
+		FROM e`
+}
+
+// enqueueOutbox записывает доменное событие в outbox. Вызывается в транзакции изменения состояния.
+func enqueueOutbox(ctx context.Context, q querier, aggregateType, aggregateID, eventType string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal outbox payload: %w", err)
+	}
+	_, err = q.Exec(ctx, `
+		INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+		VALUES ($1,$2,$3,$4)
+	`, aggregateType, aggregateID, eventType, body)
+	return err
+}
+
+// AcquireOutboxLease захватывает аренду relay до now+ttl, если предыдущая аренда истекла.
+// Возвращает false, если аренда принадлежит другому экземпляру сервиса.
+func (s *Storage) AcquireOutboxLease(ctx context.Context, token string, now time.Time, ttl time.Duration) (bool, error) {
+	cmd, err := s.conn(ctx).Exec(ctx, `
+		UPDATE outbox_relay_lease SET token=$1, expires_at=$3 WHERE expires_at <= $2
+	`, token, now, now.Add(ttl))
+	if err != nil {
+		return false, err
+	}
+	return cmd.RowsAffected() == 1, nil
+}
+
+// ReleaseOutboxLease освобождает аренду relay, если она всё ещё принадлежит token.
+func (s *Storage) ReleaseOutboxLease(ctx context.Context, token string, now time.Time) error {
+	_, err := s.conn(ctx).Exec(ctx, `UPDATE outbox_relay_lease SET expires_at=$2 WHERE token=$1`, token, now)
+	return err
+}
+
+// ListUnpublishedOutbox возвращает до limit неопубликованных записей outbox в порядке записи
+// вместе с приёмниками, которые их уже приняли.
+func (s *Storage) ListUnpublishedOutbox(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	rows, err := s.conn(ctx).Query(ctx, `
+		SELECT id, aggregate_type, aggregate_id, event_type, payload, created_at, published_sinks
+		FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.OutboxMessage, error) {
+		var msg domain.OutboxMessage
+		err := row.Scan(&msg.ID, &msg.AggregateType, &msg.AggregateID, &msg.EventType, &msg.Payload, &msg.CreatedAt,
+			&msg.PublishedSinks)
+		return msg, err
+	})
+}
+
+// MarkOutboxSinkPublished отмечает, что приёмник sink принял записи outbox. Запись становится опубликованной,
+// когда её приняли все приёмники sinks.
+func (s *Storage) MarkOutboxSinkPublished(ctx context.Context, ids []int64, sink string, sinks []string, publishedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := s.conn(ctx).Exec(ctx, `
+		UPDATE outbox
+		SET published_sinks=array_append(published_sinks, $2),
+			published_at=CASE WHEN $3::text[] <@ array_append(published_sinks, $2) THEN $4::timestamptz END
+		WHERE id = ANY($1) AND NOT ($2 = ANY(published_sinks))
+	`, ids, sink, sinks, publishedAt)
+	return err
+}
+
+// MarkOutboxPublished отмечает записи outbox опубликованными.
+func (s *Storage) MarkOutboxPublished(ctx context.Context, ids []int64, publishedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := s.conn(ctx).Exec(ctx, `UPDATE outbox SET published_at=$2 WHERE id = ANY($1)`, ids, publishedAt)
+	return err
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestStorageOutboxLease(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`UPDATE outbox_relay_lease SET token=\$1, expires_at=\$3 WHERE expires_at <= \$2`).
+		WithArgs("token-1", now, now.Add(time.Minute)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`UPDATE outbox_relay_lease SET token=\$1, expires_at=\$3 WHERE expires_at <= \$2`).
+		WithArgs("token-2", now, now.Add(time.Minute)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec(`UPDATE outbox_relay_lease SET expires_at=\$2 WHERE token=\$1`).WithArgs("token-1", now).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	acquired, err := storage.AcquireOutboxLease(ctx, "token-1", now, time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	// Аренда занята другим экземпляром
+	acquired, err = storage.AcquireOutboxLease(ctx, "token-2", now, time.Minute)
+	require.NoError(t, err)
+	require.False(t, acquired)
+	require.NoError(t, storage.ReleaseOutboxLease(ctx, "token-1", now))
+}
+
+func TestStorageListUnpublishedOutbox(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`FROM outbox\s+WHERE published_at IS NULL\s+ORDER BY id`).WithArgs(10).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "aggregate_type", "aggregate_id", "event_type", "payload", "created_at",
+			"published_sinks"}).
+			AddRow(int64(1), "team", "backend", "TEAM_CREATED", []byte(`{"team_name":"backend"}`), createdAt, []string{}).
+			AddRow(int64(2), "pull_request", "pr-1", "ASSIGNED", []byte(`{"event_id":7}`), createdAt, []string{"log"}))
+
+	messages, err := storage.ListUnpublishedOutbox(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, []domain.OutboxMessage{
+		{ID: 1, AggregateType: "team", AggregateID: "backend", EventType: "TEAM_CREATED",
+			Payload: json.RawMessage(`{"team_name":"backend"}`), CreatedAt: createdAt, PublishedSinks: []string{}},
+		{ID: 2, AggregateType: "pull_request", AggregateID: "pr-1", EventType: "ASSIGNED",
+			Payload: json.RawMessage(`{"event_id":7}`), CreatedAt: createdAt, PublishedSinks: []string{"log"}},
+	}, messages)
+}
+
+func TestStorageMarkOutboxSinkPublished(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	sinks := []string{"log", "github"}
+	mock.ExpectExec(`UPDATE outbox\s+SET published_sinks=array_append\(published_sinks, \$2\),\s+`+
+		`published_at=CASE WHEN \$3::text\[\] <@ array_append\(published_sinks, \$2\) THEN \$4::timestamptz END\s+`+
+		`WHERE id = ANY\(\$1\) AND NOT \(\$2 = ANY\(published_sinks\)\)`).
+		WithArgs([]int64{1, 2}, "github", sinks, now).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+
+	require.NoError(t, storage.MarkOutboxSinkPublished(ctx, []int64{1, 2}, "github", sinks, now))
+	// Пустая пачка не обращается к БД
+	require.NoError(t, storage.MarkOutboxSinkPublished(ctx, nil, "github", sinks, now))
+}
+
+func TestStorageMarkOutboxPublished(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`UPDATE outbox SET published_at=\$2 WHERE id = ANY\(\$1\)`).WithArgs([]int64{1, 2}, now).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+
+	require.NoError(t, storage.MarkOutboxPublished(ctx, []int64{1, 2}, now))
+	// Пустая пачка не обращается к БД
+	require.NoError(t, storage.MarkOutboxPublished(ctx, nil, now))
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+// likeEscaper экранирует спецсимволы шаблона LIKE, чтобы подстрока поиска сравнивалась буквально.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ListPullRequests возвращает PR, подходящие под фильтр, с текущими ревьюверами в порядке сортировки фильтра,
+// начиная после курсора. Поиск по названию использует триграммный индекс (см. миграцию 016).
+func (s *Storage) ListPullRequests(ctx context.Context, filter domain.PullRequestFilter) ([]domain.PullRequestShort, error) {
+	sortColumn := "p.created_at"
+	if filter.Sort == domain.PullRequestSortName {
+		sortColumn = "p.pull_request_name"
+	}
+	direction, compare := "ASC", ">"
+	if filter.Desc {
+		direction, compare = "DESC", "<"
+	}
+	query := s.sb.
+		Select("p.pull_request_id", "p.pull_request_name", "p.author_id", "p.status", "p.created_at", "p.merged_at",
+			"ARRAY(SELECT rr.reviewer_id FROM pull_request_reviewers rr "+
+				"WHERE rr.pull_request_id=p.pull_request_id ORDER BY rr.reviewer_id)").
+		From("pull_requests p").
+		OrderBy(sortColumn+" "+direction, "p.pull_request_id "+direction)
+	if filter.TeamName != "" {
+		query = query.
+			Join("users a ON a.user_id=p.author_id").
+			Where(squirrel.Eq{"a.team_name": filter.TeamName})
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		query = query.Where(squirrel.Eq{"p.status": statuses})
+	}
+	if filter.AuthorID != "" {
+		query = query.Where(squirrel.Eq{"p.author_id": filter.AuthorID})
+	}
+	if filter.ReviewerID != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM pull_request_reviewers r "+
+			"WHERE r.pull_request_id=p.pull_request_id AND r.reviewer_id=?)", filter.ReviewerID)
+	}
+	if filter.WithoutReviewers {
+		query = query.Where("NOT EXISTS (SELECT 1 FROM pull_request_reviewers r WHERE r.pull_request_id=p.pull_request_id)")
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where(squirrel.GtOrEq{"p.created_at": *filter.CreatedFrom})
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where(squirrel.Lt{"p.created_at": *filter.CreatedTo})
+	}
+	if filter.MergedFrom != nil {
+		query = query.Where(squirrel.GtOrEq{"p.merged_at": *filter.MergedFrom})
+	}
+	if filter.MergedTo != nil {
+		query = query.Where(squirrel.Lt{"p.merged_at": *filter.MergedTo})
+	}
+	if filter.Query != "" {
+		query = query.Where(squirrel.ILike{"p.pull_request_name": "%" + likeEscaper.Replace(filter.Query) + "%"})
+	}
+	if filter.After != nil {
+		var after any = filter.After.CreatedAt
+		if filter.Sort == domain.PullRequestSortName {
+			after = filter.After.Name
+		}
+		query = query.Where("("+sortColumn+", p.pull_request_id) "+compare+" (?, ?)", after, filter.After.ID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(uint64(filter.Limit))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to query pull requests", "error", err)
+		return nil, fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+	}
+	defer rows.Close()
+	result := []domain.PullRequestShort{}
+	for rows.Next() {
+		var pr domain.PullRequestShort
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &pr.AssignedReviewers); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrScanResult, err)
+		}
+		result = append(result, pr)
+	}
+	return result, rows.Err()
+}
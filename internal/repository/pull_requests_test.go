@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+var pullRequestRowColumns = []string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at", "reviewers"}
+
+func TestStorageListPullRequestsDefaults(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	created := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at, p.merged_at, ARRAY\(.*\) ` +
+		`FROM pull_requests p ORDER BY p.created_at ASC, p.pull_request_id ASC`).
+		WillReturnRows(pgxmock.NewRows(pullRequestRowColumns).
+			AddRow("pr-1", "Add search", "u1", domain.PRStatusOpen, created, nil, []string{"u2"}))
+
+	prs, err := storage.ListPullRequests(ctx, domain.PullRequestFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []domain.PullRequestShort{{
+		ID: "pr-1", Name: "Add search", AuthorID: "u1", Status: domain.PRStatusOpen, CreatedAt: created,
+		AssignedReviewers: []string{"u2"},
+	}}, prs)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStorageListPullRequestsAppliesFilters(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	mock.ExpectQuery(`SELECT .* FROM pull_requests p JOIN users a ON a.user_id=p.author_id `+
+		`WHERE a.team_name = \$1 AND p.status IN \(\$2\) AND p.author_id = \$3 `+
+		`AND EXISTS \(SELECT 1 FROM pull_request_reviewers r WHERE r.pull_request_id=p.pull_request_id AND r.reviewer_id=\$4\) `+
+		`AND p.created_at >= \$5 AND p.created_at < \$6 AND p.merged_at >= \$7 AND p.merged_at < \$8 `+
+		`AND p.pull_request_name ILIKE \$9 AND \(p.pull_request_name, p.pull_request_id\) < \(\$10, \$11\) `+
+		`ORDER BY p.pull_request_name DESC, p.pull_request_id DESC LIMIT 21`).
+		WithArgs("backend", "MERGED", "u1", "u2", from, to, from, to, `%50\%\_off%`, "Fix", "pr-9").
+		WillReturnRows(pgxmock.NewRows(pullRequestRowColumns))
+
+	prs, err := storage.ListPullRequests(ctx, domain.PullRequestFilter{
+		Statuses:    []domain.PRStatus{domain.PRStatusMerged},
+		AuthorID:    "u1",
+		TeamName:    "backend",
+		ReviewerID:  "u2",
+		CreatedFrom: &from,
+		CreatedTo:   &to,
+		MergedFrom:  &from,
+		MergedTo:    &to,
+		Query:       "50%_off",
+		Sort:        domain.PullRequestSortName,
+		Desc:        true,
+		After:       &domain.PullRequestCursor{Sort: domain.PullRequestSortName, Desc: true, Name: "Fix", ID: "pr-9"},
+		Limit:       21,
+	})
+	require.NoError(t, err)
+	require.Empty(t, prs)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStorageListPullRequestsWithoutReviewers(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	after := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT .* FROM pull_requests p `+
+		`WHERE NOT EXISTS \(SELECT 1 FROM pull_request_reviewers r WHERE r.pull_request_id=p.pull_request_id\) `+
+		`AND \(p.created_at, p.pull_request_id\) > \(\$1, \$2\) ORDER BY p.created_at ASC, p.pull_request_id ASC`).
+		WithArgs(after, "pr-3").
+		WillReturnRows(pgxmock.NewRows(pullRequestRowColumns))
+
+	_, err := storage.ListPullRequests(ctx, domain.PullRequestFilter{
+		WithoutReviewers: true,
+		After:            &domain.PullRequestCursor{CreatedAt: after, ID: "pr-3"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
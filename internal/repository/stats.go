@@ -0,0 +1,427 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+// FetchAssignmentStats собирает статистику назначений по пользователям, PR и источникам событий.
+// Интервал фильтра относится ко времени назначения ревьювера для per_user, ко времени создания PR
+// для per_pull_request и ко времени события для by_source.
+func (s *Storage) FetchAssignmentStats(ctx context.Context, filter domain.StatsFilter) (domain.AssignmentStats, error) {
+	stats := domain.AssignmentStats{
+		PerUser:  []domain.UserAssignmentStat{},
+		PerPR:    []domain.PRAssignmentStat{},
+		BySource: []domain.SourceAssignmentStat{},
+	}
+	if err := s.StreamUserAssignmentStats(ctx, filter, func(stat domain.UserAssignmentStat) error {
+		stats.PerUser = append(stats.PerUser, stat)
+		return nil
+	}); err != nil {
+		return domain.AssignmentStats{}, err
+	}
+	if err := s.StreamPullRequestStats(ctx, filter, func(stat domain.PRAssignmentStat) error {
+		stats.PerPR = append(stats.PerPR, stat)
+		return nil
+	}); err != nil {
+		return domain.AssignmentStats{}, err
+	}
+	if err := s.StreamSourceStats(ctx, filter, func(stat domain.SourceAssignmentStat) error {
+		stats.BySource = append(stats.BySource, stat)
+		return nil
+	}); err != nil {
+		return domain.AssignmentStats{}, err
+	}
+	return stats, nil
+}
+
+// StreamUserAssignmentStats передаёт в fn текущие назначения пользователей с учётом фильтра (раздел per_user),
+// не загружая выборку в память целиком. Условия на назначение и PR стоят в JOIN, чтобы пользователи
+// без назначений оставались в выборке.
+func (s *Storage) StreamUserAssignmentStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.UserAssignmentStat) error) error {
+	reviewersJoin := "pull_request_reviewers r ON r.reviewer_id=u.user_id"
+	var reviewersArgs []any
+	if filter.From != nil {
+		reviewersJoin += " AND r.assigned_at >= ?"
+		reviewersArgs = append(reviewersArgs, *filter.From)
+	}
+	if filter.To != nil {
+		reviewersJoin += " AND r.assigned_at < ?"
+		reviewersArgs = append(reviewersArgs, *filter.To)
+	}
+	prJoin := "pull_requests p ON p.pull_request_id=r.pull_request_id"
+	var prArgs []any
+	if filter.Status != "" {
+		prJoin += " AND p.status = ?"
+		prArgs = append(prArgs, string(filter.Status))
+	}
+	query := s.sb.
+		Select("u.user_id", "u.username", "u.team_name",
+			"COUNT(p.pull_request_id) AS assigned_total",
+			"COALESCE(SUM(CASE WHEN p.status='OPEN' THEN 1 ELSE 0 END), 0) AS active_pull_requests").
+		From("users u").
+		LeftJoin(reviewersJoin, reviewersArgs...).
+		LeftJoin(prJoin, prArgs...).
+		GroupBy("u.user_id", "u.username", "u.team_name").
+		OrderBy("assigned_total DESC")
+	if filter.TeamName != "" {
+		query = query.Where(squirrel.Eq{"u.team_name": filter.TeamName})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to query user stats", "error", err)
+		return fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var stat domain.UserAssignmentStat
+		if err := rows.Scan(&stat.UserID, &stat.Username, &stat.TeamName, &stat.Assigned, &stat.ActivePRs); err != nil {
+			return fmt.Errorf("%w: %v", ErrScanResult, err)
+		}
+		if err := fn(stat); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// StreamPullRequestStats передаёт в fn количество текущих ревьюверов PR, созданных в интервале фильтра
+// (раздел per_pull_request).
+func (s *Storage) StreamPullRequestStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.PRAssignmentStat) error) error {
+	query := filterPullRequests(s.sb.
+		Select("p.pull_request_id", "p.status", "COUNT(r.reviewer_id) AS reviewer_count").
+		From("pull_requests p").
+		Join("users a ON a.user_id=p.author_id").
+		LeftJoin("pull_request_reviewers r ON r.pull_request_id=p.pull_request_id").
+		GroupBy("p.pull_request_id", "p.status").
+		OrderBy("reviewer_count DESC"), filter, "p.created_at")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to query pull request stats", "error", err)
+		return fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var stat domain.PRAssignmentStat
+		if err := rows.Scan(&stat.PullRequestID, &stat.Status, &stat.ReviewerCount); err != nil {
+			return fmt.Errorf("%w: %v", ErrScanResult, err)
+		}
+		if err := fn(stat); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// StreamSourceStats передаёт в fn количество назначений, снятий и замен ревьюверов по источнику события
+// (раздел by_source).
+// Замена — снятие, для которого в той же транзакции (то же время события) с тем же источником
+// на PR назначен другой ревьювер.
+func (s *Storage) StreamSourceStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.SourceAssignmentStat) error) error {
+	query := filterPullRequests(s.sb.
+		Select("e.source",
+			"COALESCE(SUM(CASE WHEN e.event_type='ASSIGNED' THEN 1 ELSE 0 END), 0) AS assigned",
+			"COALESCE(SUM(CASE WHEN e.event_type='UNASSIGNED' THEN 1 ELSE 0 END), 0) AS unassigned",
+			`COALESCE(SUM(CASE WHEN e.event_type='UNASSIGNED' AND EXISTS (
+				SELECT 1 FROM review_assignment_events n
+				WHERE n.pull_request_id=e.pull_request_id AND n.event_type='ASSIGNED'
+				  AND n.source=e.source AND n.created_at=e.created_at
+			) THEN 1 ELSE 0 END), 0) AS reassigned`).
+		From("review_assignment_events e").
+		Join("pull_requests p ON p.pull_request_id=e.pull_request_id").
+		Join("users a ON a.user_id=p.author_id").
+		Where(squirrel.Eq{"e.event_type": []string{string(domain.EventAssigned), string(domain.EventUnassigned)}}).
+		GroupBy("e.source").
+		OrderBy("e.source"), filter, "e.created_at")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to query source stats", "error", err)
+		return fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var stat domain.SourceAssignmentStat
+		if err := rows.Scan(&stat.Source, &stat.Assigned, &stat.Unassigned, &stat.Reassigned); err != nil {
+			return fmt.Errorf("%w: %v", ErrScanResult, err)
+		}
+		if err := fn(stat); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// filterPullRequests добавляет к запросу по PR (p) с автором (a) условия фильтра;
+// интервал проверяется по колонке timeColumn.
+func filterPullRequests(query squirrel.SelectBuilder, filter domain.StatsFilter, timeColumn string) squirrel.SelectBuilder {
+	if filter.From != nil {
+		query = query.Where(squirrel.GtOrEq{timeColumn: *filter.From})
+	}
+	if filter.To != nil {
+		query = query.Where(squirrel.Lt{timeColumn: *filter.To})
+	}
+	if filter.TeamName != "" {
+		query = query.Where(squirrel.Eq{"a.team_name": filter.TeamName})
+	}
+	if filter.Status != "" {
+		query = query.Where(squirrel.Eq{"p.status": string(filter.Status)})
+	}
+	return query
+}
+
+// FetchLatencyStats считает перцентили задержек ревью по командам авторов PR и по ревьюверам.
+// Интервал фильтра относится ко времени merge для time_to_merge и ко времени решения
+// для assignment_to_decision.
+func (s *Storage) FetchLatencyStats(ctx context.Context, filter domain.LatencyFilter) (domain.LatencyStats, error) {
+	var stats domain.LatencyStats
+	var err error
+	if stats.TimeToMerge, err = s.fetchLatencyBreakdown(ctx, func(key string) squirrel.SelectBuilder {
+		return s.mergeSamples(filter, key)
+	}); err != nil {
+		return domain.LatencyStats{}, err
+	}
+	if stats.AssignmentToDecision, err = s.fetchLatencyBreakdown(ctx, func(key string) squirrel.SelectBuilder {
+		return s.decisionSamples(filter, key)
+	}); err != nil {
+		return domain.LatencyStats{}, err
+	}
+	return stats, nil
+}
+
+// fetchLatencyBreakdown агрегирует выборку задержек по команде автора (a.team_name) и по ревьюверу (r.reviewer_id).
+func (s *Storage) fetchLatencyBreakdown(ctx context.Context, samples func(key string) squirrel.SelectBuilder) (domain.LatencyBreakdown, error) {
+	perTeam, err := s.fetchLatencyPercentiles(ctx, samples("a.team_name"))
+	if err != nil {
+		return domain.LatencyBreakdown{}, err
+	}
+	perReviewer, err := s.fetchLatencyPercentiles(ctx, samples("r.reviewer_id"))
+	if err != nil {
+		return domain.LatencyBreakdown{}, err
+	}
+	breakdown := domain.LatencyBreakdown{
+		PerTeam:     make([]domain.TeamLatency, 0, len(perTeam)),
+		PerReviewer: make([]domain.ReviewerLatency, 0, len(perReviewer)),
+	}
+	for _, group := range perTeam {
+		breakdown.PerTeam = append(breakdown.PerTeam, domain.TeamLatency{TeamName: group.key, LatencyPercentiles: group.percentiles})
+	}
+	for _, group := range perReviewer {
+		breakdown.PerReviewer = append(breakdown.PerReviewer, domain.ReviewerLatency{ReviewerID: group.key, LatencyPercentiles: group.percentiles})
+	}
+	return breakdown, nil
+}
+
+// mergeSamples выбирает время от создания до merge смерженных PR с ключом группировки key.
+// Для группировки по ревьюверу каждый PR учитывается у всех его ревьюверов.
+func (s *Storage) mergeSamples(filter domain.LatencyFilter, key string) squirrel.SelectBuilder {
+	query := s.sb.
+		Select(key+" AS group_key", "EXTRACT(EPOCH FROM p.merged_at - p.created_at) AS seconds").
+		From("pull_requests p").
+		Join("users a ON a.user_id=p.author_id").
+		Where("p.merged_at IS NOT NULL")
+	if key == "r.reviewer_id" {
+		query = query.Join("pull_request_reviewers r ON r.pull_request_id=p.pull_request_id")
+	}
+	return filterLatency(query, filter, "p.merged_at")
+}
+
+// decisionSamples выбирает время от назначения ревьювера (r) до его первого решения с ключом группировки key.
+// Назначение, снятое раньше решения, не учитывается.
+func (s *Storage) decisionSamples(filter domain.LatencyFilter, key string) squirrel.SelectBuilder {
+	query := s.sb.
+		Select(key+" AS group_key", "EXTRACT(EPOCH FROM d.created_at - r.created_at) AS seconds").
+		From("review_assignment_events r").
+		Join("pull_requests p ON p.pull_request_id=r.pull_request_id").
+		Join("users a ON a.user_id=p.author_id").
+		Join(`LATERAL (
+			SELECT n.event_type, n.created_at FROM review_assignment_events n
+			WHERE n.pull_request_id=r.pull_request_id AND n.reviewer_id=r.reviewer_id AND n.id > r.id
+			  AND n.event_type IN ('UNASSIGNED', 'APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')
+			ORDER BY n.id LIMIT 1
+		) d ON d.event_type <> 'UNASSIGNED'`).
+		Where(squirrel.Eq{"r.event_type": string(domain.EventAssigned)})
+	if filter.To != nil {
+		// Назначение предшествует решению, поэтому более поздние назначения можно отбросить сразу
+		query = query.Where(squirrel.Lt{"r.created_at": *filter.To})
+	}
+	return filterLatency(query, filter, "d.created_at")
+}
+
+// filterLatency добавляет к выборке задержек интервал по колонке timeColumn и команду автора PR.
+func filterLatency(query squirrel.SelectBuilder, filter domain.LatencyFilter, timeColumn string) squirrel.SelectBuilder {
+	if filter.From != nil {
+		query = query.Where(squirrel.GtOrEq{timeColumn: *filter.From})
+	}
+	if filter.To != nil {
+		query = query.Where(squirrel.Lt{timeColumn: *filter.To})
+	}
+	if filter.TeamName != "" {
+		query = query.Where(squirrel.Eq{"a.team_name": filter.TeamName})
+	}
+	return query
+}
+
+type latencyGroup struct {
+	key         string
+	percentiles domain.LatencyPercentiles
+}
+
+// fetchLatencyPercentiles считает количество и перцентили выборки (group_key, seconds) по group_key.
+func (s *Storage) fetchLatencyPercentiles(ctx context.Context, samples squirrel.SelectBuilder) ([]latencyGroup, error) {
+	query := s.sb.
+		Select("s.group_key", "COUNT(*)",
+			"percentile_cont(0.5) WITHIN GROUP (ORDER BY s.seconds)",
+			"percentile_cont(0.9) WITHIN GROUP (ORDER BY s.seconds)",
+			"percentile_cont(0.99) WITHIN GROUP (ORDER BY s.seconds)").
+		FromSelect(samples, "s").
+		GroupBy("s.group_key").
+		OrderBy("s.group_key")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to query latency stats", "error", err)
+		return nil, fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+	}
+	defer rows.Close()
+	var groups []latencyGroup
+	for rows.Next() {
+		var group latencyGroup
+		p := &group.percentiles
+		if err := rows.Scan(&group.key, &p.Count, &p.P50, &p.P90, &p.P99); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrScanResult, err)
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+// FetchMemberAssignments возвращает количество назначений каждого активного участника за интервал фильтра
+// в порядке команды и идентификатора. Участники без назначений возвращаются с нулём.
+func (s *Storage) FetchMemberAssignments(ctx context.Context, filter domain.FairnessFilter) ([]domain.MemberAssignments, error) {
+	eventsJoin := "review_assignment_events e ON e.reviewer_id=u.user_id AND e.event_type=?"
+	eventsArgs := []any{string(domain.EventAssigned)}
+	if filter.From != nil {
+		eventsJoin += " AND e.created_at >= ?"
+		eventsArgs = append(eventsArgs, *filter.From)
+	}
+	if filter.To != nil {
+		eventsJoin += " AND e.created_at < ?"
+		eventsArgs = append(eventsArgs, *filter.To)
+	}
+	query := s.sb.
+		Select("u.team_name", "u.user_id", "u.username", "COUNT(e.id)").
+		From("users u").
+		LeftJoin(eventsJoin, eventsArgs...).
+		Where("u.is_active").
+		GroupBy("u.team_name", "u.user_id", "u.username").
+		OrderBy("u.team_name", "u.user_id")
+	if filter.TeamName != "" {
+		query = query.Where(squirrel.Eq{"u.team_name": filter.TeamName})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to query member assignments", "error", err)
+		return nil, fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+	}
+	defer rows.Close()
+	members := []domain.MemberAssignments{}
+	for rows.Next() {
+		var member domain.MemberAssignments
+		if err := rows.Scan(&member.TeamName, &member.UserID, &member.Username, &member.Assigned); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrScanResult, err)
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// FetchWorkload возвращает текущую нагрузку: открытые PR по командам авторов, включая команды без открытых PR,
+// и открытые ревью по активным пользователям и неактивным, за которыми ещё числятся открытые ревью.
+func (s *Storage) FetchWorkload(ctx context.Context) (domain.Workload, error) {
+	workload := domain.Workload{Teams: []domain.TeamWorkload{}, Reviewers: []domain.ReviewerWorkload{}}
+
+	teamsSQL, teamsArgs, err := s.sb.
+		Select("t.team_name", "COUNT(pr.pull_request_id)").
+		From("teams t").
+		LeftJoin("users a ON a.team_name=t.team_name").
+		LeftJoin("pull_requests pr ON pr.author_id=a.user_id AND pr.status=?", string(domain.PRStatusOpen)).
+		GroupBy("t.team_name").
+		OrderBy("t.team_name").
+		ToSql()
+	if err != nil {
+		return domain.Workload{}, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+	rows, err := s.conn(ctx).Query(ctx, teamsSQL, teamsArgs...)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to query open pull requests by team", "error", err)
+		return domain.Workload{}, fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+	}
+	for rows.Next() {
+		var team domain.TeamWorkload
+		if err := rows.Scan(&team.TeamName, &team.OpenPullRequests); err != nil {
+			rows.Close()
+			return domain.Workload{}, fmt.Errorf("%w: %v", ErrScanResult, err)
+		}
+		workload.Teams = append(workload.Teams, team)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return domain.Workload{}, fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+	}
+
+	reviewersSQL, reviewersArgs, err := s.sb.
+		Select("u.user_id", "u.team_name", "COUNT(pr.pull_request_id)").
+		From("users u").
+		LeftJoin("pull_request_reviewers r ON r.reviewer_id=u.user_id").
+		LeftJoin("pull_requests pr ON pr.pull_request_id=r.pull_request_id AND pr.status=?", string(domain.PRStatusOpen)).
+		GroupBy("u.user_id", "u.team_name", "u.is_active").
+		Having("u.is_active OR COUNT(pr.pull_request_id) > 0").
+		OrderBy("u.team_name", "u.user_id").
+		ToSql()
+	if err != nil {
+		return domain.Workload{}, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+	rows, err = s.conn(ctx).Query(ctx, reviewersSQL, reviewersArgs...)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to query open reviews by reviewer", "error", err)
+		return domain.Workload{}, fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var reviewer domain.ReviewerWorkload
+		if err := rows.Scan(&reviewer.ReviewerID, &reviewer.TeamName, &reviewer.OpenReviews); err != nil {
+			return domain.Workload{}, fmt.Errorf("%w: %v", ErrScanResult, err)
+		}
+		workload.Reviewers = append(workload.Reviewers, reviewer)
+	}
+	return workload, rows.Err()
+}
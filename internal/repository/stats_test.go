@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+var (
+	userStatColumns   = []string{"user_id", "username", "team_name", "assigned_total", "active_pull_requests"}
+	prStatColumns     = []string{"pull_request_id", "status", "reviewer_count"}
+	sourceStatColumns = []string{"source", "assigned", "unassigned", "reassigned"}
+)
+
+func TestStorageFetchAssignmentStats(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT u\.user_id, .* FROM users u LEFT JOIN pull_request_reviewers r ON r.reviewer_id=u.user_id ` +
+		`LEFT JOIN pull_requests p ON p.pull_request_id=r.pull_request_id GROUP BY`).
+		WillReturnRows(pgxmock.NewRows(userStatColumns).AddRow("u1", "Alice", "backend", int64(3), int64(1)))
+	mock.ExpectQuery(`SELECT p\.pull_request_id, .* FROM pull_requests p .* GROUP BY`).
+		WillReturnRows(pgxmock.NewRows(prStatColumns).AddRow("pr-1", domain.PRStatusOpen, int64(2)))
+	mock.ExpectQuery(`SELECT e\.source, .* FROM review_assignment_events e .* WHERE e.event_type IN \(\$1,\$2\) GROUP BY e.source`).
+		WithArgs("ASSIGNED", "UNASSIGNED").
+		WillReturnRows(pgxmock.NewRows(sourceStatColumns).AddRow("AUTO", int64(3), int64(0), int64(0)).
+			AddRow("REASSIGN", int64(1), int64(1), int64(1)))
+
+	stats, err := storage.FetchAssignmentStats(ctx, domain.StatsFilter{})
+	require.NoError(t, err)
+	require.Len(t, stats.PerUser, 1)
+	require.Len(t, stats.PerPR, 1)
+	require.Equal(t, "u1", stats.PerUser[0].UserID)
+	require.Equal(t, int64(2), stats.PerPR[0].ReviewerCount)
+	require.Equal(t, domain.PRStatusOpen, stats.PerPR[0].Status)
+	require.Equal(t, []domain.SourceAssignmentStat{
+		{Source: "AUTO", Assigned: 3},
+		{Source: "REASSIGN", Assigned: 1, Unassigned: 1, Reassigned: 1},
+	}, stats.BySource)
+}
+
+func TestStorageFetchAssignmentStatsAppliesFilter(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	filter := domain.StatsFilter{From: &from, To: &to, TeamName: "backend", Status: domain.PRStatusMerged}
+
+	mock.ExpectQuery(`LEFT JOIN pull_request_reviewers r ON r.reviewer_id=u.user_id AND r.assigned_at >= \$1 AND r.assigned_at < \$2 `+
+		`LEFT JOIN pull_requests p ON p.pull_request_id=r.pull_request_id AND p.status = \$3 WHERE u.team_name = \$4`).
+		WithArgs(from, to, "MERGED", "backend").
+		WillReturnRows(pgxmock.NewRows(userStatColumns))
+	mock.ExpectQuery(`FROM pull_requests p .* WHERE p.created_at >= \$1 AND p.created_at < \$2 AND a.team_name = \$3 AND p.status = \$4`).
+		WithArgs(from, to, "backend", "MERGED").
+		WillReturnRows(pgxmock.NewRows(prStatColumns))
+	mock.ExpectQuery(`FROM review_assignment_events e .* WHERE e.event_type IN \(\$1,\$2\) AND e.created_at >= \$3 `+
+		`AND e.created_at < \$4 AND a.team_name = \$5 AND p.status = \$6`).
+		WithArgs("ASSIGNED", "UNASSIGNED", from, to, "backend", "MERGED").
+		WillReturnRows(pgxmock.NewRows(sourceStatColumns))
+
+	stats, err := storage.FetchAssignmentStats(ctx, filter)
+	require.NoError(t, err)
+	require.Empty(t, stats.PerUser)
+	require.Empty(t, stats.PerPR)
+	require.Empty(t, stats.BySource)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+var latencyColumns = []string{"group_key", "count", "p50", "p90", "p99"}
+
+func TestStorageFetchLatencyStats(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	filter := domain.LatencyFilter{From: &from, To: &to, TeamName: "backend"}
+
+	mock.ExpectQuery(`SELECT s.group_key, COUNT\(\*\), percentile_cont\(0.5\) .* FROM \(SELECT a.team_name AS group_key, `+
+		`EXTRACT\(EPOCH FROM p.merged_at - p.created_at\) AS seconds FROM pull_requests p JOIN users a ON a.user_id=p.author_id `+
+		`WHERE p.merged_at IS NOT NULL AND p.merged_at >= \$1 AND p.merged_at < \$2 AND a.team_name = \$3\) AS s GROUP BY s.group_key`).
+		WithArgs(from, to, "backend").
+		WillReturnRows(pgxmock.NewRows(latencyColumns).AddRow("backend", int64(4), 3600.0, 7200.0, 9000.0))
+	mock.ExpectQuery(`FROM \(SELECT r.reviewer_id AS group_key, .* JOIN pull_request_reviewers r .* AS s GROUP BY s.group_key`).
+		WithArgs(from, to, "backend").
+		WillReturnRows(pgxmock.NewRows(latencyColumns).AddRow("u2", int64(2), 1800.0, 3000.0, 3500.0))
+	mock.ExpectQuery(`FROM \(SELECT a.team_name AS group_key, EXTRACT\(EPOCH FROM d.created_at - r.created_at\) AS seconds `+
+		`FROM review_assignment_events r .* JOIN LATERAL .* WHERE r.event_type = \$1 AND r.created_at < \$2 `+
+		`AND d.created_at >= \$3 AND d.created_at < \$4 AND a.team_name = \$5\) AS s`).
+		WithArgs("ASSIGNED", to, from, to, "backend").
+		WillReturnRows(pgxmock.NewRows(latencyColumns))
+	mock.ExpectQuery(`FROM \(SELECT r.reviewer_id AS group_key, EXTRACT\(EPOCH FROM d.created_at - r.created_at\)`).
+		WithArgs("ASSIGNED", to, from, to, "backend").
+		WillReturnRows(pgxmock.NewRows(latencyColumns))
+
+	stats, err := storage.FetchLatencyStats(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, []domain.TeamLatency{{TeamName: "backend",
+		LatencyPercentiles: domain.LatencyPercentiles{Count: 4, P50: 3600, P90: 7200, P99: 9000}}}, stats.TimeToMerge.PerTeam)
+	require.Equal(t, []domain.ReviewerLatency{{ReviewerID: "u2",
+		LatencyPercentiles: domain.LatencyPercentiles{Count: 2, P50: 1800, P90: 3000, P99: 3500}}}, stats.TimeToMerge.PerReviewer)
+	require.Empty(t, stats.AssignmentToDecision.PerTeam)
+	require.NotNil(t, stats.AssignmentToDecision.PerReviewer)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStorageFetchMemberAssignments(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT u.team_name, u.user_id, u.username, COUNT\(e.id\) FROM users u `+
+		`LEFT JOIN review_assignment_events e ON e.reviewer_id=u.user_id AND e.event_type=\$1 AND e.created_at >= \$2 `+
+		`WHERE u.is_active AND u.team_name = \$3 GROUP BY u.team_name, u.user_id, u.username ORDER BY u.team_name, u.user_id`).
+		WithArgs("ASSIGNED", from, "backend").
+		WillReturnRows(pgxmock.NewRows([]string{"team_name", "user_id", "username", "count"}).
+			AddRow("backend", "u1", "Alice", int64(5)).
+			AddRow("backend", "u2", "Bob", int64(0)))
+
+	members, err := storage.FetchMemberAssignments(ctx, domain.FairnessFilter{From: &from, TeamName: "backend"})
+	require.NoError(t, err)
+	require.Equal(t, []domain.MemberAssignments{
+		{TeamName: "backend", UserID: "u1", Username: "Alice", Assigned: 5},
+		{TeamName: "backend", UserID: "u2", Username: "Bob"},
+	}, members)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStorageFetchWorkload(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT t.team_name, COUNT\(pr.pull_request_id\) FROM teams t ` +
+		`LEFT JOIN users a ON a.team_name=t.team_name ` +
+		`LEFT JOIN pull_requests pr ON pr.author_id=a.user_id AND pr.status=\$1 ` +
+		`GROUP BY t.team_name ORDER BY t.team_name`).
+		WithArgs("OPEN").
+		WillReturnRows(pgxmock.NewRows([]string{"team_name", "count"}).
+			AddRow("backend", int64(2)).
+			AddRow("frontend", int64(0)))
+	mock.ExpectQuery(`SELECT u.user_id, u.team_name, COUNT\(pr.pull_request_id\) FROM users u ` +
+		`LEFT JOIN pull_request_reviewers r ON r.reviewer_id=u.user_id ` +
+		`LEFT JOIN pull_requests pr ON pr.pull_request_id=r.pull_request_id AND pr.status=\$1 ` +
+		`GROUP BY u.user_id, u.team_name, u.is_active HAVING u.is_active OR COUNT\(pr.pull_request_id\) > 0 ` +
+		`ORDER BY u.team_name, u.user_id`).
+		WithArgs("OPEN").
+		WillReturnRows(pgxmock.NewRows([]string{"user_id", "team_name", "count"}).
+			AddRow("u1", "backend", int64(2)))
+
+	workload, err := storage.FetchWorkload(ctx)
+	require.NoError(t, err)
+	require.Equal(t, domain.Workload{
+		Teams:     []domain.TeamWorkload{{TeamName: "backend", OpenPullRequests: 2}, {TeamName: "frontend"}},
+		Reviewers: []domain.ReviewerWorkload{{ReviewerID: "u1", TeamName: "backend", OpenReviews: 2}},
+	}, workload)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
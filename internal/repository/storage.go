@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 
@@ -17,27 +18,27 @@ import (
 )
 
 type pgxPool interface {
+	trmpgx.Tr
 	Close()
 	Ping(ctx context.Context) error
-	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
-	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
-	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
-	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 }
 
 // Storage инкапсулирует работу с PostgreSQL.
+// Если в контексте есть транзакция transaction manager'а, все запросы выполняются в ней.
 type Storage struct {
-	pool  pgxPool
-	nower nower.Nower
-	sb    squirrel.StatementBuilderType
+	pool   pgxPool
+	getter *trmpgx.CtxGetter
+	nower  nower.Nower
+	sb     squirrel.StatementBuilderType
 }
 
 // New создаёт новый слой хранения.
 func New(pool pgxPool, nower nower.Nower) *Storage {
 	return &Storage{
-		pool:  pool,
-		nower: nower,
-		sb:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+		pool:   pool,
+		getter: trmpgx.DefaultCtxGetter,
+		nower:  nower,
+		sb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
 	}
 }
 
@@ -51,9 +52,16 @@ func (s *Storage) Ping(ctx context.Context) error {
 	return s.pool.Ping(ctx)
 }
 
+// conn возвращает транзакцию transaction manager'а из контекста, а при её отсутствии — пул.
+func (s *Storage) conn(ctx context.Context) trmpgx.Tr {
+	return s.getter.DefaultTrOrDB(ctx, s.pool)
+}
+
 // WithTx оборачивает выполнение в транзакцию (низкоуровневый метод).
+// Внутри транзакции transaction manager'а открывается SAVEPOINT: ошибка fn откатывает только
+// её изменения, а фиксация происходит вместе с внешней транзакцией.
 func (s *Storage) WithTx(ctx context.Context, fn func(pgx.Tx) error) error {
-	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	tx, err := s.conn(ctx).Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -70,14 +78,6 @@ func (s *Storage) WithTx(ctx context.Context, fn func(pgx.Tx) error) error {
 	return tx.Commit(ctx)
 }
 
-// WithTransaction выполняет функцию fn в рамках транзакции на уровне репозитория.
-func (s *Storage) WithTransaction(ctx context.Context, fn func(Repository) error) error {
-	return s.WithTx(ctx, func(tx pgx.Tx) error {
-		txRepo := newTxStorage(tx)
-		return fn(txRepo)
-	})
-}
-
 // CreateTeam создаёт новую команду и обновляет/создаёт пользователей в одной транзакции.
 // Если команда уже существует, возвращает ошибку. Пользователи обновляются через UPSERT.
 func (s *Storage) CreateTeam(ctx context.Context, team domain.Team) (domain.Team, error) {
@@ -104,8 +104,8 @@ func (s *Storage) CreateTeam(ctx context.Context, team domain.Team) (domain.Team
 		// Вставка команды
 		insertTeamSQL, insertTeamArgs, err := s.sb.
 			Insert("teams").
-			Columns("team_name").
-			Values(team.Name).
+			Columns("team_name", "required_reviewers").
+			Values(team.Name, squirrel.Expr("NULLIF(?, 0)", team.RequiredReviewers)).
 			ToSql()
 		if err != nil {
 			slog.ErrorContext(ctx, "failed to build insert team query", "error", err)
@@ -122,9 +122,9 @@ func (s *Storage) CreateTeam(ctx context.Context, team domain.Team) (domain.Team
 			member.TeamName = team.Name
 			insertUserSQL, insertUserArgs, err := s.sb.
 				Insert("users").
-				Columns("user_id", "username", "team_name", "is_active", "created_at", "updated_at").
-				Values(member.ID, member.Username, member.TeamName, member.IsActive, now, now).
-				Suffix("ON CONFLICT (user_id) DO UPDATE SET username=EXCLUDED.username, team_name=EXCLUDED.team_name, is_active=EXCLUDED.is_active, updated_at=EXCLUDED.updated_at").
+				Columns("user_id", "username", "team_name", "is_active", "role", "created_at", "updated_at").
+				Values(member.ID, member.Username, member.TeamName, member.IsActive, squirrel.Expr("NULLIF(?, '')", member.Role), now, now).
+				Suffix("ON CONFLICT (user_id) DO UPDATE SET username=EXCLUDED.username, team_name=EXCLUDED.team_name, is_active=EXCLUDED.is_active, role=EXCLUDED.role, updated_at=EXCLUDED.updated_at").
 				ToSql()
 			if err != nil {
 				slog.ErrorContext(ctx, "failed to build insert user query", "error", err)
@@ -135,7 +135,7 @@ func (s *Storage) CreateTeam(ctx context.Context, team domain.Team) (domain.Team
 				return fmt.Errorf("%w: %v", ErrExecuteQuery, err)
 			}
 		}
-		return nil
+		return enqueueOutbox(ctx, tx, domain.OutboxAggregateTeam, team.Name, domain.OutboxTeamCreated, team)
 	})
 	if err != nil {
 		return domain.Team{}, err
@@ -147,7 +147,7 @@ func (s *Storage) CreateTeam(ctx context.Context, team domain.Team) (domain.Team
 func (s *Storage) GetTeam(ctx context.Context, teamName string) (domain.Team, error) {
 	// Получение участников через Squirrel
 	selectSQL, selectArgs, err := s.sb.
-		Select("u.user_id", "u.username", "u.team_name", "u.is_active").
+		Select("u.user_id", "u.username", "u.team_name", "u.is_active", "COALESCE(u.role, '')").
 		From("users u").
 		Where(squirrel.Eq{"u.team_name": teamName}).
 		OrderBy("u.username ASC").
@@ -157,7 +157,7 @@ func (s *Storage) GetTeam(ctx context.Context, teamName string) (domain.Team, er
 		return domain.Team{}, fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
 
-	rows, err := s.pool.Query(ctx, selectSQL, selectArgs...)
+	rows, err := s.conn(ctx).Query(ctx, selectSQL, selectArgs...)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to query users", "error", err)
 		return domain.Team{}, fmt.Errorf("%w: %v", ErrExecuteQuery, err)
@@ -167,7 +167,7 @@ func (s *Storage) GetTeam(ctx context.Context, teamName string) (domain.Team, er
 	var members []domain.User
 	for rows.Next() {
 		var u domain.User
-		if err := rows.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+		if err := rows.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive, &u.Role); err != nil {
 			slog.ErrorContext(ctx, "failed to scan user", "error", err)
 			return domain.Team{}, fmt.Errorf("%w: %v", ErrScanResult, err)
 		}
@@ -187,7 +187,7 @@ func (s *Storage) GetTeam(ctx context.Context, teamName string) (domain.Team, er
 			return domain.Team{}, fmt.Errorf("%w: %v", ErrBuildQuery, err)
 		}
 		var exists bool
-		if err := s.pool.QueryRow(ctx, "SELECT EXISTS("+existsSQL+")", existsArgs...).Scan(&exists); err != nil {
+		if err := s.conn(ctx).QueryRow(ctx, "SELECT EXISTS("+existsSQL+")", existsArgs...).Scan(&exists); err != nil {
 			return domain.Team{}, fmt.Errorf("%w: %v", ErrExecuteQuery, err)
 		}
 		if !exists {
@@ -200,6 +200,72 @@ func (s *Storage) GetTeam(ctx context.Context, teamName string) (domain.Team, er
 	return domain.Team{Name: teamName, Members: members}, nil
 }
 
+// GetTeamSettings возвращает настройки команды.
+// Пустые значения означают, что для команды используются значения из конфигурации.
+func (s *Storage) GetTeamSettings(ctx context.Context, teamName string) (domain.TeamSettings, error) {
+	selectSQL, selectArgs, err := s.sb.
+		Select("team_name", "COALESCE(reviewer_strategy, '')", "COALESCE(required_reviewers, 0)", "COALESCE(fallback_teams, '{}')",
+			"COALESCE(max_open_reviews, 0)", "COALESCE(merge_required_approvals, 0)", "merge_block_on_changes_requested",
+			"COALESCE(merge_required_role, '')", "COALESCE(review_sla_hours, 0)", "COALESCE(review_sla_escalation, '')").
+		From("teams").
+		Where(squirrel.Eq{"team_name": teamName}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to build select team settings query", "error", err)
+		return domain.TeamSettings{}, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var settings domain.TeamSettings
+	err = s.conn(ctx).QueryRow(ctx, selectSQL, selectArgs...).Scan(&settings.TeamName, &settings.ReviewerStrategy, &settings.RequiredReviewers, &settings.FallbackTeams, &settings.MaxOpenReviews,
+		&settings.MergePolicy.RequiredApprovals, &settings.MergePolicy.BlockOnChangesRequested, &settings.MergePolicy.RequiredRole,
+		&settings.ReviewSLA.Hours, &settings.ReviewSLA.Escalation)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.TeamSettings{}, domain.ErrTeamNotFound
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to scan team settings", "error", err)
+		return domain.TeamSettings{}, fmt.Errorf("%w: %v", ErrScanResult, err)
+	}
+	return settings, nil
+}
+
+// UpdateTeamSettings сохраняет настройки команды.
+func (s *Storage) UpdateTeamSettings(ctx context.Context, settings domain.TeamSettings) (domain.TeamSettings, error) {
+	updateSQL, updateArgs, err := s.sb.
+		Update("teams").
+		Set("reviewer_strategy", squirrel.Expr("NULLIF(?, '')", string(settings.ReviewerStrategy))).
+		Set("required_reviewers", squirrel.Expr("NULLIF(?, 0)", settings.RequiredReviewers)).
+		Set("fallback_teams", squirrel.Expr("NULLIF(?::text[], '{}')", settings.FallbackTeams)).
+		Set("max_open_reviews", squirrel.Expr("NULLIF(?, 0)", settings.MaxOpenReviews)).
+		Set("merge_required_approvals", squirrel.Expr("NULLIF(?, 0)", settings.MergePolicy.RequiredApprovals)).
+		Set("merge_block_on_changes_requested", settings.MergePolicy.BlockOnChangesRequested).
+		Set("merge_required_role", squirrel.Expr("NULLIF(?, '')", settings.MergePolicy.RequiredRole)).
+		Set("review_sla_hours", squirrel.Expr("NULLIF(?, 0)", settings.ReviewSLA.Hours)).
+		Set("review_sla_escalation", squirrel.Expr("NULLIF(?, '')", string(settings.ReviewSLA.Escalation))).
+		Where(squirrel.Eq{"team_name": settings.TeamName}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to build update team settings query", "error", err)
+		return domain.TeamSettings{}, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	err = s.WithTx(ctx, func(tx pgx.Tx) error {
+		cmd, err := tx.Exec(ctx, updateSQL, updateArgs...)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to update team settings", "error", err)
+			return fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+		}
+		if cmd.RowsAffected() == 0 {
+			return domain.ErrTeamNotFound
+		}
+		return enqueueOutbox(ctx, tx, domain.OutboxAggregateTeam, settings.TeamName, domain.OutboxTeamSettingsUpdated, settings)
+	})
+	if err != nil {
+		return domain.TeamSettings{}, err
+	}
+	return s.GetTeamSettings(ctx, settings.TeamName)
+}
+
 // SetUserActivity обновляет флаг активности пользователя.
 func (s *Storage) SetUserActivity(ctx context.Context, userID string, active bool) (domain.User, error) {
 	now := s.nower.Now()
@@ -214,21 +280,69 @@ func (s *Storage) SetUserActivity(ctx context.Context, userID string, active boo
 		return domain.User{}, fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
 
-	cmd, err := s.pool.Exec(ctx, updateSQL, updateArgs...)
+	if err := s.updateUser(ctx, userID, updateSQL, updateArgs, domain.OutboxUserActivityChanged, map[string]any{"user_id": userID, "is_active": active}); err != nil {
+		return domain.User{}, err
+	}
+	return s.GetUserByID(ctx, userID)
+}
+
+// SetUserMaxOpenReviews задаёт персональный лимит открытых ревью; 0 сбрасывает его к значению команды.
+func (s *Storage) SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error) {
+	updateSQL, updateArgs, err := s.sb.
+		Update("users").
+		Set("max_open_reviews", squirrel.Expr("NULLIF(?, 0)", maxOpenReviews)).
+		Set("updated_at", s.nower.Now()).
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to update user", "error", err)
-		return domain.User{}, fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+		slog.ErrorContext(ctx, "failed to build update user limit query", "error", err)
+		return domain.User{}, fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
-	if cmd.RowsAffected() == 0 {
-		return domain.User{}, domain.ErrUserNotFound
+
+	if err := s.updateUser(ctx, userID, updateSQL, updateArgs, domain.OutboxUserMaxOpenReviewsChanged, map[string]any{"user_id": userID, "max_open_reviews": maxOpenReviews}); err != nil {
+		return domain.User{}, err
+	}
+	return s.GetUserByID(ctx, userID)
+}
+
+// SetUserRole задаёт роль пользователя; пустая строка снимает роль.
+func (s *Storage) SetUserRole(ctx context.Context, userID, role string) (domain.User, error) {
+	updateSQL, updateArgs, err := s.sb.
+		Update("users").
+		Set("role", squirrel.Expr("NULLIF(?, '')", role)).
+		Set("updated_at", s.nower.Now()).
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to build update user role query", "error", err)
+		return domain.User{}, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if err := s.updateUser(ctx, userID, updateSQL, updateArgs, domain.OutboxUserRoleChanged, map[string]any{"user_id": userID, "role": role}); err != nil {
+		return domain.User{}, err
 	}
 	return s.GetUserByID(ctx, userID)
 }
 
+// updateUser выполняет обновление пользователя и записывает событие outbox в одной транзакции.
+func (s *Storage) updateUser(ctx context.Context, userID, updateSQL string, updateArgs []any, eventType string, payload any) error {
+	return s.WithTx(ctx, func(tx pgx.Tx) error {
+		cmd, err := tx.Exec(ctx, updateSQL, updateArgs...)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to update user", "error", err, "event_type", eventType)
+			return fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+		}
+		if cmd.RowsAffected() == 0 {
+			return domain.ErrUserNotFound
+		}
+		return enqueueOutbox(ctx, tx, domain.OutboxAggregateUser, userID, eventType, payload)
+	})
+}
+
 // GetUserByID возвращает пользователя.
 func (s *Storage) GetUserByID(ctx context.Context, userID string) (domain.User, error) {
 	selectSQL, selectArgs, err := s.sb.
-		Select("user_id", "username", "team_name", "is_active").
+		Select("user_id", "username", "team_name", "is_active", "COALESCE(max_open_reviews, 0)", "COALESCE(role, '')").
 		From("users").
 		Where(squirrel.Eq{"user_id": userID}).
 		ToSql()
@@ -238,7 +352,7 @@ func (s *Storage) GetUserByID(ctx context.Context, userID string) (domain.User,
 	}
 
 	var u domain.User
-	err = s.pool.QueryRow(ctx, selectSQL, selectArgs...).Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive)
+	err = s.conn(ctx).QueryRow(ctx, selectSQL, selectArgs...).Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive, &u.MaxOpenReviews, &u.Role)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return domain.User{}, domain.ErrUserNotFound
 	}
@@ -265,6 +379,12 @@ func (s *Storage) CreatePullRequest(ctx context.Context, pr domain.PullRequest,
 		`, pr.ID, pr.Name, pr.AuthorID, string(pr.Status)); err != nil {
 			return err
 		}
+		// Создание записывается в outbox отдельно от назначений: у черновика и PR без кандидатов их нет
+		if err := enqueueOutbox(ctx, tx, domain.OutboxAggregatePullRequest, pr.ID, domain.OutboxPullRequestCreated, map[string]any{
+			"pull_request_id": pr.ID, "pull_request_name": pr.Name, "author_id": pr.AuthorID, "status": pr.Status,
+		}); err != nil {
+			return err
+		}
 		if len(reviewers) > 0 {
 			if err := insertReviewers(ctx, tx, pr.ID, reviewers, "AUTO_ASSIGN"); err != nil {
 				return err
@@ -279,39 +399,177 @@ func (s *Storage) CreatePullRequest(ctx context.Context, pr domain.PullRequest,
 }
 
 // insertReviewers добавляет ревьюверов к PR и создаёт события назначения в одном батче.
+// Ревьювер не из команды автора помечается как назначенный из резервной команды.
 func insertReviewers(ctx context.Context, tx pgx.Tx, prID string, reviewers []string, source string) error {
 	batch := &pgx.Batch{}
 	for _, reviewer := range reviewers {
-		// Добавляем связь PR-ревьювер
-		batch.Queue(`
-			INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, assigned_at)
-			VALUES ($1,$2,NOW())
-		`, prID, reviewer)
-		// Создаём событие назначения для аудита
-		batch.Queue(`
-			INSERT INTO review_assignment_events (pull_request_id, reviewer_id, event_type, source)
-			VALUES ($1,$2,'ASSIGNED',$3)
-		`, prID, reviewer, source)
+		queueAssignment(batch, prID, reviewer, source, "")
 	}
 	return tx.SendBatch(ctx, batch).Close()
 }
 
+// queueAssignment добавляет в batch назначение ревьювера и его событие. replaces — снятый ревьювер,
+// которого заменяет назначение, или пустая строка.
+func queueAssignment(batch *pgx.Batch, prID, reviewer, source, replaces string) {
+	// Добавляем связь PR-ревьювер
+	batch.Queue(`
+		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, assigned_at, from_fallback)
+		VALUES ($1,$2,NOW(),COALESCE((
+			SELECT u.team_name <> a.team_name
+			FROM users u, pull_requests p
+			JOIN users a ON a.user_id=p.author_id
+			WHERE u.user_id=$2 AND p.pull_request_id=$1
+		), FALSE))
+	`, prID, reviewer)
+	// Создаём событие назначения для аудита и outbox
+	insertEvent := `
+		INSERT INTO review_assignment_events (pull_request_id, reviewer_id, event_type, source)
+		VALUES ($1,$2,'ASSIGNED',$3)`
+	if replaces == "" {
+		batch.Queue(withOutbox(insertEvent), prID, reviewer, source)
+		return
+	}
+	batch.Queue(withReplacementOutbox(insertEvent), prID, reviewer, source, replaces)
+}
+
 // removeReviewer удаляет ревьювера из PR и создаёт событие снятия назначения.
 func removeReviewer(ctx context.Context, tx pgx.Tx, prID, reviewerID, source string) error {
 	batch := &pgx.Batch{}
 	batch.Queue(`DELETE FROM pull_request_reviewers WHERE pull_request_id=$1 AND reviewer_id=$2`, prID, reviewerID)
-	batch.Queue(`
+	batch.Queue(withOutbox(`
 		INSERT INTO review_assignment_events (pull_request_id, reviewer_id, event_type, source)
-		VALUES ($1,$2,'UNASSIGNED',$3)
-	`, prID, reviewerID, source)
+		VALUES ($1,$2,'UNASSIGNED',$3)`), prID, reviewerID, source)
 	return tx.SendBatch(ctx, batch).Close()
 }
 
+// requireOpenPR проверяет, что PR существует и открыт: у смерженного, закрытого PR и черновика
+// нельзя менять состав ревьюверов и выносить решения.
+func requireOpenPR(ctx context.Context, q querier, prID string) error {
+	status, err := pullRequestStatus(ctx, q, prID)
+	if err != nil {
+		return err
+	}
+	return domain.RequireOpenStatus(status)
+}
+
+// requireUnassigned проверяет, что никто из reviewers ещё не назначен на PR. Вызывается после requireOpenPR,
+// под блокировкой строки PR, поэтому параллельное назначение того же ревьювера не приводит к нарушению
+// первичного ключа pull_request_reviewers.
+func requireUnassigned(ctx context.Context, q querier, prID string, reviewers []string) error {
+	var assigned bool
+	if err := q.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM pull_request_reviewers WHERE pull_request_id=$1 AND reviewer_id = ANY($2)
+		)`, prID, reviewers).Scan(&assigned); err != nil {
+		return err
+	}
+	if assigned {
+		return domain.ErrReviewerNotEligible
+	}
+	return nil
+}
+
+// pullRequestStatus возвращает текущий статус PR и блокирует его строку до конца транзакции,
+// чтобы изменения ревьюверов и решений не пересекались с merge, проверяющим по ним политику.
+func pullRequestStatus(ctx context.Context, q querier, prID string) (domain.PRStatus, error) {
+	var status domain.PRStatus
+	if err := q.QueryRow(ctx, `SELECT status FROM pull_requests WHERE pull_request_id=$1 FOR UPDATE`, prID).Scan(&status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", domain.ErrPRNotFound
+		}
+		return "", err
+	}
+	return status, nil
+}
+
+// recordStatusEvent создаёт событие смены статуса PR. Такие события не относятся к конкретному ревьюверу.
+func recordStatusEvent(ctx context.Context, q querier, prID, eventType string) error {
+	_, err := q.Exec(ctx, withOutbox(`
+		INSERT INTO review_assignment_events (pull_request_id, reviewer_id, event_type, source)
+		VALUES ($1,NULL,$2,'STATUS_CHANGE')`), prID, eventType)
+	return err
+}
+
+// openPullRequest переводит PR из статуса from в OPEN и назначает ревьюверов.
+// Уже открытый PR остаётся без изменений; из остальных статусов переход запрещён.
+func openPullRequest(ctx context.Context, tx pgx.Tx, prID string, from domain.PRStatus, eventType string, reviewers []string) error {
+	status, err := pullRequestStatus(ctx, tx, prID)
+	if err != nil {
+		return err
+	}
+	if status == domain.PRStatusOpen {
+		return nil
+	}
+	if status != from {
+		return domain.RequireOpenStatus(status)
+	}
+	if _, err := tx.Exec(ctx, `UPDATE pull_requests SET status=$2 WHERE pull_request_id=$1`, prID, string(domain.PRStatusOpen)); err != nil {
+		return err
+	}
+	if err := recordStatusEvent(ctx, tx, prID, eventType); err != nil {
+		return err
+	}
+	if len(reviewers) == 0 {
+		return nil
+	}
+	return insertReviewers(ctx, tx, prID, reviewers, "AUTO_ASSIGN")
+}
+
+// closePullRequest закрывает PR без merge и снимает всех назначенных ревьюверов.
+// Уже закрытый PR остаётся без изменений; смерженный PR закрыть нельзя.
+func closePullRequest(ctx context.Context, tx pgx.Tx, prID string) error {
+	status, err := pullRequestStatus(ctx, tx, prID)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case domain.PRStatusClosed:
+		return nil
+	case domain.PRStatusMerged:
+		return domain.ErrPRMerged
+	}
+	rows, err := tx.Query(ctx, `SELECT reviewer_id FROM pull_request_reviewers WHERE pull_request_id=$1`, prID)
+	if err != nil {
+		return err
+	}
+	reviewers, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+	for _, reviewer := range reviewers {
+		if err := removeReviewer(ctx, tx, prID, reviewer, "PR_CLOSED"); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(ctx, `UPDATE pull_requests SET status=$2 WHERE pull_request_id=$1`, prID, string(domain.PRStatusClosed)); err != nil {
+		return err
+	}
+	return recordStatusEvent(ctx, tx, prID, "CLOSED")
+}
+
+// recordReview сохраняет решение назначенного ревьювера и создаёт событие с этим решением.
+func recordReview(ctx context.Context, tx pgx.Tx, prID, reviewerID string, decision domain.ReviewDecision) error {
+	tag, err := tx.Exec(ctx, `
+		UPDATE pull_request_reviewers SET decision=$3, decided_at=NOW()
+		WHERE pull_request_id=$1 AND reviewer_id=$2
+	`, prID, reviewerID, string(decision))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrReviewerAbsent
+	}
+	_, err = tx.Exec(ctx, withOutbox(`
+		INSERT INTO review_assignment_events (pull_request_id, reviewer_id, event_type, source)
+		VALUES ($1,$2,$3,'REVIEW')`), prID, reviewerID, string(decision))
+	return err
+}
+
 // GetPullRequest возвращает полный PR.
 func (s *Storage) GetPullRequest(ctx context.Context, prID string) (domain.PullRequest, error) {
 	var pr domain.PullRequest
 	var mergedAt *time.Time
-	err := s.pool.QueryRow(ctx, `
+	err := s.conn(ctx).QueryRow(ctx, `
 		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
 		FROM pull_requests WHERE pull_request_id=$1
 	`, prID).Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt)
@@ -322,26 +580,54 @@ func (s *Storage) GetPullRequest(ctx context.Context, prID string) (domain.PullR
 		return domain.PullRequest{}, err
 	}
 	pr.MergedAt = mergedAt
-	rows, err := s.pool.Query(ctx, `
-		SELECT reviewer_id FROM pull_request_reviewers
+	if err := loadReviewers(ctx, s.conn(ctx), &pr); err != nil {
+		return domain.PullRequest{}, err
+	}
+	return pr, nil
+}
+
+// LockPullRequest блокирует строку PR до конца текущей транзакции. Возвращает ErrPRNotFound, если PR нет.
+func (s *Storage) LockPullRequest(ctx context.Context, prID string) error {
+	_, err := pullRequestStatus(ctx, s.conn(ctx), prID)
+	return err
+}
+
+// loadReviewers заполняет назначенных ревьюверов PR, в том числе назначенных из резервных команд,
+// и их последние решения.
+func loadReviewers(ctx context.Context, q querier, pr *domain.PullRequest) error {
+	rows, err := q.Query(ctx, `
+		SELECT reviewer_id, from_fallback, decision, decided_at FROM pull_request_reviewers
 		WHERE pull_request_id=$1
 		ORDER BY reviewer_id ASC
-	`, prID)
+	`, pr.ID)
 	if err != nil {
-		return domain.PullRequest{}, err
+		return err
 	}
 	defer rows.Close()
+	pr.AssignedReviewers = []string{}
+	pr.FallbackReviewers = nil
+	pr.Decisions = nil
 	for rows.Next() {
 		var id string
-		if err := rows.Scan(&id); err != nil {
-			return domain.PullRequest{}, err
+		var fallback bool
+		var decision *string
+		var decidedAt *time.Time
+		if err := rows.Scan(&id, &fallback, &decision, &decidedAt); err != nil {
+			return err
 		}
 		pr.AssignedReviewers = append(pr.AssignedReviewers, id)
+		if fallback {
+			pr.FallbackReviewers = append(pr.FallbackReviewers, id)
+		}
+		if decision != nil && decidedAt != nil {
+			pr.Decisions = append(pr.Decisions, domain.Review{
+				ReviewerID: id,
+				Decision:   domain.ReviewDecision(*decision),
+				DecidedAt:  *decidedAt,
+			})
+		}
 	}
-	if pr.AssignedReviewers == nil {
-		pr.AssignedReviewers = []string{}
-	}
-	return pr, rows.Err()
+	return rows.Err()
 }
 
 // UpdatePRStatus устанавливает статус PR и merged_at (идемпотентная операция).
@@ -364,12 +650,17 @@ func (s *Storage) UpdatePRStatus(ctx context.Context, prID string, status domain
 		}
 		// Устанавливаем merged_at только при переходе в статус MERGED
 		if status == domain.PRStatusMerged {
+			// Смержить можно только открытый PR
+			if err := domain.RequireOpenStatus(current); err != nil {
+				return err
+			}
 			if _, err := tx.Exec(ctx, `
 				UPDATE pull_requests SET status=$2, merged_at=COALESCE(merged_at, NOW())
 				WHERE pull_request_id=$1
 			`, prID, string(status)); err != nil {
 				return err
 			}
+			return recordStatusEvent(ctx, tx, prID, "MERGED")
 		}
 		return nil
 	})
@@ -379,19 +670,45 @@ func (s *Storage) UpdatePRStatus(ctx context.Context, prID string, status domain
 	return s.GetPullRequest(ctx, prID)
 }
 
+// MarkPRReady переводит черновик PR в статус OPEN и назначает ревьюверов.
+func (s *Storage) MarkPRReady(ctx context.Context, prID string, reviewers []string) (domain.PullRequest, error) {
+	err := s.WithTx(ctx, func(tx pgx.Tx) error {
+		return openPullRequest(ctx, tx, prID, domain.PRStatusDraft, "READY_FOR_REVIEW", reviewers)
+	})
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	return s.GetPullRequest(ctx, prID)
+}
+
+// ClosePullRequest закрывает PR без merge, освобождая назначенных ревьюверов.
+func (s *Storage) ClosePullRequest(ctx context.Context, prID string) (domain.PullRequest, error) {
+	err := s.WithTx(ctx, func(tx pgx.Tx) error {
+		return closePullRequest(ctx, tx, prID)
+	})
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	return s.GetPullRequest(ctx, prID)
+}
+
+// ReopenPullRequest переоткрывает закрытый PR и назначает ревьюверов.
+func (s *Storage) ReopenPullRequest(ctx context.Context, prID string, reviewers []string) (domain.PullRequest, error) {
+	err := s.WithTx(ctx, func(tx pgx.Tx) error {
+		return openPullRequest(ctx, tx, prID, domain.PRStatusClosed, "REOPENED", reviewers)
+	})
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	return s.GetPullRequest(ctx, prID)
+}
+
 // ReplaceReviewer меняет одного ревьювера на другого.
 func (s *Storage) ReplaceReviewer(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error) {
 	err := s.WithTx(ctx, func(tx pgx.Tx) error {
-		var status domain.PRStatus
-		if err := tx.QueryRow(ctx, `SELECT status FROM pull_requests WHERE pull_request_id=$1`, prID).Scan(&status); err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return domain.ErrPRNotFound
-			}
+		if err := requireOpenPR(ctx, tx, prID); err != nil {
 			return err
 		}
-		if status == domain.PRStatusMerged {
-			return domain.ErrPRMerged
-		}
 		var assigned bool
 		if err := tx.QueryRow(ctx, `
 			SELECT EXISTS(
@@ -402,15 +719,20 @@ func (s *Storage) ReplaceReviewer(ctx context.Context, prID, oldReviewer, newRev
 		if !assigned {
 			return domain.ErrReviewerAbsent
 		}
-		if err := removeReviewer(ctx, tx, prID, oldReviewer, source); err != nil {
-			return err
-		}
 		if newReviewer != "" {
-			if err := insertReviewers(ctx, tx, prID, []string{newReviewer}, source); err != nil {
+			if err := requireUnassigned(ctx, tx, prID, []string{newReviewer}); err != nil {
 				return err
 			}
 		}
-		return nil
+		if err := removeReviewer(ctx, tx, prID, oldReviewer, source); err != nil {
+			return err
+		}
+		if newReviewer == "" {
+			return nil
+		}
+		batch := &pgx.Batch{}
+		queueAssignment(batch, prID, newReviewer, source, oldReviewer)
+		return tx.SendBatch(ctx, batch).Close()
 	})
 	if err != nil {
 		return domain.PullRequest{}, "", err
@@ -419,30 +741,110 @@ func (s *Storage) ReplaceReviewer(ctx context.Context, prID, oldReviewer, newRev
 	return pr, newReviewer, err
 }
 
-// ListReviewAssignments возвращает PR'ы для ревьювера.
-func (s *Storage) ListReviewAssignments(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
-	rows, err := s.pool.Query(ctx, `
-		SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status
-		FROM pull_request_reviewers r
-		JOIN pull_requests p ON p.pull_request_id=r.pull_request_id
-		WHERE r.reviewer_id=$1
-		ORDER BY p.created_at DESC
-	`, userID)
+// AddReviewers добавляет ревьюверов к PR, не снимая уже назначенных.
+func (s *Storage) AddReviewers(ctx context.Context, prID string, reviewers []string, source string) (domain.PullRequest, error) {
+	err := s.WithTx(ctx, func(tx pgx.Tx) error {
+		if err := requireOpenPR(ctx, tx, prID); err != nil {
+			return err
+		}
+		if len(reviewers) == 0 {
+			return nil
+		}
+		if err := requireUnassigned(ctx, tx, prID, reviewers); err != nil {
+			return err
+		}
+		return insertReviewers(ctx, tx, prID, reviewers, source)
+	})
 	if err != nil {
-		return nil, err
+		return domain.PullRequest{}, err
+	}
+	return s.GetPullRequest(ctx, prID)
+}
+
+// SubmitReview сохраняет решение ревьювера по открытому PR.
+func (s *Storage) SubmitReview(ctx context.Context, prID, reviewerID string, decision domain.ReviewDecision) (domain.PullRequest, error) {
+	err := s.WithTx(ctx, func(tx pgx.Tx) error {
+		if err := requireOpenPR(ctx, tx, prID); err != nil {
+			return err
+		}
+		return recordReview(ctx, tx, prID, reviewerID, decision)
+	})
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	return s.GetPullRequest(ctx, prID)
+}
+
+// ListReviewAssignments возвращает PR, на которые назначен ревьювер, от новых к старым с учётом фильтра и курсора.
+func (s *Storage) ListReviewAssignments(ctx context.Context, filter domain.ReviewAssignmentFilter) ([]domain.PullRequestShort, error) {
+	columns := []string{"p.pull_request_id", "p.pull_request_name", "p.author_id", "p.status", "p.created_at", "p.merged_at"}
+	if filter.IncludeReviewers {
+		columns = append(columns, "ARRAY(SELECT rr.reviewer_id FROM pull_request_reviewers rr "+
+			"WHERE rr.pull_request_id=p.pull_request_id ORDER BY rr.reviewer_id)")
+	}
+	query := filterReviewAssignments(s.sb.Select(columns...), filter).
+		OrderBy("p.created_at DESC", "p.pull_request_id DESC")
+	if filter.AfterCreatedAt != nil {
+		query = query.Where("(p.created_at, p.pull_request_id) < (?, ?)", *filter.AfterCreatedAt, filter.AfterID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(uint64(filter.Limit))
+	}
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to query review assignments", "error", err)
+		return nil, fmt.Errorf("%w: %v", ErrExecuteQuery, err)
 	}
 	defer rows.Close()
-	var result []domain.PullRequestShort
+	result := []domain.PullRequestShort{}
 	for rows.Next() {
 		var pr domain.PullRequestShort
-		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status); err != nil {
-			return nil, err
+		dest := []any{&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt}
+		if filter.IncludeReviewers {
+			dest = append(dest, &pr.AssignedReviewers)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrScanResult, err)
 		}
 		result = append(result, pr)
 	}
 	return result, rows.Err()
 }
 
+// CountReviewAssignments возвращает количество PR ревьювера под фильтром статусов без учёта курсора и limit.
+func (s *Storage) CountReviewAssignments(ctx context.Context, filter domain.ReviewAssignmentFilter) (int64, error) {
+	sql, args, err := filterReviewAssignments(s.sb.Select("COUNT(*)"), filter).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+	var total int64
+	if err := s.conn(ctx).QueryRow(ctx, sql, args...).Scan(&total); err != nil {
+		slog.ErrorContext(ctx, "failed to count review assignments", "error", err)
+		return 0, fmt.Errorf("%w: %v", ErrExecuteQuery, err)
+	}
+	return total, nil
+}
+
+// filterReviewAssignments ограничивает запрос PR ревьювера filter.UserID со статусами filter.Statuses.
+func filterReviewAssignments(query squirrel.SelectBuilder, filter domain.ReviewAssignmentFilter) squirrel.SelectBuilder {
+	query = query.
+		From("pull_request_reviewers r").
+		Join("pull_requests p ON p.pull_request_id=r.pull_request_id").
+		Where(squirrel.Eq{"r.reviewer_id": filter.UserID})
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		query = query.Where(squirrel.Eq{"p.status": statuses})
+	}
+	return query
+}
+
 // ListActiveTeamMembers возвращает активных участников команды, исключая указанных пользователей.
 // Использует динамическое построение SQL для фильтрации по списку исключений.
 func (s *Storage) ListActiveTeamMembers(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
@@ -459,8 +861,8 @@ func (s *Storage) ListActiveTeamMembers(ctx context.Context, teamName string, ex
 		}
 		where.WriteString(" AND user_id NOT IN (" + strings.Join(ph, ",") + ")")
 	}
-	query := fmt.Sprintf(`SELECT user_id, username, team_name, is_active FROM users WHERE %s`, where.String())
-	rows, err := s.pool.Query(ctx, query, params...)
+	query := fmt.Sprintf(`SELECT user_id, username, team_name, is_active, COALESCE(max_open_reviews, 0) FROM users WHERE %s`, where.String())
+	rows, err := s.conn(ctx).Query(ctx, query, params...)
 	if err != nil {
 		return nil, err
 	}
@@ -468,7 +870,7 @@ func (s *Storage) ListActiveTeamMembers(ctx context.Context, teamName string, ex
 	var users []domain.User
 	for rows.Next() {
 		var u domain.User
-		if err := rows.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+		if err := rows.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive, &u.MaxOpenReviews); err != nil {
 			return nil, err
 		}
 		users = append(users, u)
@@ -483,15 +885,22 @@ func (s *Storage) DeactivateUsers(ctx context.Context, userIDs []string) ([]doma
 	}
 	err := s.WithTx(ctx, func(tx pgx.Tx) error {
 		_, err := tx.Exec(ctx, `
-			UPDATE users SET is_active=FALSE, updated_at=NOW()
-			WHERE user_id = ANY($1)
+			WITH u AS (
+				UPDATE users SET is_active=FALSE, updated_at=NOW()
+				WHERE user_id = ANY($1)
+				RETURNING user_id
+			)
+			INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+			SELECT '`+domain.OutboxAggregateUser+`', u.user_id, '`+domain.OutboxUserActivityChanged+`',
+				jsonb_build_object('user_id', u.user_id, 'is_active', FALSE)
+			FROM u
 		`, userIDs)
 		return err
 	})
 	if err != nil {
 		return nil, err
 	}
-	rows, err := s.pool.Query(ctx, `
+	rows, err := s.conn(ctx).Query(ctx, `
 		SELECT user_id, username, team_name, is_active FROM users WHERE user_id = ANY($1)
 	`, userIDs)
 	if err != nil {
@@ -514,7 +923,7 @@ func (s *Storage) ListOpenPRsByReviewer(ctx context.Context, reviewerIDs []strin
 	if len(reviewerIDs) == 0 {
 		return map[string][]string{}, nil
 	}
-	rows, err := s.pool.Query(ctx, `
+	rows, err := s.conn(ctx).Query(ctx, `
 		SELECT r.reviewer_id, r.pull_request_id
 		FROM pull_request_reviewers r
 		JOIN pull_requests p ON p.pull_request_id=r.pull_request_id
@@ -535,51 +944,87 @@ func (s *Storage) ListOpenPRsByReviewer(ctx context.Context, reviewerIDs []strin
 	return result, rows.Err()
 }
 
-// FetchAssignmentStats собирает статистику.
-func (s *Storage) FetchAssignmentStats(ctx context.Context) (domain.AssignmentStats, error) {
-	rows, err := s.pool.Query(ctx, `
+// ListPendingReviews возвращает назначения на открытые PR, сделанные раньше assignedBefore,
+// по которым ревьювер ещё не вынес решение и нарушение SLA ещё не зафиксировано.
+func (s *Storage) ListPendingReviews(ctx context.Context, assignedBefore time.Time) ([]domain.PendingReview, error) {
+	rows, err := s.conn(ctx).Query(ctx, `
+		SELECT r.pull_request_id, r.reviewer_id, a.team_name, r.assigned_at
+		FROM pull_request_reviewers r
+		JOIN pull_requests p ON p.pull_request_id=r.pull_request_id
+		JOIN users a ON a.user_id=p.author_id
+		WHERE p.status='OPEN' AND r.decision IS NULL AND r.assigned_at < $1
+			AND NOT EXISTS (
+				SELECT 1 FROM review_assignment_events e
+				WHERE e.pull_request_id=r.pull_request_id AND e.reviewer_id=r.reviewer_id
+					AND e.event_type='SLA_BREACHED' AND e.created_at >= r.assigned_at
+			)
+		ORDER BY r.assigned_at
+	`, assignedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []domain.PendingReview
+	for rows.Next() {
+		var review domain.PendingReview
+		if err := rows.Scan(&review.PullRequestID, &review.ReviewerID, &review.AuthorTeam, &review.AssignedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, review)
+	}
+	return result, rows.Err()
+}
+
+// RecordSLABreach фиксирует в журнале событий нарушение SLA ревьювером PR.
+func (s *Storage) RecordSLABreach(ctx context.Context, prID, reviewerID, source string) error {
+	_, err := s.conn(ctx).Exec(ctx, withOutbox(`
+		INSERT INTO review_assignment_events (pull_request_id, reviewer_id, event_type, source)
+		VALUES ($1,$2,'SLA_BREACHED',$3)`), prID, reviewerID, source)
+	return err
+}
+
+// FetchUserAssignmentStats возвращает агрегаты назначений по пользователям.
+// Если userIDs пуст, возвращает статистику по всем пользователям.
+func (s *Storage) FetchUserAssignmentStats(ctx context.Context, userIDs []string) ([]domain.UserAssignmentStat, error) {
+	return fetchUserAssignmentStats(ctx, s.conn(ctx), userIDs)
+}
+
+// querier — общий интерфейс пула и транзакции для выполнения запросов.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// fetchUserAssignmentStats агрегирует назначения по пользователям: всего назначений
+// и количество открытых PR (active_pull_requests), на которые пользователь назначен ревьювером.
+func fetchUserAssignmentStats(ctx context.Context, q querier, userIDs []string) ([]domain.UserAssignmentStat, error) {
+	var filter any
+	if len(userIDs) > 0 {
+		filter = userIDs
+	}
+	rows, err := q.Query(ctx, `
 		SELECT u.user_id, u.username, u.team_name,
 		       COUNT(r.pull_request_id) AS assigned_total,
-		       SUM(CASE WHEN p.status='OPEN' THEN 1 ELSE 0 END) AS active_pull_requests
+		       COALESCE(SUM(CASE WHEN p.status='OPEN' THEN 1 ELSE 0 END), 0) AS active_pull_requests
 		FROM users u
 		LEFT JOIN pull_request_reviewers r ON r.reviewer_id=u.user_id
 		LEFT JOIN pull_requests p ON p.pull_request_id=r.pull_request_id
+		WHERE $1::text[] IS NULL OR u.user_id = ANY($1)
 		GROUP BY u.user_id, u.username, u.team_name
 		ORDER BY assigned_total DESC
-	`)
+	`, filter)
 	if err != nil {
-		return domain.AssignmentStats{}, err
+		return nil, err
 	}
 	defer rows.Close()
 	var perUser []domain.UserAssignmentStat
 	for rows.Next() {
 		var stat domain.UserAssignmentStat
 		if err := rows.Scan(&stat.UserID, &stat.Username, &stat.TeamName, &stat.Assigned, &stat.ActivePRs); err != nil {
-			return domain.AssignmentStats{}, err
+			return nil, err
 		}
 		perUser = append(perUser, stat)
 	}
-	if err := rows.Err(); err != nil {
-		return domain.AssignmentStats{}, err
-	}
-	rows2, err := s.pool.Query(ctx, `
-		SELECT p.pull_request_id, COUNT(r.reviewer_id) AS reviewer_count
-		FROM pull_requests p
-		LEFT JOIN pull_request_reviewers r ON r.pull_request_id=p.pull_request_id
-		GROUP BY p.pull_request_id
-		ORDER BY reviewer_count DESC
-	`)
-	if err != nil {
-		return domain.AssignmentStats{}, err
-	}
-	defer rows2.Close()
-	var perPR []domain.PRAssignmentStat
-	for rows2.Next() {
-		var stat domain.PRAssignmentStat
-		if err := rows2.Scan(&stat.PullRequestID, &stat.ReviewerCount); err != nil {
-			return domain.AssignmentStats{}, err
-		}
-		perPR = append(perPR, stat)
-	}
-	return domain.AssignmentStats{PerUser: perUser, PerPR: perPR}, rows2.Err()
+	return perUser, rows.Err()
 }
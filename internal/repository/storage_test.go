@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
 	"github.com/jackc/pgx/v5"
 	pgxmock "github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/require"
@@ -38,25 +40,28 @@ func TestStorageCreateTeamInsertsMembers(t *testing.T) {
 	mock.ExpectBeginTx(pgx.TxOptions{})
 	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("backend").
 		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
-	mock.ExpectExec(`INSERT INTO teams`).WithArgs("backend").
+	mock.ExpectExec(`INSERT INTO teams`).WithArgs("backend", 3).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 	mock.ExpectExec(`INSERT INTO users`).
-		WithArgs("u1", "Alice", "backend", true, n.now, n.now).
+		WithArgs("u1", "Alice", "backend", true, "lead", n.now, n.now).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 	mock.ExpectExec(`INSERT INTO users`).
-		WithArgs("u2", "Bob", "backend", true, n.now, n.now).
+		WithArgs("u2", "Bob", "backend", true, "", n.now, n.now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`INSERT INTO outbox`).WithArgs("team", "backend", "TEAM_CREATED", pgxmock.AnyArg()).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 	mock.ExpectCommit()
 
-	rows := pgxmock.NewRows([]string{"user_id", "username", "team_name", "is_active"}).
-		AddRow("u1", "Alice", "backend", true).
-		AddRow("u2", "Bob", "backend", true)
+	rows := pgxmock.NewRows([]string{"user_id", "username", "team_name", "is_active", "role"}).
+		AddRow("u1", "Alice", "backend", true, "lead").
+		AddRow("u2", "Bob", "backend", true, "")
 	mock.ExpectQuery(`SELECT u\.user_id`).WithArgs("backend").WillReturnRows(rows)
 
 	team := domain.Team{
-		Name: "backend",
+		Name:              "backend",
+		RequiredReviewers: 3,
 		Members: []domain.User{
-			{ID: "u1", Username: "Alice", IsActive: true},
+			{ID: "u1", Username: "Alice", IsActive: true, Role: "lead"},
 			{ID: "u2", Username: "Bob", IsActive: true},
 		},
 	}
@@ -65,6 +70,7 @@ func TestStorageCreateTeamInsertsMembers(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "backend", created.Name)
 	require.Len(t, created.Members, 2)
+	require.Equal(t, "lead", created.Members[0].Role)
 }
 
 func TestStorageGetTeamNotFound(t *testing.T) {
@@ -72,7 +78,7 @@ func TestStorageGetTeamNotFound(t *testing.T) {
 	ctx := context.Background()
 
 	mock.ExpectQuery(`SELECT u\.user_id`).WithArgs("ghost").
-		WillReturnRows(pgxmock.NewRows([]string{"user_id", "username", "team_name", "is_active"}))
+		WillReturnRows(pgxmock.NewRows([]string{"user_id", "username", "team_name", "is_active", "role"}))
 	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("ghost").
 		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
 
@@ -80,15 +86,68 @@ func TestStorageGetTeamNotFound(t *testing.T) {
 	require.ErrorIs(t, err, domain.ErrTeamNotFound)
 }
 
+func TestStorageGetTeamSettingsNotFound(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT team_name, COALESCE\(reviewer_strategy`).WithArgs("ghost").
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err := storage.GetTeamSettings(ctx, "ghost")
+	require.ErrorIs(t, err, domain.ErrTeamNotFound)
+}
+
+func TestStorageUpdateTeamSettings(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	block := true
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectExec(`UPDATE teams SET reviewer_strategy = NULLIF\(\$1, ''\), required_reviewers = NULLIF\(\$2, 0\), fallback_teams = NULLIF.*max_open_reviews = NULLIF.*merge_block_on_changes_requested = \$6`).
+		WithArgs("round_robin", 3, []string{"platform", "mobile"}, 5, 2, &block, "lead", 24, "REASSIGN", "backend").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`INSERT INTO outbox`).WithArgs("team", "backend", "TEAM_SETTINGS_UPDATED", pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT team_name, COALESCE\(reviewer_strategy`).WithArgs("backend").
+		WillReturnRows(pgxmock.NewRows([]string{"team_name", "reviewer_strategy", "required_reviewers", "fallback_teams", "max_open_reviews",
+			"merge_required_approvals", "merge_block_on_changes_requested", "merge_required_role", "review_sla_hours", "review_sla_escalation"}).
+			AddRow("backend", domain.ReviewerStrategyRoundRobin, 3, []string{"platform", "mobile"}, 5, 2, &block, "lead", 24, domain.SLAEscalationReassign))
+
+	settings, err := storage.UpdateTeamSettings(ctx, domain.TeamSettings{
+		TeamName:          "backend",
+		ReviewerStrategy:  domain.ReviewerStrategyRoundRobin,
+		RequiredReviewers: 3,
+		FallbackTeams:     []string{"platform", "mobile"},
+		MaxOpenReviews:    5,
+		MergePolicy:       domain.MergePolicy{RequiredApprovals: 2, BlockOnChangesRequested: &block, RequiredRole: "lead"},
+		ReviewSLA:         domain.ReviewSLA{Hours: 24, Escalation: domain.SLAEscalationReassign},
+	})
+	require.NoError(t, err)
+	require.Equal(t, domain.ReviewerStrategyRoundRobin, settings.ReviewerStrategy)
+	require.Equal(t, 3, settings.RequiredReviewers)
+	require.Equal(t, []string{"platform", "mobile"}, settings.FallbackTeams)
+	require.Equal(t, 5, settings.MaxOpenReviews)
+	require.Equal(t, 2, settings.MergePolicy.RequiredApprovals)
+	require.True(t, *settings.MergePolicy.BlockOnChangesRequested)
+	require.Equal(t, "lead", settings.MergePolicy.RequiredRole)
+	require.Equal(t, domain.ReviewSLA{Hours: 24, Escalation: domain.SLAEscalationReassign}, settings.ReviewSLA)
+}
+
 func TestStorageSetUserActivityUpdatesAndReturnsUser(t *testing.T) {
 	storage, mock, n := newMockStorage(t)
 	ctx := context.Background()
 
+	mock.ExpectBeginTx(pgx.TxOptions{})
 	mock.ExpectExec(`UPDATE users SET`).WithArgs(false, n.now, "u1").
 		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "u1", "USER_ACTIVITY_CHANGED", []byte(`{"is_active":false,"user_id":"u1"}`)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
 	mock.ExpectQuery(`SELECT user_id`).WithArgs("u1").
-		WillReturnRows(pgxmock.NewRows([]string{"user_id", "username", "team_name", "is_active"}).
-			AddRow("u1", "Alice", "backend", false))
+		WillReturnRows(pgxmock.NewRows([]string{"user_id", "username", "team_name", "is_active", "max_open_reviews", "role"}).
+			AddRow("u1", "Alice", "backend", false, 0, ""))
 
 	user, err := storage.SetUserActivity(ctx, "u1", false)
 	require.NoError(t, err)
@@ -96,6 +155,51 @@ func TestStorageSetUserActivityUpdatesAndReturnsUser(t *testing.T) {
 	require.False(t, user.IsActive)
 }
 
+func TestStorageSetUserMaxOpenReviews(t *testing.T) {
+	storage, mock, n := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectExec(`UPDATE users SET max_open_reviews = NULLIF`).WithArgs(3, n.now, "u1").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`INSERT INTO outbox`).WithArgs("user", "u1", "USER_MAX_OPEN_REVIEWS_CHANGED", pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT user_id`).WithArgs("u1").
+		WillReturnRows(pgxmock.NewRows([]string{"user_id", "username", "team_name", "is_active", "max_open_reviews", "role"}).
+			AddRow("u1", "Alice", "backend", true, 3, ""))
+
+	user, err := storage.SetUserMaxOpenReviews(ctx, "u1", 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, user.MaxOpenReviews)
+}
+
+func TestStorageSetUserRole(t *testing.T) {
+	storage, mock, n := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectExec(`UPDATE users SET role = NULLIF`).WithArgs("lead", n.now, "u1").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`INSERT INTO outbox`).WithArgs("user", "u1", "USER_ROLE_CHANGED", pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT user_id`).WithArgs("u1").
+		WillReturnRows(pgxmock.NewRows([]string{"user_id", "username", "team_name", "is_active", "max_open_reviews", "role"}).
+			AddRow("u1", "Alice", "backend", true, 0, "lead"))
+
+	user, err := storage.SetUserRole(ctx, "u1", "lead")
+	require.NoError(t, err)
+	require.Equal(t, "lead", user.Role)
+
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectExec(`UPDATE users SET role = NULLIF`).WithArgs("", n.now, "ghost").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectRollback()
+	_, err = storage.SetUserRole(ctx, "ghost", "")
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
 func TestStorageGetUserByIDNotFound(t *testing.T) {
 	storage, mock, _ := newMockStorage(t)
 	ctx := context.Background()
@@ -116,6 +220,9 @@ func TestStorageCreatePullRequestPersistsReviewers(t *testing.T) {
 		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
 	mock.ExpectExec(`INSERT INTO pull_requests`).WithArgs("pr-1", "Feature", "u1", string(domain.PRStatusOpen)).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs(domain.OutboxAggregatePullRequest, "pr-1", domain.OutboxPullRequestCreated, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 	batch := mock.ExpectBatch()
 	batch.ExpectExec(`INSERT INTO pull_request_reviewers`).WithArgs("pr-1", "u2").
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
@@ -128,7 +235,7 @@ func TestStorageCreatePullRequestPersistsReviewers(t *testing.T) {
 		WillReturnRows(pgxmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at"}).
 			AddRow("pr-1", "Feature", "u1", domain.PRStatusOpen, now, nil))
 	mock.ExpectQuery(`SELECT reviewer_id`).WithArgs("pr-1").
-		WillReturnRows(pgxmock.NewRows([]string{"reviewer_id"}).AddRow("u2"))
+		WillReturnRows(pgxmock.NewRows([]string{"reviewer_id", "from_fallback", "decision", "decided_at"}).AddRow("u2", false, nil, nil))
 
 	pr := domain.PullRequest{ID: "pr-1", Name: "Feature", AuthorID: "u1", Status: domain.PRStatusOpen}
 	created, err := storage.CreatePullRequest(ctx, pr, []string{"u2"})
@@ -136,6 +243,35 @@ func TestStorageCreatePullRequestPersistsReviewers(t *testing.T) {
 	require.Equal(t, []string{"u2"}, created.AssignedReviewers)
 }
 
+func TestStorageCreateDraftPullRequestWritesOutbox(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(`INSERT INTO pull_requests`).WithArgs("pr-1", "Feature", "u1", string(domain.PRStatusDraft)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	// Ровно одна запись outbox, хотя ревьюверов у черновика нет
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs(domain.OutboxAggregatePullRequest, "pr-1", domain.OutboxPullRequestCreated,
+			[]byte(`{"author_id":"u1","pull_request_id":"pr-1","pull_request_name":"Feature","status":"DRAFT"}`)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT pull_request_id`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at"}).
+			AddRow("pr-1", "Feature", "u1", domain.PRStatusDraft, now, nil))
+	mock.ExpectQuery(`SELECT reviewer_id`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"reviewer_id", "from_fallback", "decision", "decided_at"}))
+
+	pr := domain.PullRequest{ID: "pr-1", Name: "Feature", AuthorID: "u1", Status: domain.PRStatusDraft}
+	created, err := storage.CreatePullRequest(ctx, pr, nil)
+	require.NoError(t, err)
+	require.Empty(t, created.AssignedReviewers)
+}
+
 func TestStorageGetPullRequestNotFound(t *testing.T) {
 	storage, mock, _ := newMockStorage(t)
 	ctx := context.Background()
@@ -151,28 +287,82 @@ func TestStorageListReviewAssignments(t *testing.T) {
 	storage, mock, _ := newMockStorage(t)
 	ctx := context.Background()
 
-	rows := pgxmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status"}).
-		AddRow("pr-1", "Feature", "u1", domain.PRStatusOpen)
-	mock.ExpectQuery(`SELECT p\.pull_request_id`).WithArgs("u2").WillReturnRows(rows)
+	created := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	rows := pgxmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at"}).
+		AddRow("pr-1", "Feature", "u1", domain.PRStatusOpen, created, nil)
+	mock.ExpectQuery(`SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at, p.merged_at ` +
+		`FROM pull_request_reviewers r JOIN pull_requests p ON p.pull_request_id=r.pull_request_id ` +
+		`WHERE r.reviewer_id = \$1 ORDER BY p.created_at DESC, p.pull_request_id DESC`).
+		WithArgs("u2").WillReturnRows(rows)
 
-	assignments, err := storage.ListReviewAssignments(ctx, "u2")
+	assignments, err := storage.ListReviewAssignments(ctx, domain.ReviewAssignmentFilter{UserID: "u2"})
+	require.NoError(t, err)
+	require.Equal(t, []domain.PullRequestShort{
+		{ID: "pr-1", Name: "Feature", AuthorID: "u1", Status: domain.PRStatusOpen, CreatedAt: created},
+	}, assignments)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStorageListReviewAssignmentsAppliesFilter(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	after := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	rows := pgxmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at", "reviewers"}).
+		AddRow("pr-1", "Feature", "u1", domain.PRStatusDraft, after.Add(-time.Hour), nil, []string{"u2", "u3"})
+	mock.ExpectQuery(`SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at, p.merged_at, `+
+		`ARRAY\(SELECT rr.reviewer_id FROM pull_request_reviewers rr WHERE rr.pull_request_id=p.pull_request_id ORDER BY rr.reviewer_id\) `+
+		`FROM pull_request_reviewers r JOIN pull_requests p ON p.pull_request_id=r.pull_request_id `+
+		`WHERE r.reviewer_id = \$1 AND p.status IN \(\$2,\$3\) AND \(p.created_at, p.pull_request_id\) < \(\$4, \$5\) `+
+		`ORDER BY p.created_at DESC, p.pull_request_id DESC LIMIT 11`).
+		WithArgs("u2", "OPEN", "DRAFT", after, "pr-9").WillReturnRows(rows)
+
+	assignments, err := storage.ListReviewAssignments(ctx, domain.ReviewAssignmentFilter{
+		UserID:           "u2",
+		Statuses:         []domain.PRStatus{domain.PRStatusOpen, domain.PRStatusDraft},
+		IncludeReviewers: true,
+		AfterCreatedAt:   &after,
+		AfterID:          "pr-9",
+		Limit:            11,
+	})
 	require.NoError(t, err)
 	require.Len(t, assignments, 1)
-	require.Equal(t, "pr-1", assignments[0].ID)
+	require.Equal(t, []string{"u2", "u3"}, assignments[0].AssignedReviewers)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStorageCountReviewAssignments(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM pull_request_reviewers r JOIN pull_requests p ON p.pull_request_id=r.pull_request_id `+
+		`WHERE r.reviewer_id = \$1 AND p.status IN \(\$2\)`).
+		WithArgs("u2", "OPEN").WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(42)))
+
+	total, err := storage.CountReviewAssignments(ctx, domain.ReviewAssignmentFilter{
+		UserID:   "u2",
+		Statuses: []domain.PRStatus{domain.PRStatusOpen},
+		AfterID:  "ignored",
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(42), total)
+	require.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestStorageListActiveTeamMembersExcludes(t *testing.T) {
 	storage, mock, _ := newMockStorage(t)
 	ctx := context.Background()
 
-	rows := pgxmock.NewRows([]string{"user_id", "username", "team_name", "is_active"}).
-		AddRow("u3", "Charlie", "backend", true)
-	mock.ExpectQuery(`SELECT user_id, username, team_name, is_active FROM users WHERE team_name=\$1 AND is_active=TRUE AND user_id NOT IN`).
+	rows := pgxmock.NewRows([]string{"user_id", "username", "team_name", "is_active", "max_open_reviews"}).
+		AddRow("u3", "Charlie", "backend", true, 4)
+	mock.ExpectQuery(`SELECT user_id, username, team_name, is_active, COALESCE\(max_open_reviews, 0\) FROM users WHERE team_name=\$1 AND is_active=TRUE AND user_id NOT IN`).
 		WithArgs("backend", "u1", "u2").WillReturnRows(rows)
 
 	users, err := storage.ListActiveTeamMembers(ctx, "backend", []string{"u1", "u2"})
 	require.NoError(t, err)
 	require.Equal(t, []string{"u3"}, []string{users[0].ID})
+	require.Equal(t, 4, users[0].MaxOpenReviews)
 }
 
 func TestStorageDeactivateUsers(t *testing.T) {
@@ -210,24 +400,18 @@ func TestStorageListOpenPRsByReviewer(t *testing.T) {
 	require.Equal(t, []string{"pr-1", "pr-2"}, result["u2"])
 }
 
-func TestStorageFetchAssignmentStats(t *testing.T) {
+func TestStorageFetchUserAssignmentStatsFiltersUsers(t *testing.T) {
 	storage, mock, _ := newMockStorage(t)
 	ctx := context.Background()
 
 	userRows := pgxmock.NewRows([]string{"user_id", "username", "team_name", "assigned_total", "active_pull_requests"}).
-		AddRow("u1", "Alice", "backend", int64(3), int64(1))
-	mock.ExpectQuery(`SELECT u\.user_id`).WillReturnRows(userRows)
+		AddRow("u2", "Bob", "backend", int64(4), int64(2))
+	mock.ExpectQuery(`SELECT u\.user_id`).WithArgs([]string{"u2"}).WillReturnRows(userRows)
 
-	prRows := pgxmock.NewRows([]string{"pull_request_id", "reviewer_count"}).
-		AddRow("pr-1", int64(2))
-	mock.ExpectQuery(`SELECT p\.pull_request_id`).WillReturnRows(prRows)
-
-	stats, err := storage.FetchAssignmentStats(ctx)
+	stats, err := storage.FetchUserAssignmentStats(ctx, []string{"u2"})
 	require.NoError(t, err)
-	require.Len(t, stats.PerUser, 1)
-	require.Len(t, stats.PerPR, 1)
-	require.Equal(t, "u1", stats.PerUser[0].UserID)
-	require.Equal(t, int64(2), stats.PerPR[0].ReviewerCount)
+	require.Len(t, stats, 1)
+	require.Equal(t, int64(2), stats[0].ActivePRs)
 }
 
 func TestStorageUpdatePRStatusMerges(t *testing.T) {
@@ -239,6 +423,8 @@ func TestStorageUpdatePRStatusMerges(t *testing.T) {
 		WillReturnRows(pgxmock.NewRows([]string{"status", "merged_at"}).AddRow(domain.PRStatusOpen, nil))
 	mock.ExpectExec(`UPDATE pull_requests SET status=`).WithArgs("pr-1", string(domain.PRStatusMerged)).
 		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`INSERT INTO review_assignment_events`).WithArgs("pr-1", "MERGED").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 	mock.ExpectCommit()
 
 	now := time.Now()
@@ -247,7 +433,7 @@ func TestStorageUpdatePRStatusMerges(t *testing.T) {
 		WillReturnRows(pgxmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at"}).
 			AddRow("pr-1", "Feature", "u1", domain.PRStatusMerged, now, &mergedAt))
 	mock.ExpectQuery(`SELECT reviewer_id`).WithArgs("pr-1").
-		WillReturnRows(pgxmock.NewRows([]string{"reviewer_id"}))
+		WillReturnRows(pgxmock.NewRows([]string{"reviewer_id", "from_fallback", "decision", "decided_at"}))
 
 	pr, err := storage.UpdatePRStatus(ctx, "pr-1", domain.PRStatusMerged)
 	require.NoError(t, err)
@@ -255,6 +441,109 @@ func TestStorageUpdatePRStatusMerges(t *testing.T) {
 	require.NotNil(t, pr.MergedAt)
 }
 
+func TestStorageUpdatePRStatusRejectsClosed(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectQuery(`SELECT status, merged_at`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"status", "merged_at"}).AddRow(domain.PRStatusClosed, nil))
+	mock.ExpectRollback()
+
+	_, err := storage.UpdatePRStatus(ctx, "pr-1", domain.PRStatusMerged)
+	require.ErrorIs(t, err, domain.ErrPRClosed)
+}
+
+func TestStorageMarkPRReadyAssignsReviewers(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectQuery(`SELECT status FROM pull_requests`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(domain.PRStatusDraft))
+	mock.ExpectExec(`UPDATE pull_requests SET status=`).WithArgs("pr-1", string(domain.PRStatusOpen)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`INSERT INTO review_assignment_events`).WithArgs("pr-1", "READY_FOR_REVIEW").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	batch := mock.ExpectBatch()
+	batch.ExpectExec(`INSERT INTO pull_request_reviewers`).WithArgs("pr-1", "u2").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	batch.ExpectExec(`INSERT INTO review_assignment_events`).WithArgs("pr-1", "u2", "AUTO_ASSIGN").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT pull_request_id`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at"}).
+			AddRow("pr-1", "Feature", "u1", domain.PRStatusOpen, now, nil))
+	mock.ExpectQuery(`SELECT reviewer_id`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"reviewer_id", "from_fallback", "decision", "decided_at"}).AddRow("u2", false, nil, nil))
+
+	pr, err := storage.MarkPRReady(ctx, "pr-1", []string{"u2"})
+	require.NoError(t, err)
+	require.Equal(t, domain.PRStatusOpen, pr.Status)
+	require.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+}
+
+func TestStorageLockPullRequest(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT status FROM pull_requests WHERE pull_request_id=\$1 FOR UPDATE`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(domain.PRStatusOpen))
+	mock.ExpectQuery(`SELECT status FROM pull_requests WHERE pull_request_id=\$1 FOR UPDATE`).WithArgs("pr-2").
+		WillReturnError(pgx.ErrNoRows)
+
+	require.NoError(t, storage.LockPullRequest(ctx, "pr-1"))
+	require.ErrorIs(t, storage.LockPullRequest(ctx, "pr-2"), domain.ErrPRNotFound)
+}
+
+func TestStorageReopenPullRequestRejectsMerged(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectQuery(`SELECT status FROM pull_requests`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(domain.PRStatusMerged))
+	mock.ExpectRollback()
+
+	_, err := storage.ReopenPullRequest(ctx, "pr-1", []string{"u2"})
+	require.ErrorIs(t, err, domain.ErrPRMerged)
+}
+
+func TestStorageClosePullRequestReleasesReviewers(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectQuery(`SELECT status FROM pull_requests`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(domain.PRStatusOpen))
+	mock.ExpectQuery(`SELECT reviewer_id FROM pull_request_reviewers`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"reviewer_id"}).AddRow("u2"))
+	batch := mock.ExpectBatch()
+	batch.ExpectExec(`DELETE FROM pull_request_reviewers`).WithArgs("pr-1", "u2").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	batch.ExpectExec(`INSERT INTO review_assignment_events`).WithArgs("pr-1", "u2", "PR_CLOSED").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`UPDATE pull_requests SET status=`).WithArgs("pr-1", string(domain.PRStatusClosed)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`INSERT INTO review_assignment_events`).WithArgs("pr-1", "CLOSED").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT pull_request_id`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at"}).
+			AddRow("pr-1", "Feature", "u1", domain.PRStatusClosed, now, nil))
+	mock.ExpectQuery(`SELECT reviewer_id`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"reviewer_id", "from_fallback", "decision", "decided_at"}))
+
+	pr, err := storage.ClosePullRequest(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, domain.PRStatusClosed, pr.Status)
+	require.Empty(t, pr.AssignedReviewers)
+}
+
 func TestStorageReplaceReviewer(t *testing.T) {
 	storage, mock, _ := newMockStorage(t)
 	ctx := context.Background()
@@ -264,6 +553,8 @@ func TestStorageReplaceReviewer(t *testing.T) {
 		WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(domain.PRStatusOpen))
 	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("pr-1", "old").
 		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`reviewer_id = ANY\(\$2\)`).WithArgs("pr-1", []string{"new"}).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
 
 	removeBatch := mock.ExpectBatch()
 	removeBatch.ExpectExec(`DELETE FROM pull_request_reviewers`).WithArgs("pr-1", "old").
@@ -274,7 +565,8 @@ func TestStorageReplaceReviewer(t *testing.T) {
 	addBatch := mock.ExpectBatch()
 	addBatch.ExpectExec(`INSERT INTO pull_request_reviewers`).WithArgs("pr-1", "new").
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
-	addBatch.ExpectExec(`INSERT INTO review_assignment_events`).WithArgs("pr-1", "new", "MANUAL").
+	// Снятый ревьювер записывается в payload outbox назначения
+	addBatch.ExpectExec(`'replaces', \$4::text`).WithArgs("pr-1", "new", "MANUAL", "old").
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	mock.ExpectCommit()
@@ -284,12 +576,199 @@ func TestStorageReplaceReviewer(t *testing.T) {
 		WillReturnRows(pgxmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at"}).
 			AddRow("pr-1", "Feature", "u1", domain.PRStatusOpen, now, nil))
 	mock.ExpectQuery(`SELECT reviewer_id`).WithArgs("pr-1").
-		WillReturnRows(pgxmock.NewRows([]string{"reviewer_id"}).AddRow("new"))
+		WillReturnRows(pgxmock.NewRows([]string{"reviewer_id", "from_fallback", "decision", "decided_at"}).AddRow("new", true, nil, nil))
 
 	pr, replacedBy, err := storage.ReplaceReviewer(ctx, "pr-1", "old", "new", "MANUAL")
 	require.NoError(t, err)
 	require.Equal(t, "new", replacedBy)
 	require.Equal(t, []string{"new"}, pr.AssignedReviewers)
+	require.Equal(t, []string{"new"}, pr.FallbackReviewers)
+}
+
+func TestStorageAddReviewersRejectsAlreadyAssigned(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectQuery(`SELECT status FROM pull_requests WHERE pull_request_id=\$1 FOR UPDATE`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(domain.PRStatusOpen))
+	mock.ExpectQuery(`reviewer_id = ANY\(\$2\)`).WithArgs("pr-1", []string{"u3"}).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+	batch := mock.ExpectBatch()
+	batch.ExpectExec(`INSERT INTO pull_request_reviewers`).WithArgs("pr-1", "u3").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	batch.ExpectExec(`INSERT INTO review_assignment_events`).WithArgs("pr-1", "u3", "MANUAL_ADD").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT pull_request_id`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at"}).
+			AddRow("pr-1", "Feature", "u1", domain.PRStatusOpen, time.Now(), nil))
+	mock.ExpectQuery(`SELECT reviewer_id`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"reviewer_id", "from_fallback", "decision", "decided_at"}).AddRow("u3", false, nil, nil))
+
+	// Повторное добавление того же ревьювера проверяется под блокировкой PR, а не падает на первичном ключе
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectQuery(`SELECT status FROM pull_requests WHERE pull_request_id=\$1 FOR UPDATE`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(domain.PRStatusOpen))
+	mock.ExpectQuery(`reviewer_id = ANY\(\$2\)`).WithArgs("pr-1", []string{"u3"}).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	pr, err := storage.AddReviewers(ctx, "pr-1", []string{"u3"}, "MANUAL_ADD")
+	require.NoError(t, err)
+	require.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+	_, err = storage.AddReviewers(ctx, "pr-1", []string{"u3"}, "MANUAL_ADD")
+	require.ErrorIs(t, err, domain.ErrReviewerNotEligible)
+}
+
+func TestStorageAddReviewersRejectsMerged(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectQuery(`SELECT status FROM pull_requests`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(domain.PRStatusMerged))
+	mock.ExpectRollback()
+
+	_, err := storage.AddReviewers(ctx, "pr-1", []string{"u3"}, "TEAM_DEACTIVATION")
+	require.ErrorIs(t, err, domain.ErrPRMerged)
+}
+
+// newMockTxManager возвращает transaction manager поверх отдельного мока: запросы, ушедшие мимо
+// транзакции из контекста в пул хранилища, не совпадут с ожиданиями и завершатся ошибкой.
+func newMockTxManager(t *testing.T) (*manager.Manager, pgxmock.PgxPoolIface) {
+	txMock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, txMock.ExpectationsWereMet())
+		txMock.Close()
+	})
+	return manager.Must(trmpgx.NewDefaultFactory(txMock)), txMock
+}
+
+func TestStorageUsesTransactionFromContext(t *testing.T) {
+	storage, _, n := newMockStorage(t)
+	ctx := context.Background()
+	trMgr, mock := newMockTxManager(t)
+
+	// Внешняя транзакция transaction manager'а, внутри неё — SAVEPOINT'ы для SetUserActivity и AddReviewers;
+	// чтения GetPullRequest и FetchUserAssignmentStats идут прямо в неё
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectExec(`UPDATE users SET`).WithArgs(false, n.now, "u1").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`INSERT INTO outbox`).WithArgs("user", "u1", "USER_ACTIVITY_CHANGED", pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT user_id`).WithArgs("u1").
+		WillReturnRows(pgxmock.NewRows([]string{"user_id", "username", "team_name", "is_active", "max_open_reviews", "role"}).
+			AddRow("u1", "Alice", "backend", false, 0, ""))
+	// Ревьюверы PR и нагрузка читаются в транзакции, чтобы учитывать её незакоммиченные переназначения
+	created := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT pull_request_id`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at"}).
+			AddRow("pr-1", "Add search", "u1", domain.PRStatusOpen, created, nil))
+	mock.ExpectQuery(`SELECT reviewer_id, from_fallback`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"reviewer_id", "from_fallback", "decision", "decided_at"}).
+			AddRow("u1", false, nil, nil))
+	mock.ExpectQuery(`SELECT u\.user_id`).WithArgs([]string{"u3"}).
+		WillReturnRows(pgxmock.NewRows([]string{"user_id", "username", "team_name", "assigned_total", "active_pull_requests"}).
+			AddRow("u3", "Carol", "backend", int64(1), int64(1)))
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectQuery(`SELECT status FROM pull_requests`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(domain.PRStatusMerged))
+	mock.ExpectRollback()
+	mock.ExpectRollback()
+
+	err := trMgr.Do(ctx, func(ctx context.Context) error {
+		if _, err := storage.SetUserActivity(ctx, "u1", false); err != nil {
+			return err
+		}
+		pr, err := storage.GetPullRequest(ctx, "pr-1")
+		if err != nil {
+			return err
+		}
+		require.Equal(t, []string{"u1"}, pr.AssignedReviewers)
+		stats, err := storage.FetchUserAssignmentStats(ctx, []string{"u3"})
+		if err != nil {
+			return err
+		}
+		require.Equal(t, int64(1), stats[0].ActivePRs)
+		_, err = storage.AddReviewers(ctx, "pr-1", []string{"u3"}, "TEAM_DEACTIVATION")
+		return err
+	})
+	require.ErrorIs(t, err, domain.ErrPRMerged)
+}
+
+func TestStorageListPendingReviews(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	before := time.Date(2025, 1, 10, 11, 0, 0, 0, time.UTC)
+	assignedAt := before.Add(-24 * time.Hour)
+	mock.ExpectQuery(`SELECT r.pull_request_id, r.reviewer_id, a.team_name, r.assigned_at`).WithArgs(before).
+		WillReturnRows(pgxmock.NewRows([]string{"pull_request_id", "reviewer_id", "team_name", "assigned_at"}).
+			AddRow("pr-1", "u2", "backend", assignedAt))
+
+	pending, err := storage.ListPendingReviews(ctx, before)
+	require.NoError(t, err)
+	require.Equal(t, []domain.PendingReview{
+		{PullRequestID: "pr-1", ReviewerID: "u2", AuthorTeam: "backend", AssignedAt: assignedAt},
+	}, pending)
+}
+
+func TestStorageRecordSLABreach(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectExec(`INSERT INTO review_assignment_events .*'SLA_BREACHED'.*INSERT INTO webhook_deliveries .*JOIN webhook_subscriptions w ON w.is_active.*INSERT INTO outbox`).WithArgs("pr-1", "u2", "SLA_NOTIFY").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, storage.RecordSLABreach(ctx, "pr-1", "u2", "SLA_NOTIFY"))
+}
+
+func TestStorageSubmitReview(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectQuery(`SELECT status FROM pull_requests`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(domain.PRStatusOpen))
+	mock.ExpectExec(`UPDATE pull_request_reviewers SET decision`).WithArgs("pr-1", "u2", "APPROVED").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`INSERT INTO review_assignment_events`).WithArgs("pr-1", "u2", "APPROVED").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	now := time.Now()
+	decision := "APPROVED"
+	mock.ExpectQuery(`SELECT pull_request_id`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at"}).
+			AddRow("pr-1", "Feature", "u1", domain.PRStatusOpen, now, nil))
+	mock.ExpectQuery(`SELECT reviewer_id`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"reviewer_id", "from_fallback", "decision", "decided_at"}).
+			AddRow("u2", false, &decision, &now).
+			AddRow("u3", false, nil, nil))
+
+	pr, err := storage.SubmitReview(ctx, "pr-1", "u2", domain.ReviewDecisionApproved)
+	require.NoError(t, err)
+	require.Equal(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+	require.Equal(t, []domain.Review{{ReviewerID: "u2", Decision: domain.ReviewDecisionApproved, DecidedAt: now}}, pr.Decisions)
+}
+
+func TestStorageSubmitReviewRequiresAssignment(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectBeginTx(pgx.TxOptions{})
+	mock.ExpectQuery(`SELECT status FROM pull_requests`).WithArgs("pr-1").
+		WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(domain.PRStatusOpen))
+	mock.ExpectExec(`UPDATE pull_request_reviewers SET decision`).WithArgs("pr-1", "u9", "COMMENTED").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectRollback()
+
+	_, err := storage.SubmitReview(ctx, "pr-1", "u9", domain.ReviewDecisionCommented)
+	require.ErrorIs(t, err, domain.ErrReviewerAbsent)
 }
 
 func TestStoragePingAndClose(t *testing.T) {
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+const webhookColumns = `id, url, event_types, teams, is_active, created_at`
+
+const webhookDeliveryColumns = `d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+	d.next_attempt_at, d.last_status_code, d.last_error, d.delivered_at, d.created_at`
+
+// CreateWebhook создаёт подписку. Подписка получает только события, записанные после её создания:
+// доставки создаются вместе с событием (см. withOutbox).
+func (s *Storage) CreateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	created, err := scanWebhook(s.conn(ctx).QueryRow(ctx, `
+		INSERT INTO webhook_subscriptions (url, secret, event_types, teams, is_active)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+webhookColumns,
+		webhook.URL, webhook.Secret, eventTypeStrings(webhook.EventTypes), nonNilStrings(webhook.Teams), webhook.IsActive))
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	created.Secret = webhook.Secret
+	return created, nil
+}
+
+// GetWebhook возвращает подписку без секрета.
+func (s *Storage) GetWebhook(ctx context.Context, id int64) (domain.Webhook, error) {
+	webhook, err := scanWebhook(s.conn(ctx).QueryRow(ctx,
+		`SELECT `+webhookColumns+` FROM webhook_subscriptions WHERE id=$1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	}
+	return webhook, err
+}
+
+// ListWebhooks возвращает все подписки без секретов.
+func (s *Storage) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	rows, err := s.conn(ctx).Query(ctx, `SELECT `+webhookColumns+` FROM webhook_subscriptions ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	webhooks := []domain.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// UpdateWebhook сохраняет подписку. Пустой Secret оставляет прежний ключ подписи.
+func (s *Storage) UpdateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	updated, err := scanWebhook(s.conn(ctx).QueryRow(ctx, `
+		UPDATE webhook_subscriptions
+		SET url=$2, secret=COALESCE(NULLIF($3, ''), secret), event_types=$4, teams=$5, is_active=$6, updated_at=$7
+		WHERE id=$1
+		RETURNING `+webhookColumns,
+		webhook.ID, webhook.URL, webhook.Secret, eventTypeStrings(webhook.EventTypes), nonNilStrings(webhook.Teams),
+		webhook.IsActive, s.nower.Now()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	}
+	return updated, err
+}
+
+// DeleteWebhook удаляет подписку вместе с журналом её доставок.
+func (s *Storage) DeleteWebhook(ctx context.Context, id int64) error {
+	cmd, err := s.conn(ctx).Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// ClaimWebhookDeliveries выбирает до limit доставок, которым пора выполнить попытку, и откладывает
+// их следующую попытку до now+lease, чтобы другие экземпляры сервиса не отправили их одновременно.
+func (s *Storage) ClaimWebhookDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	rows, err := s.conn(ctx).Query(ctx, `
+		WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status='PENDING' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at=$2
+		FROM due, webhook_subscriptions w
+		WHERE d.id=due.id AND w.id=d.subscription_id
+		RETURNING `+webhookDeliveryColumns+`, w.url, w.secret
+	`, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		if err := rows.Scan(append(deliveryDest(&delivery), &delivery.URL, &delivery.Secret)...); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// SaveWebhookAttempt сохраняет результат попытки доставки: статус, счётчик попыток,
+// время следующей попытки и ответ получателя.
+func (s *Storage) SaveWebhookAttempt(ctx context.Context, delivery domain.WebhookDelivery) error {
+	_, err := s.conn(ctx).Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status=$2, attempts=$3, next_attempt_at=$4, last_status_code=$5, last_error=$6, delivered_at=$7
+		WHERE id=$1
+	`, delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt,
+		delivery.LastStatusCode, delivery.LastError, delivery.DeliveredAt)
+	return err
+}
+
+// ListWebhookDeliveries возвращает журнал доставок, начиная с последних.
+func (s *Storage) ListWebhookDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	rows, err := s.conn(ctx).Query(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries d
+		WHERE ($1::BIGINT = 0 OR d.subscription_id=$1) AND ($2::TEXT = '' OR d.status=$2)
+		ORDER BY d.id DESC
+		LIMIT $3
+	`, filter.WebhookID, string(filter.Status), filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		if err := rows.Scan(deliveryDest(&delivery)...); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// RedeliverWebhookDelivery ставит доставку в очередь заново с обнулённым счётчиком попыток.
+func (s *Storage) RedeliverWebhookDelivery(ctx context.Context, id int64, now time.Time) (domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := s.conn(ctx).QueryRow(ctx, `
+		UPDATE webhook_deliveries d
+		SET status='PENDING', attempts=0, next_attempt_at=$2, delivered_at=NULL
+		WHERE d.id=$1
+		RETURNING `+webhookDeliveryColumns,
+		id, now).Scan(deliveryDest(&delivery)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.WebhookDelivery{}, domain.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return delivery, nil
+}
+
+func scanWebhook(row pgx.Row) (domain.Webhook, error) {
+	var (
+		webhook    domain.Webhook
+		eventTypes []string
+	)
+	if err := row.Scan(&webhook.ID, &webhook.URL, &eventTypes, &webhook.Teams, &webhook.IsActive, &webhook.CreatedAt); err != nil {
+		return domain.Webhook{}, err
+	}
+	webhook.EventTypes = make([]domain.EventType, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		webhook.EventTypes = append(webhook.EventTypes, domain.EventType(eventType))
+	}
+	return webhook, nil
+}
+
+// deliveryDest возвращает приёмники для колонок webhookDeliveryColumns.
+func deliveryDest(d *domain.WebhookDelivery) []any {
+	return []any{&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastStatusCode, &d.LastError, &d.DeliveredAt, &d.CreatedAt}
+}
+
+func eventTypeStrings(eventTypes []domain.EventType) []string {
+	result := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		result = append(result, string(eventType))
+	}
+	return result
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
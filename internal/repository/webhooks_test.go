@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+var webhookRowColumns = []string{"id", "url", "event_types", "teams", "is_active", "created_at"}
+
+var deliveryRowColumns = []string{"id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts",
+	"next_attempt_at", "last_status_code", "last_error", "delivered_at", "created_at"}
+
+func TestStorageCreateWebhook(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`INSERT INTO webhook_subscriptions \(url, secret, event_types, teams, is_active\)`).
+		WithArgs("https://example.com", "secret", []string{"ASSIGNED"}, []string{}, true).
+		WillReturnRows(pgxmock.NewRows(webhookRowColumns).
+			AddRow(int64(1), "https://example.com", []string{"ASSIGNED"}, []string{}, true, createdAt))
+
+	hook, err := storage.CreateWebhook(ctx, domain.Webhook{
+		URL:        "https://example.com",
+		Secret:     "secret",
+		EventTypes: []domain.EventType{domain.EventAssigned},
+		IsActive:   true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, domain.Webhook{
+		ID:         1,
+		URL:        "https://example.com",
+		Secret:     "secret",
+		EventTypes: []domain.EventType{domain.EventAssigned},
+		Teams:      []string{},
+		IsActive:   true,
+		CreatedAt:  createdAt,
+	}, hook)
+}
+
+func TestStorageWebhookNotFound(t *testing.T) {
+	storage, mock, n := newMockStorage(t)
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT .* FROM webhook_subscriptions WHERE id=\$1`).WithArgs(int64(7)).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery(`UPDATE webhook_subscriptions`).
+		WithArgs(int64(7), "https://example.com", "", []string{}, []string{}, false, n.now).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectExec(`DELETE FROM webhook_subscriptions`).WithArgs(int64(7)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	_, err := storage.GetWebhook(ctx, 7)
+	require.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	_, err = storage.UpdateWebhook(ctx, domain.Webhook{ID: 7, URL: "https://example.com"})
+	require.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	require.ErrorIs(t, storage.DeleteWebhook(ctx, 7), domain.ErrWebhookNotFound)
+}
+
+func TestStorageClaimWebhookDeliveries(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`FOR UPDATE SKIP LOCKED`).WithArgs(now, now.Add(time.Minute), 10).
+		WillReturnRows(pgxmock.NewRows(append(deliveryRowColumns, "url", "secret")).
+			AddRow(int64(5), int64(1), int64(42), domain.EventAssigned, []byte(`{"event_id":42}`),
+				domain.WebhookDeliveryPending, 1, now, nil, nil, nil, now, "https://example.com", "secret"))
+
+	deliveries, err := storage.ClaimWebhookDeliveries(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, int64(42), deliveries[0].EventID)
+	require.Equal(t, "https://example.com", deliveries[0].URL)
+	require.Equal(t, "secret", deliveries[0].Secret)
+	require.JSONEq(t, `{"event_id":42}`, string(deliveries[0].Payload))
+}
+
+func TestStorageRedeliverWebhookDelivery(t *testing.T) {
+	storage, mock, _ := newMockStorage(t)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	code, lastErr := 500, "unexpected status 500"
+	mock.ExpectQuery(`UPDATE webhook_deliveries d\s+SET status='PENDING', attempts=0`).WithArgs(int64(5), now).
+		WillReturnRows(pgxmock.NewRows(deliveryRowColumns).
+			AddRow(int64(5), int64(1), int64(42), domain.EventAssigned, []byte(`{}`),
+				domain.WebhookDeliveryPending, 0, now, &code, &lastErr, nil, now))
+	mock.ExpectQuery(`UPDATE webhook_deliveries d`).WithArgs(int64(6), now).WillReturnError(pgx.ErrNoRows)
+
+	delivery, err := storage.RedeliverWebhookDelivery(ctx, 5, now)
+	require.NoError(t, err)
+	require.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+	require.Equal(t, 500, *delivery.LastStatusCode)
+
+	_, err = storage.RedeliverWebhookDelivery(ctx, 6, now)
+	require.ErrorIs(t, err, domain.ErrDeliveryNotFound)
+}
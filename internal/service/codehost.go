@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/infrastructure/codehost"
+	"pr-reviewer-service_Avito/internal/infrastructure/outbox"
+)
+
+// assignmentMessage — payload записи outbox о событии журнала назначений (см. repository.withOutbox).
+type assignmentMessage struct {
+	PullRequestID string           `json:"pull_request_id"`
+	ReviewerID    *string          `json:"reviewer_id"`
+	EventType     domain.EventType `json:"event_type"`
+	Source        string           `json:"source"`
+	CreatedAt     string           `json:"created_at"`
+	Replaces      *string          `json:"replaces"` // Снятый ревьювер, если назначение — замена
+}
+
+// codeHostSink переносит назначения ревьюверов из outbox на платформу хостинга кода.
+type codeHostSink struct {
+	client codehost.Client
+	logins map[string]string // user_id -> login
+}
+
+// NewCodeHostSink создаёт приёмник outbox, который запрашивает ревью у назначенных ревьюверов
+// и отзывает запросы у снятых, а при замене ревьювера оставляет комментарий в PR. Учитываются только
+// PR из GitHub и пользователи с сопоставленным login (github.users).
+func (s *Service) NewCodeHostSink(client codehost.Client) outbox.Sink {
+	logins := make(map[string]string, len(s.cfg.GitHub.Users))
+	for login, userID := range s.cfg.GitHub.Users {
+		logins[userID] = login
+	}
+	return &codeHostSink{client: client, logins: logins}
+}
+
+func (c *codeHostSink) Name() string {
+	return outbox.SinkGitHub
+}
+
+// Publish выполняет вызовы платформы по порядку и возвращает количество обработанных сообщений.
+// Временная ошибка, оставшаяся после повторов клиента, возвращается, и необработанный остаток будет
+// опубликован заново; постоянная (например, пользователь не является участником репозитория) только
+// логируется, чтобы не останавливать outbox. Комментарий о замене оставляется по назначению нового
+// ревьювера, и при его ошибке повторяется вместе с запросом ревью: повтор запроса идемпотентен.
+func (c *codeHostSink) Publish(ctx context.Context, messages []domain.OutboxMessage) (int, error) {
+	for i, msg := range messages {
+		if msg.AggregateType != domain.OutboxAggregatePullRequest {
+			continue
+		}
+		var event assignmentMessage
+		if err := json.Unmarshal(msg.Payload, &event); err != nil {
+			return i, fmt.Errorf("decode outbox message %d: %w", msg.ID, err)
+		}
+		if event.ReviewerID == nil {
+			continue
+		}
+		repository, number, ok := ParseGitHubPullRequestID(event.PullRequestID)
+		login, mapped := c.logins[*event.ReviewerID]
+		if !ok || !mapped {
+			continue
+		}
+		pr := codehost.PullRequestRef{Repository: repository, Number: number}
+		var err error
+		switch event.EventType {
+		case domain.EventAssigned:
+			err = c.client.RequestReviewers(ctx, pr, []string{login})
+			if oldLogin, replaced := c.replacedLogin(event); err == nil && replaced {
+				err = c.client.Comment(ctx, pr, fmt.Sprintf("Ревьювер @%s заменён на @%s (причина: %s).",
+					oldLogin, login, event.Source))
+			}
+		case domain.EventUnassigned:
+			err = c.client.RemoveReviewRequests(ctx, pr, []string{login})
+		}
+		if err := c.skipPermanent(ctx, msg, err); err != nil {
+			return i, err
+		}
+	}
+	return len(messages), nil
+}
+
+// replacedLogin возвращает login ревьювера, которого заменило назначение event.
+func (c *codeHostSink) replacedLogin(event assignmentMessage) (string, bool) {
+	if event.Replaces == nil {
+		return "", false
+	}
+	login, ok := c.logins[*event.Replaces]
+	return login, ok
+}
+
+// skipPermanent логирует постоянную ошибку платформы и возвращает nil; прочие ошибки возвращает как есть.
+func (c *codeHostSink) skipPermanent(ctx context.Context, msg domain.OutboxMessage, err error) error {
+	var statusErr *codehost.StatusError
+	if errors.As(err, &statusErr) && !statusErr.Temporary() {
+		slog.WarnContext(ctx, "code host rejected outbox message",
+			"outbox_id", msg.ID, "status", statusErr.StatusCode, "error", statusErr.Body)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/infrastructure/codehost"
+	"pr-reviewer-service_Avito/internal/infrastructure/outbox"
+)
+
+type stubCodeHost struct {
+	calls []string
+	errs  map[string]error // вызов -> ошибка
+}
+
+func (s *stubCodeHost) record(call string) error {
+	s.calls = append(s.calls, call)
+	return s.errs[call]
+}
+
+func (s *stubCodeHost) RequestReviewers(ctx context.Context, pr codehost.PullRequestRef, logins []string) error {
+	return s.record(fmt.Sprintf("request %s#%d %v", pr.Repository, pr.Number, logins))
+}
+
+func (s *stubCodeHost) RemoveReviewRequests(ctx context.Context, pr codehost.PullRequestRef, logins []string) error {
+	return s.record(fmt.Sprintf("remove %s#%d %v", pr.Repository, pr.Number, logins))
+}
+
+func (s *stubCodeHost) Comment(ctx context.Context, pr codehost.PullRequestRef, body string) error {
+	return s.record(fmt.Sprintf("comment %s#%d %s", pr.Repository, pr.Number, body))
+}
+
+func assignmentOutbox(id int64, prID, reviewerID string, eventType domain.EventType, source string) domain.OutboxMessage {
+	payload, _ := json.Marshal(map[string]any{
+		"event_id": id, "pull_request_id": prID, "reviewer_id": reviewerID, "event_type": eventType,
+		"source": source, "created_at": "2025-01-10T12:00:00+00:00",
+	})
+	return domain.OutboxMessage{ID: id, AggregateType: domain.OutboxAggregatePullRequest, AggregateID: prID,
+		EventType: string(eventType), Payload: payload}
+}
+
+// replacementOutbox — назначение newReviewer взамен oldReviewer, как его записывает repository.ReplaceReviewer.
+func replacementOutbox(id int64, prID, oldReviewer, newReviewer, source string) domain.OutboxMessage {
+	msg := assignmentOutbox(id, prID, newReviewer, domain.EventAssigned, source)
+	var payload map[string]any
+	_ = json.Unmarshal(msg.Payload, &payload)
+	payload["replaces"] = oldReviewer
+	msg.Payload, _ = json.Marshal(payload)
+	return msg
+}
+
+func TestParseGitHubPullRequestID(t *testing.T) {
+	t.Parallel()
+	repository, number, ok := ParseGitHubPullRequestID(GitHubPullRequestID("acme/api", 12))
+	require.True(t, ok)
+	require.Equal(t, "acme/api", repository)
+	require.Equal(t, 12, number)
+
+	for _, prID := range []string{"pr-1", "api#12", "acme/api#", "acme/api#x", "#1"} {
+		_, _, ok := ParseGitHubPullRequestID(prID)
+		require.False(t, ok, prID)
+	}
+}
+
+func TestService_CodeHostSinkWritesAssignments(t *testing.T) {
+	t.Parallel()
+	client := &stubCodeHost{}
+	svc := New(&fakeRepo{}, githubTestConfig(), stubManager{}, stubRandomizer{})
+	sink := svc.NewCodeHostSink(client)
+	require.Equal(t, "github", sink.Name())
+
+	done, err := sink.Publish(context.Background(), []domain.OutboxMessage{
+		{ID: 1, AggregateType: domain.OutboxAggregateTeam, AggregateID: "backend", Payload: []byte(`{}`)},
+		assignmentOutbox(2, "acme/api#12", "u1", domain.EventAssigned, "AUTO"),
+		// PR создан не из GitHub
+		assignmentOutbox(3, "pr-1", "u1", domain.EventAssigned, "AUTO"),
+		// Пользователь без login
+		assignmentOutbox(4, "acme/api#12", "u9", domain.EventAssigned, "AUTO"),
+		assignmentOutbox(5, "acme/api#12", "u1", domain.EventUnassigned, "MANUAL_REASSIGN"),
+		replacementOutbox(6, "acme/api#12", "u1", "u2", "MANUAL_REASSIGN"),
+		// Снятие и добавление с тем же источником и временем без replaces — не замена
+		assignmentOutbox(7, "acme/api#12", "u2", domain.EventUnassigned, "MANUAL_REMOVE"),
+		assignmentOutbox(8, "acme/api#12", "u1", domain.EventAssigned, "MANUAL_REMOVE"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 8, done)
+	require.Equal(t, []string{
+		"request acme/api#12 [alice]",
+		"remove acme/api#12 [alice]",
+		"request acme/api#12 [bob]",
+		"comment acme/api#12 Ревьювер @alice заменён на @bob (причина: MANUAL_REASSIGN).",
+		"remove acme/api#12 [bob]",
+		"request acme/api#12 [alice]",
+	}, client.calls)
+}
+
+func TestService_CodeHostSinkReplacementAcrossBatches(t *testing.T) {
+	t.Parallel()
+
+	// Снятие и назначение замены попадают в разные пачки relay
+	pending := []domain.OutboxMessage{
+		assignmentOutbox(1, "acme/api#12", "u1", domain.EventUnassigned, "SLA_REASSIGN"),
+		replacementOutbox(2, "acme/api#12", "u1", "u2", "SLA_REASSIGN"),
+	}
+	fake := &fakeRepo{
+		listUnpublishedOutboxFn: func(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+			return pending[:min(limit, len(pending))], nil
+		},
+		markOutboxSinkPublishedFn: func(ctx context.Context, ids []int64, sink string, sinks []string, publishedAt time.Time) error {
+			pending = slices.DeleteFunc(pending, func(msg domain.OutboxMessage) bool { return slices.Contains(ids, msg.ID) })
+			return nil
+		},
+	}
+	cfg := githubTestConfig()
+	cfg.Outbox.BatchSize = 1
+	svc := New(fake, cfg, stubManager{}, stubRandomizer{})
+	client := &stubCodeHost{}
+	sinks := []outbox.Sink{svc.NewCodeHostSink(client)}
+
+	for range 2 {
+		published, err := svc.PublishOutbox(context.Background(), sinks, time.Now())
+		require.NoError(t, err)
+		require.Equal(t, 1, published)
+	}
+	require.Empty(t, pending)
+	require.Equal(t, []string{
+		"remove acme/api#12 [alice]",
+		"request acme/api#12 [bob]",
+		"comment acme/api#12 Ревьювер @alice заменён на @bob (причина: SLA_REASSIGN).",
+	}, client.calls)
+}
+
+func TestService_CodeHostSinkErrors(t *testing.T) {
+	t.Parallel()
+	svc := New(&fakeRepo{}, githubTestConfig(), stubManager{}, stubRandomizer{})
+	messages := []domain.OutboxMessage{
+		assignmentOutbox(1, "acme/api#12", "u1", domain.EventAssigned, "AUTO"),
+		assignmentOutbox(2, "acme/api#12", "u2", domain.EventAssigned, "AUTO"),
+	}
+
+	// Постоянная ошибка пропускается, чтобы не останавливать outbox
+	rejected := &stubCodeHost{errs: map[string]error{
+		"request acme/api#12 [alice]": &codehost.StatusError{StatusCode: http.StatusUnprocessableEntity},
+	}}
+	done, err := svc.NewCodeHostSink(rejected).Publish(context.Background(), messages)
+	require.NoError(t, err)
+	require.Equal(t, 2, done)
+	require.Len(t, rejected.calls, 2)
+
+	// Временная ошибка возвращается, и необработанный остаток будет опубликован повторно
+	unavailable := &stubCodeHost{errs: map[string]error{
+		"request acme/api#12 [alice]": &codehost.StatusError{StatusCode: http.StatusServiceUnavailable},
+	}}
+	done, err = svc.NewCodeHostSink(unavailable).Publish(context.Background(), messages)
+	require.Error(t, err)
+	require.Equal(t, 0, done)
+	require.Len(t, unavailable.calls, 1)
+
+	// Если не удался комментарий о замене, назначение замены публикуется повторно
+	replacement := []domain.OutboxMessage{
+		assignmentOutbox(1, "acme/api#12", "u1", domain.EventAssigned, "AUTO"),
+		assignmentOutbox(2, "acme/api#12", "u1", domain.EventUnassigned, "MANUAL_REASSIGN"),
+		replacementOutbox(3, "acme/api#12", "u1", "u2", "MANUAL_REASSIGN"),
+	}
+	commentFailed := &stubCodeHost{errs: map[string]error{
+		"comment acme/api#12 Ревьювер @alice заменён на @bob (причина: MANUAL_REASSIGN).": &codehost.StatusError{
+			StatusCode: http.StatusBadGateway},
+	}}
+	done, err = svc.NewCodeHostSink(commentFailed).Publish(context.Background(), replacement)
+	require.Error(t, err)
+	require.Equal(t, 2, done)
+}
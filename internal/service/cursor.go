@@ -0,0 +1,37 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// errMalformedCursor возвращается для курсора, который не выдавал этот список.
+var errMalformedCursor = errors.New("malformed cursor")
+
+// encodeCursor кодирует позицию страницы в непрозрачный курсор: JSON в base64url без выравнивания.
+// Все списки с курсорной пагинацией используют это кодирование.
+func encodeCursor(position any) (string, error) {
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", fmt.Errorf("encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor разбирает курсор encodeCursor в position. Неизвестные поля отклоняются,
+// поэтому курсор одного списка не принимается другим.
+func decodeCursor(cursor string, position any) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return errMalformedCursor
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(position); err != nil {
+		return errMalformedCursor
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+// DefaultEventsLimit — размер страницы журнала назначений, если limit не задан.
+const DefaultEventsLimit = 100
+
+// ListEvents возвращает страницу журнала назначений в хронологическом порядке.
+// Следующая страница запрашивается с курсором NextCursor (см. DecodeEventCursor).
+func (s *Service) ListEvents(ctx context.Context, filter domain.EventFilter) (domain.EventPage, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidateEventFilter(filter); err != nil {
+		return domain.EventPage{}, err
+	}
+	limit := filter.Limit
+	if limit == 0 {
+		limit = DefaultEventsLimit
+	}
+	// Лишнее событие показывает, что за страницей есть продолжение
+	filter.Limit = limit + 1
+	events, err := s.repo.ListEvents(ctx, filter)
+	if err != nil {
+		return domain.EventPage{}, err
+	}
+	page := domain.EventPage{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		if page.NextCursor, err = EncodeEventCursor(page.Events[limit-1].ID); err != nil {
+			return domain.EventPage{}, err
+		}
+	}
+	return page, nil
+}
+
+// ExportEvents передаёт в fn все события журнала назначений, подходящие под фильтр, начиная с курсора.
+// Limit фильтра не учитывается.
+func (s *Service) ExportEvents(ctx context.Context, filter domain.EventFilter, fn func(domain.AssignmentEvent) error) error {
+	ctx, cancel := s.longOperationContext(ctx)
+	defer cancel()
+
+	filter.Limit = 0
+	if err := ValidateEventFilter(filter); err != nil {
+		return err
+	}
+	return s.repo.StreamEvents(ctx, filter, fn)
+}
+
+// eventCursor — содержимое курсора журнала назначений.
+type eventCursor struct {
+	ID int64 `json:"id"`
+}
+
+// EncodeEventCursor возвращает непрозрачный курсор страницы, следующей за событием eventID.
+func EncodeEventCursor(eventID int64) (string, error) {
+	return encodeCursor(eventCursor{ID: eventID})
+}
+
+// DecodeEventCursor возвращает ID события, после которого начинается страница.
+func DecodeEventCursor(cursor string) (int64, error) {
+	var raw eventCursor
+	if err := decodeCursor(cursor, &raw); err != nil || raw.ID <= 0 {
+		return 0, errMalformedCursor
+	}
+	return raw.ID, nil
+}
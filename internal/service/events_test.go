@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestService_ListEventsPaginates(t *testing.T) {
+	t.Parallel()
+	fake := &fakeRepo{
+		listEventsFn: func(ctx context.Context, filter domain.EventFilter) ([]domain.AssignmentEvent, error) {
+			require.Equal(t, 3, filter.Limit)
+			require.Equal(t, int64(10), filter.AfterID)
+			return []domain.AssignmentEvent{{ID: 11}, {ID: 12}, {ID: 13}}, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	page, err := svc.ListEvents(context.Background(), domain.EventFilter{AfterID: 10, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []domain.AssignmentEvent{{ID: 11}, {ID: 12}}, page.Events)
+
+	afterID, err := DecodeEventCursor(page.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, int64(12), afterID)
+}
+
+func TestService_ListEventsLastPage(t *testing.T) {
+	t.Parallel()
+	fake := &fakeRepo{
+		listEventsFn: func(ctx context.Context, filter domain.EventFilter) ([]domain.AssignmentEvent, error) {
+			require.Equal(t, DefaultEventsLimit+1, filter.Limit)
+			return []domain.AssignmentEvent{{ID: 1}}, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	page, err := svc.ListEvents(context.Background(), domain.EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, page.Events, 1)
+	require.Empty(t, page.NextCursor)
+}
+
+func TestService_ListEventsValidates(t *testing.T) {
+	t.Parallel()
+	svc := New(&fakeRepo{}, testConfig(), stubManager{}, stubRandomizer{})
+	now := time.Now()
+
+	_, err := svc.ListEvents(context.Background(), domain.EventFilter{EventType: "PUSHED"})
+	require.Error(t, err)
+	_, err = svc.ListEvents(context.Background(), domain.EventFilter{From: &now, To: &now})
+	require.Error(t, err)
+	_, err = svc.ListEvents(context.Background(), domain.EventFilter{Limit: MaxEventsLimit + 1})
+	require.Error(t, err)
+}
+
+func TestService_ExportEventsIgnoresLimit(t *testing.T) {
+	t.Parallel()
+	fake := &fakeRepo{
+		streamEventsFn: func(ctx context.Context, filter domain.EventFilter, fn func(domain.AssignmentEvent) error) error {
+			require.Zero(t, filter.Limit)
+			require.Equal(t, "backend", filter.TeamName)
+			return fn(domain.AssignmentEvent{ID: 1})
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	var exported []int64
+	err := svc.ExportEvents(context.Background(), domain.EventFilter{TeamName: "backend", Limit: 5},
+		func(event domain.AssignmentEvent) error {
+			exported = append(exported, event.ID)
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, exported)
+}
+
+func TestDecodeEventCursorRejectsGarbage(t *testing.T) {
+	t.Parallel()
+	zero, err := EncodeEventCursor(0)
+	require.NoError(t, err)
+	for _, cursor := range []string{"***", zero, "YWJj"} {
+		_, err := DecodeEventCursor(cursor)
+		require.Error(t, err, cursor)
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+// FairnessReport возвращает по каждой команде, насколько равномерно назначения за интервал
+// распределены между её активными участниками.
+func (s *Service) FairnessReport(ctx context.Context, filter domain.FairnessFilter) (domain.FairnessReport, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidateFairnessFilter(filter); err != nil {
+		return domain.FairnessReport{}, err
+	}
+	members, err := s.repo.FetchMemberAssignments(ctx, filter)
+	if err != nil {
+		return domain.FairnessReport{}, err
+	}
+	report := domain.FairnessReport{Teams: []domain.TeamFairness{}}
+	// Участники приходят сгруппированными по команде
+	for start := 0; start < len(members); {
+		end := start
+		for end < len(members) && members[end].TeamName == members[start].TeamName {
+			end++
+		}
+		report.Teams = append(report.Teams, teamFairness(members[start:end]))
+		start = end
+	}
+	return report, nil
+}
+
+// teamFairness считает показатели равномерности для участников одной команды.
+func teamFairness(members []domain.MemberAssignments) domain.TeamFairness {
+	fairness := domain.TeamFairness{TeamName: members[0].TeamName, ActiveMembers: len(members)}
+	counts := make([]int64, 0, len(members))
+	for _, member := range members {
+		fairness.TotalAssignments += member.Assigned
+		counts = append(counts, member.Assigned)
+	}
+	fairness.FairShare = float64(fairness.TotalAssignments) / float64(len(members))
+	fairness.Gini = gini(counts)
+
+	var above, below *domain.MemberShare
+	for _, member := range members {
+		share := &domain.MemberShare{
+			UserID:   member.UserID,
+			Username: member.Username,
+			Assigned: member.Assigned,
+			Delta:    float64(member.Assigned) - fairness.FairShare,
+		}
+		if share.Delta > 0 && (above == nil || share.Delta > above.Delta) {
+			above = share
+		}
+		if share.Delta < 0 && (below == nil || share.Delta < below.Delta) {
+			below = share
+		}
+	}
+	fairness.MostAbove, fairness.MostBelow = above, below
+
+	minCount, maxCount := counts[0], counts[0]
+	for _, count := range counts {
+		minCount, maxCount = min(minCount, count), max(maxCount, count)
+	}
+	if minCount > 0 {
+		ratio := float64(maxCount) / float64(minCount)
+		fairness.MaxMinRatio = &ratio
+	}
+	return fairness
+}
+
+// gini возвращает коэффициент Джини неотрицательных значений: 0 — все равны,
+// (n-1)/n — всё у одного. Для пустой или нулевой выборки возвращает 0.
+func gini(values []int64) float64 {
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var total, weighted float64
+	for i, value := range sorted {
+		total += float64(value)
+		weighted += float64(i+1) * float64(value)
+	}
+	if total == 0 {
+		return 0
+	}
+	n := float64(len(sorted))
+	return 2*weighted/(n*total) - (n+1)/n
+}
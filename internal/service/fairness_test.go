@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestService_FairnessReport(t *testing.T) {
+	t.Parallel()
+	fake := &fakeRepo{
+		fetchMemberAssignmentsFn: func(ctx context.Context, filter domain.FairnessFilter) ([]domain.MemberAssignments, error) {
+			return []domain.MemberAssignments{
+				{TeamName: "backend", UserID: "u1", Username: "Alice", Assigned: 6},
+				{TeamName: "backend", UserID: "u2", Username: "Bob", Assigned: 2},
+				{TeamName: "backend", UserID: "u3", Username: "Carol", Assigned: 4},
+				{TeamName: "frontend", UserID: "u4", Username: "Dave", Assigned: 3},
+				{TeamName: "frontend", UserID: "u5", Username: "Eve", Assigned: 0},
+			}, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	report, err := svc.FairnessReport(context.Background(), domain.FairnessFilter{})
+	require.NoError(t, err)
+	require.Len(t, report.Teams, 2)
+
+	backend := report.Teams[0]
+	require.Equal(t, "backend", backend.TeamName)
+	require.Equal(t, 3, backend.ActiveMembers)
+	require.Equal(t, int64(12), backend.TotalAssignments)
+	require.InDelta(t, 4.0, backend.FairShare, 1e-9)
+	require.InDelta(t, 2.0/9.0, backend.Gini, 1e-9)
+	require.NotNil(t, backend.MaxMinRatio)
+	require.InDelta(t, 3.0, *backend.MaxMinRatio, 1e-9)
+	require.Equal(t, &domain.MemberShare{UserID: "u1", Username: "Alice", Assigned: 6, Delta: 2}, backend.MostAbove)
+	require.Equal(t, &domain.MemberShare{UserID: "u2", Username: "Bob", Assigned: 2, Delta: -2}, backend.MostBelow)
+
+	frontend := report.Teams[1]
+	require.InDelta(t, 0.5, frontend.Gini, 1e-9)
+	require.Nil(t, frontend.MaxMinRatio)
+}
+
+func TestService_FairnessReportEvenTeam(t *testing.T) {
+	t.Parallel()
+	fake := &fakeRepo{
+		fetchMemberAssignmentsFn: func(ctx context.Context, filter domain.FairnessFilter) ([]domain.MemberAssignments, error) {
+			require.Equal(t, "backend", filter.TeamName)
+			return []domain.MemberAssignments{
+				{TeamName: "backend", UserID: "u1", Assigned: 3},
+				{TeamName: "backend", UserID: "u2", Assigned: 3},
+			}, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	report, err := svc.FairnessReport(context.Background(), domain.FairnessFilter{TeamName: "backend"})
+	require.NoError(t, err)
+	require.Len(t, report.Teams, 1)
+	require.Zero(t, report.Teams[0].Gini)
+	require.Nil(t, report.Teams[0].MostAbove)
+	require.Nil(t, report.Teams[0].MostBelow)
+	require.InDelta(t, 1.0, *report.Teams[0].MaxMinRatio, 1e-9)
+}
+
+func TestService_FairnessReportValidates(t *testing.T) {
+	t.Parallel()
+	svc := New(&fakeRepo{}, testConfig(), stubManager{}, stubRandomizer{})
+	now := time.Now()
+
+	_, err := svc.FairnessReport(context.Background(), domain.FairnessFilter{From: &now, To: &now})
+	require.Error(t, err)
+}
+
+func TestGini(t *testing.T) {
+	t.Parallel()
+	require.Zero(t, gini(nil))
+	require.Zero(t, gini([]int64{0, 0}))
+	require.Zero(t, gini([]int64{5, 5, 5}))
+	require.InDelta(t, 0.75, gini([]int64{0, 0, 0, 8}), 1e-9)
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/infrastructure/webhook"
+	"pr-reviewer-service_Avito/internal/metrics"
+)
+
+// События и действия GitHub, которые обрабатывает сервис.
+const (
+	GitHubEventPullRequest       = "pull_request"
+	GitHubEventPullRequestReview = "pull_request_review"
+
+	GitHubActionOpened         = "opened"
+	GitHubActionReopened       = "reopened"
+	GitHubActionClosed         = "closed"
+	GitHubActionReadyForReview = "ready_for_review"
+	GitHubActionSubmitted      = "submitted"
+)
+
+// GitHubOutcome — результат обработки входящего webhook'а GitHub.
+type GitHubOutcome string
+
+const (
+	GitHubOutcomeProcessed GitHubOutcome = "PROCESSED" // Событие применено
+	GitHubOutcomeDuplicate GitHubOutcome = "DUPLICATE" // Доставка уже обрабатывалась
+	GitHubOutcomeIgnored   GitHubOutcome = "IGNORED"   // Событие не относится к сервису
+)
+
+// GitHubEvent — webhook GitHub о pull request'е, сведённый к полям, которые использует сервис.
+type GitHubEvent struct {
+	DeliveryID    string // Заголовок X-GitHub-Delivery
+	Event         string // Заголовок X-GitHub-Event
+	Action        string
+	Repository    string // owner/repo
+	Number        int
+	Title         string
+	AuthorLogin   string
+	Draft         bool
+	Merged        bool
+	ReviewerLogin string
+	ReviewState   string // approved, changes_requested или commented
+}
+
+// GitHubPullRequestID возвращает ID PR в сервисе для PR number репозитория repository: "owner/repo#number".
+func GitHubPullRequestID(repository string, number int) string {
+	return repository + "#" + strconv.Itoa(number)
+}
+
+// ParseGitHubPullRequestID разбирает ID PR, созданного по webhook'у GitHub. ok=false для PR,
+// созданных не из GitHub.
+func ParseGitHubPullRequestID(prID string) (repository string, number int, ok bool) {
+	i := strings.LastIndexByte(prID, '#')
+	if i <= 0 || !strings.Contains(prID[:i], "/") {
+		return "", 0, false
+	}
+	number, err := strconv.Atoi(prID[i+1:])
+	if err != nil || number <= 0 {
+		return "", 0, false
+	}
+	return prID[:i], number, true
+}
+
+// VerifyGitHubSignature проверяет заголовок X-Hub-Signature-256 по секрету webhook'а.
+// Без настроенного секрета проверка не проходит.
+func (s *Service) VerifyGitHubSignature(body []byte, signature string) bool {
+	secret := s.cfg.GitHub.WebhookSecret
+	if secret == "" {
+		return false
+	}
+	return hmac.Equal([]byte(webhook.Sign(secret, body)), []byte(signature))
+}
+
+// HandleGitHubEvent применяет webhook GitHub: opened создаёт PR, reopened, closed и ready_for_review
+// меняют его статус, а submitted сохраняет решение ревьювера. Логины GitHub переводятся в user_id
+// по конфигурации; события пользователей без сопоставления, события о PR, которых нет в сервисе,
+// и прочие события игнорируются. Повторная доставка с тем же DeliveryID не применяется второй раз.
+func (s *Service) HandleGitHubEvent(ctx context.Context, event GitHubEvent) (GitHubOutcome, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	apply := s.githubHandler(event)
+	if apply == nil {
+		slog.DebugContext(ctx, "github event ignored",
+			"event", event.Event, "action", event.Action, "delivery_id", event.DeliveryID)
+		return GitHubOutcomeIgnored, nil
+	}
+	// Регистрация доставки и её применение фиксируются вместе: при ошибке откатываются оба,
+	// и повтор доставки от GitHub обрабатывается заново
+	var claimed, unknown bool
+	err := s.trMgr.Do(ctx, func(ctx context.Context) error {
+		var err error
+		claimed, err = s.repo.ClaimGitHubDelivery(ctx, event.DeliveryID, event.Event, event.Action)
+		if err != nil || !claimed {
+			return err
+		}
+		err = apply(ctx)
+		// PR открыт в GitHub до подключения интеграции: его события не относятся к сервису,
+		// а доставка фиксируется, чтобы GitHub не повторял её
+		unknown = errors.Is(err, domain.ErrPRNotFound)
+		if unknown {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case !claimed:
+		return GitHubOutcomeDuplicate, nil
+	case unknown:
+		slog.DebugContext(ctx, "github event for unknown pull request ignored",
+			"event", event.Event, "action", event.Action, "delivery_id", event.DeliveryID)
+		return GitHubOutcomeIgnored, nil
+	}
+	return GitHubOutcomeProcessed, nil
+}
+
+// githubHandler возвращает операцию сервиса для события или nil, если событие игнорируется.
+func (s *Service) githubHandler(event GitHubEvent) func(context.Context) error {
+	prID := GitHubPullRequestID(event.Repository, event.Number)
+	switch {
+	case event.Event == GitHubEventPullRequest && event.Action == GitHubActionOpened:
+		authorID, ok := s.cfg.GitHub.Users[event.AuthorLogin]
+		if !ok {
+			return nil
+		}
+		return func(ctx context.Context) error {
+			_, err := s.CreatePullRequest(ctx, prID, event.Title, authorID, event.Draft)
+			// PR мог быть создан через API до подключения интеграции
+			if errors.Is(err, domain.ErrPRExists) {
+				return nil
+			}
+			return err
+		}
+	case event.Event == GitHubEventPullRequest && event.Action == GitHubActionReopened:
+		return func(ctx context.Context) error {
+			_, err := s.ReopenPullRequest(ctx, prID)
+			return err
+		}
+	case event.Event == GitHubEventPullRequest && event.Action == GitHubActionClosed && event.Merged:
+		return func(ctx context.Context) error {
+			_, err := s.syncMergedPullRequest(ctx, prID)
+			return err
+		}
+	case event.Event == GitHubEventPullRequest && event.Action == GitHubActionClosed:
+		return func(ctx context.Context) error {
+			_, err := s.ClosePullRequest(ctx, prID)
+			return err
+		}
+	case event.Event == GitHubEventPullRequest && event.Action == GitHubActionReadyForReview:
+		return func(ctx context.Context) error {
+			_, err := s.MarkReadyForReview(ctx, prID)
+			return err
+		}
+	case event.Event == GitHubEventPullRequestReview && event.Action == GitHubActionSubmitted:
+		reviewerID, ok := s.cfg.GitHub.Users[event.ReviewerLogin]
+		decision := domain.ReviewDecision(strings.ToUpper(event.ReviewState))
+		if !ok || ValidateReviewDecision(decision) != nil {
+			return nil
+		}
+		return func(ctx context.Context) error {
+			_, err := s.SubmitReview(ctx, prID, reviewerID, decision)
+			return err
+		}
+	}
+	return nil
+}
+
+// syncMergedPullRequest отмечает PR смерженным по событию GitHub. Merge уже произошёл в GitHub,
+// поэтому политика merge команды не проверяется.
+func (s *Service) syncMergedPullRequest(ctx context.Context, prID string) (domain.PullRequest, error) {
+	pr, err := s.repo.GetPullRequest(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	if pr.Status == domain.PRStatusMerged {
+		return pr, nil
+	}
+	merged, err := s.repo.UpdatePRStatus(ctx, prID, domain.PRStatusMerged)
+	if err == nil {
+		metrics.IncPullRequestStatusChanges(domain.PRStatusMerged)
+		s.observeMerge(ctx, merged)
+	}
+	return merged, err
+}
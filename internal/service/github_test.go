@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/config"
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/infrastructure/webhook"
+)
+
+func githubTestConfig() config.Config {
+	cfg := testConfig()
+	cfg.GitHub = config.GitHubConfig{
+		WebhookSecret: "It's a Secret to Everybody",
+		Users:         map[string]string{"alice": "u1", "bob": "u2"},
+	}
+	return cfg
+}
+
+func TestService_VerifyGitHubSignature(t *testing.T) {
+	t.Parallel()
+	body := []byte("Hello, World!")
+
+	svc := New(&fakeRepo{}, githubTestConfig(), stubManager{}, stubRandomizer{})
+	require.True(t, svc.VerifyGitHubSignature(body, webhook.Sign("It's a Secret to Everybody", body)))
+	require.False(t, svc.VerifyGitHubSignature(body, webhook.Sign("other", body)))
+	require.False(t, svc.VerifyGitHubSignature(body, ""))
+
+	// Без секрета интеграция выключена
+	unconfigured := New(&fakeRepo{}, testConfig(), stubManager{}, stubRandomizer{})
+	require.False(t, unconfigured.VerifyGitHubSignature(body, webhook.Sign("", body)))
+}
+
+func TestService_HandleGitHubEventOpenedCreatesPullRequest(t *testing.T) {
+	t.Parallel()
+
+	var created domain.PullRequest
+	fake := &fakeRepo{
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			return domain.User{ID: userID, TeamName: "backend", IsActive: true}, nil
+		},
+		createPullRequestFn: func(ctx context.Context, pr domain.PullRequest, reviewers []string) (domain.PullRequest, error) {
+			created = pr
+			return pr, nil
+		},
+	}
+	svc := New(fake, githubTestConfig(), stubManager{}, stubRandomizer{})
+
+	outcome, err := svc.HandleGitHubEvent(context.Background(), GitHubEvent{
+		DeliveryID: "d-1", Event: GitHubEventPullRequest, Action: GitHubActionOpened,
+		Repository: "acme/api", Number: 12, Title: "Fix", AuthorLogin: "alice", Draft: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, GitHubOutcomeProcessed, outcome)
+	require.Equal(t, "acme/api#12", created.ID)
+	require.Equal(t, "u1", created.AuthorID)
+	require.Equal(t, domain.PRStatusDraft, created.Status)
+}
+
+func TestService_HandleGitHubEventDuplicateDelivery(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeRepo{
+		claimGitHubDeliveryFn: func(ctx context.Context, deliveryID, event, action string) (bool, error) {
+			require.Equal(t, "d-1", deliveryID)
+			return false, nil
+		},
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			t.Fatal("duplicate delivery must not be applied")
+			return domain.PullRequest{}, nil
+		},
+	}
+	svc := New(fake, githubTestConfig(), stubManager{}, stubRandomizer{})
+
+	outcome, err := svc.HandleGitHubEvent(context.Background(), GitHubEvent{
+		DeliveryID: "d-1", Event: GitHubEventPullRequest, Action: GitHubActionClosed, Repository: "acme/api", Number: 12,
+	})
+	require.NoError(t, err)
+	require.Equal(t, GitHubOutcomeDuplicate, outcome)
+}
+
+func TestService_HandleGitHubEventMergedSkipsPolicy(t *testing.T) {
+	t.Parallel()
+
+	var status domain.PRStatus
+	fake := &fakeRepo{
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, AuthorID: "u1", Status: domain.PRStatusOpen}, nil
+		},
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			t.Fatal("merge policy must not be checked for a merge made on GitHub")
+			return domain.User{}, nil
+		},
+		updatePRStatusFn: func(ctx context.Context, prID string, s domain.PRStatus) (domain.PullRequest, error) {
+			status = s
+			return domain.PullRequest{ID: prID, Status: s}, nil
+		},
+	}
+	svc := New(fake, githubTestConfig(), stubManager{}, stubRandomizer{})
+
+	outcome, err := svc.HandleGitHubEvent(context.Background(), GitHubEvent{
+		DeliveryID: "d-2", Event: GitHubEventPullRequest, Action: GitHubActionClosed,
+		Repository: "acme/api", Number: 12, Merged: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, GitHubOutcomeProcessed, outcome)
+	require.Equal(t, domain.PRStatusMerged, status)
+}
+
+func TestService_HandleGitHubEventReviewSubmitted(t *testing.T) {
+	t.Parallel()
+
+	var decision domain.ReviewDecision
+	fake := &fakeRepo{
+		submitReviewFn: func(ctx context.Context, prID, reviewerID string, d domain.ReviewDecision) (domain.PullRequest, error) {
+			require.Equal(t, "acme/api#12", prID)
+			require.Equal(t, "u2", reviewerID)
+			decision = d
+			return domain.PullRequest{ID: prID}, nil
+		},
+	}
+	svc := New(fake, githubTestConfig(), stubManager{}, stubRandomizer{})
+
+	outcome, err := svc.HandleGitHubEvent(context.Background(), GitHubEvent{
+		DeliveryID: "d-3", Event: GitHubEventPullRequestReview, Action: GitHubActionSubmitted,
+		Repository: "acme/api", Number: 12, ReviewerLogin: "bob", ReviewState: "changes_requested",
+	})
+	require.NoError(t, err)
+	require.Equal(t, GitHubOutcomeProcessed, outcome)
+	require.Equal(t, domain.ReviewDecisionChangesRequested, decision)
+}
+
+func TestService_HandleGitHubEventIgnoresUnmappedAndUnknown(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeRepo{
+		claimGitHubDeliveryFn: func(ctx context.Context, deliveryID, event, action string) (bool, error) {
+			t.Fatal("ignored events must not be recorded")
+			return false, nil
+		},
+	}
+	svc := New(fake, githubTestConfig(), stubManager{}, stubRandomizer{})
+
+	for _, event := range []GitHubEvent{
+		{DeliveryID: "d-4", Event: GitHubEventPullRequest, Action: GitHubActionOpened, AuthorLogin: "mallory"},
+		{DeliveryID: "d-5", Event: GitHubEventPullRequestReview, Action: GitHubActionSubmitted, ReviewerLogin: "bob", ReviewState: "dismissed"},
+		{DeliveryID: "d-6", Event: GitHubEventPullRequest, Action: "labeled"},
+		{DeliveryID: "d-7", Event: "ping"},
+	} {
+		outcome, err := svc.HandleGitHubEvent(context.Background(), event)
+		require.NoError(t, err)
+		require.Equal(t, GitHubOutcomeIgnored, outcome)
+	}
+}
+
+// txMarkerManager помечает контекст транзакции, чтобы проверить, какие вызовы выполняются внутри неё.
+type txMarkerManager struct{ stubManager }
+
+type txMarkerKey struct{}
+
+func (txMarkerManager) Do(ctx context.Context, fn func(context.Context) error) error {
+	return fn(context.WithValue(ctx, txMarkerKey{}, true))
+}
+
+func TestService_HandleGitHubEventClaimsAndAppliesInOneTransaction(t *testing.T) {
+	t.Parallel()
+
+	errDB := errors.New("db down")
+	fake := &fakeRepo{
+		claimGitHubDeliveryFn: func(ctx context.Context, deliveryID, event, action string) (bool, error) {
+			require.Equal(t, true, ctx.Value(txMarkerKey{}), "claim must run in the transaction")
+			return true, nil
+		},
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			require.Equal(t, true, ctx.Value(txMarkerKey{}), "apply must run in the claim transaction")
+			return domain.PullRequest{}, errDB
+		},
+	}
+	svc := New(fake, githubTestConfig(), txMarkerManager{}, stubRandomizer{})
+
+	// Ошибка применения откатывает и регистрацию доставки
+	_, err := svc.HandleGitHubEvent(context.Background(), GitHubEvent{
+		DeliveryID: "d-8", Event: GitHubEventPullRequest, Action: GitHubActionReopened, Repository: "acme/api", Number: 1,
+	})
+	require.ErrorIs(t, err, errDB)
+}
+
+func TestService_HandleGitHubEventIgnoresUnknownPullRequest(t *testing.T) {
+	t.Parallel()
+
+	var claims int
+	fake := &fakeRepo{
+		claimGitHubDeliveryFn: func(ctx context.Context, deliveryID, event, action string) (bool, error) {
+			claims++
+			return true, nil
+		},
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{}, domain.ErrPRNotFound
+		},
+	}
+	svc := New(fake, githubTestConfig(), stubManager{}, stubRandomizer{})
+
+	// PR, открытый до подключения интеграции, не даёт GitHub ответ 404
+	for _, event := range []GitHubEvent{
+		{DeliveryID: "d-9", Event: GitHubEventPullRequest, Action: GitHubActionClosed, Repository: "acme/api", Number: 7},
+		{DeliveryID: "d-10", Event: GitHubEventPullRequest, Action: GitHubActionClosed, Repository: "acme/api", Number: 7, Merged: true},
+		{DeliveryID: "d-11", Event: GitHubEventPullRequest, Action: GitHubActionReopened, Repository: "acme/api", Number: 7},
+		{DeliveryID: "d-12", Event: GitHubEventPullRequest, Action: GitHubActionReadyForReview, Repository: "acme/api", Number: 7},
+	} {
+		outcome, err := svc.HandleGitHubEvent(context.Background(), event)
+		require.NoError(t, err, event.Action)
+		require.Equal(t, GitHubOutcomeIgnored, outcome, event.Action)
+	}
+	require.Equal(t, 4, claims)
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/metrics"
+)
+
+// LatencyStats возвращает перцентили времени до merge и времени от назначения до первого решения ревьювера.
+func (s *Service) LatencyStats(ctx context.Context, filter domain.LatencyFilter) (domain.LatencyStats, error) {
+	ctx, cancel := s.longOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidateLatencyFilter(filter); err != nil {
+		return domain.LatencyStats{}, err
+	}
+	return s.repo.FetchLatencyStats(ctx, filter)
+}
+
+// observeDecisionLatency записывает в метрики время от назначения ревьювера до решения,
+// если это его первое решение после назначения.
+func (s *Service) observeDecisionLatency(ctx context.Context, prID, reviewerID string) {
+	events, err := s.repo.ListEvents(ctx, domain.EventFilter{PullRequestID: prID, ReviewerID: reviewerID})
+	if err != nil {
+		slog.WarnContext(ctx, "failed to observe review decision latency", "pull_request_id", prID, "error", err)
+		return
+	}
+	if latency, ok := firstDecisionLatency(events); ok {
+		metrics.ObserveReviewDecisionLatency(events[0].TeamName, latency)
+	}
+}
+
+// firstDecisionLatency возвращает время от последнего назначения ревьювера до решения, если последнее событие —
+// первое решение после этого назначения. events — события одного ревьювера по одному PR в порядке журнала.
+func firstDecisionLatency(events []domain.AssignmentEvent) (time.Duration, bool) {
+	var assigned, lastIsFirstDecision bool
+	var assignedAt, decidedAt time.Time
+	decisions := 0
+	for _, event := range events {
+		lastIsFirstDecision = false
+		switch event.EventType {
+		case domain.EventAssigned:
+			assigned, assignedAt, decisions = true, event.CreatedAt, 0
+		case domain.EventUnassigned:
+			assigned = false
+		case domain.EventApproved, domain.EventChangesRequested, domain.EventCommented:
+			decisions++
+			decidedAt = event.CreatedAt
+			lastIsFirstDecision = assigned && decisions == 1
+		}
+	}
+	if !lastIsFirstDecision {
+		return 0, false
+	}
+	return decidedAt.Sub(assignedAt), true
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestService_LatencyStats(t *testing.T) {
+	t.Parallel()
+	stats := domain.LatencyStats{TimeToMerge: domain.LatencyBreakdown{
+		PerTeam: []domain.TeamLatency{{TeamName: "backend", LatencyPercentiles: domain.LatencyPercentiles{Count: 1, P50: 60}}},
+	}}
+	fake := &fakeRepo{
+		fetchLatencyStatsFn: func(ctx context.Context, filter domain.LatencyFilter) (domain.LatencyStats, error) {
+			require.Equal(t, "backend", filter.TeamName)
+			return stats, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	result, err := svc.LatencyStats(context.Background(), domain.LatencyFilter{TeamName: "backend"})
+	require.NoError(t, err)
+	require.Equal(t, stats, result)
+
+	now := time.Now()
+	_, err = svc.LatencyStats(context.Background(), domain.LatencyFilter{From: &now, To: &now})
+	require.Error(t, err)
+}
+
+func TestFirstDecisionLatency(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	tests := []struct {
+		name    string
+		events  []domain.AssignmentEvent
+		latency time.Duration
+		ok      bool
+	}{
+		{
+			name: "first decision",
+			events: []domain.AssignmentEvent{
+				{EventType: domain.EventAssigned, CreatedAt: at(0)},
+				{EventType: domain.EventApproved, CreatedAt: at(30)},
+			},
+			latency: 30 * time.Minute,
+			ok:      true,
+		},
+		{
+			name: "repeated decision",
+			events: []domain.AssignmentEvent{
+				{EventType: domain.EventAssigned, CreatedAt: at(0)},
+				{EventType: domain.EventCommented, CreatedAt: at(10)},
+				{EventType: domain.EventApproved, CreatedAt: at(30)},
+			},
+		},
+		{
+			name: "first decision after reassignment back",
+			events: []domain.AssignmentEvent{
+				{EventType: domain.EventAssigned, CreatedAt: at(0)},
+				{EventType: domain.EventCommented, CreatedAt: at(10)},
+				{EventType: domain.EventUnassigned, CreatedAt: at(20)},
+				{EventType: domain.EventAssigned, CreatedAt: at(40)},
+				{EventType: domain.EventChangesRequested, CreatedAt: at(45)},
+			},
+			latency: 5 * time.Minute,
+			ok:      true,
+		},
+		{
+			name: "no decision",
+			events: []domain.AssignmentEvent{
+				{EventType: domain.EventAssigned, CreatedAt: at(0)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latency, ok := firstDecisionLatency(tt.events)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.latency, latency)
+		})
+	}
+}
+
+func TestService_SubmitReviewLooksUpReviewerEvents(t *testing.T) {
+	t.Parallel()
+	var filter domain.EventFilter
+	fake := &fakeRepo{
+		listEventsFn: func(ctx context.Context, f domain.EventFilter) ([]domain.AssignmentEvent, error) {
+			filter = f
+			return nil, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	_, err := svc.SubmitReview(context.Background(), "pr-1", "u2", domain.ReviewDecisionApproved)
+	require.NoError(t, err)
+	require.Equal(t, domain.EventFilter{PullRequestID: "pr-1", ReviewerID: "u2"}, filter)
+}
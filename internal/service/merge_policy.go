@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+// mergePolicyFor определяет политику merge команды. Поля, заданные для команды в БД,
+// имеют приоритет над конфигурацией команды; без них merge ничем не ограничен.
+func (s *Service) mergePolicyFor(settings domain.TeamSettings) domain.MergePolicy {
+	policy := settings.MergePolicy
+	teamCfg := s.cfg.Reviewers.Teams[settings.TeamName].MergePolicy
+	if policy.RequiredApprovals <= 0 {
+		policy.RequiredApprovals = max(teamCfg.RequiredApprovals, 0)
+	}
+	if policy.BlockOnChangesRequested == nil {
+		block := teamCfg.BlockOnChangesRequested
+		policy.BlockOnChangesRequested = &block
+	}
+	if policy.RequiredRole == "" {
+		policy.RequiredRole = teamCfg.RequiredRole
+	}
+	return policy
+}
+
+// checkMergePolicy проверяет PR по политике merge команды автора.
+// Если условия не выполнены, возвращает *domain.MergePolicyError со всеми невыполненными условиями.
+func (s *Service) checkMergePolicy(ctx context.Context, pr domain.PullRequest) error {
+	settings, err := s.authorTeamSettings(ctx, pr)
+	if err != nil {
+		return err
+	}
+	unmet, err := s.unmetMergeConditions(ctx, s.mergePolicyFor(settings), pr)
+	if err != nil {
+		return err
+	}
+	if len(unmet) > 0 {
+		return &domain.MergePolicyError{Unmet: unmet}
+	}
+	return nil
+}
+
+// unmetMergeConditions сравнивает последние решения ревьюверов PR с политикой merge.
+func (s *Service) unmetMergeConditions(ctx context.Context, policy domain.MergePolicy, pr domain.PullRequest) ([]domain.UnmetMergeCondition, error) {
+	var approvers, changesRequested []string
+	for _, review := range pr.Decisions {
+		switch review.Decision {
+		case domain.ReviewDecisionApproved:
+			approvers = append(approvers, review.ReviewerID)
+		case domain.ReviewDecisionChangesRequested:
+			changesRequested = append(changesRequested, review.ReviewerID)
+		}
+	}
+
+	var unmet []domain.UnmetMergeCondition
+	if len(approvers) < policy.RequiredApprovals {
+		unmet = append(unmet, domain.UnmetMergeCondition{
+			Code:    domain.MergeConditionApprovals,
+			Message: fmt.Sprintf("%d approvals required, got %d", policy.RequiredApprovals, len(approvers)),
+		})
+	}
+	if policy.BlockOnChangesRequested != nil && *policy.BlockOnChangesRequested && len(changesRequested) > 0 {
+		unmet = append(unmet, domain.UnmetMergeCondition{
+			Code:    domain.MergeConditionChangesRequested,
+			Message: "changes requested by " + strings.Join(changesRequested, ", "),
+		})
+	}
+	if policy.RequiredRole != "" {
+		approved, err := s.approvedByRole(ctx, approvers, policy.RequiredRole)
+		if err != nil {
+			return nil, err
+		}
+		if !approved {
+			unmet = append(unmet, domain.UnmetMergeCondition{
+				Code:    domain.MergeConditionRoleApproval,
+				Message: fmt.Sprintf("approval from role %q required", policy.RequiredRole),
+			})
+		}
+	}
+	return unmet, nil
+}
+
+// approvedByRole проверяет, есть ли среди одобривших пользователь с указанной ролью.
+func (s *Service) approvedByRole(ctx context.Context, approvers []string, role string) (bool, error) {
+	for _, id := range approvers {
+		user, err := s.repo.GetUserByID(ctx, id)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if user.Role == role {
+			return true, nil
+		}
+	}
+	return false, nil
+}
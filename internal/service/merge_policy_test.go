@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/config"
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestService_MergePullRequest_RejectsUnmetPolicy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	block := true
+	merged := false
+	fake := &fakeRepo{
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{
+				ID:                prID,
+				Status:            domain.PRStatusOpen,
+				AuthorID:          "u1",
+				AssignedReviewers: []string{"u2", "u3"},
+				Decisions: []domain.Review{
+					{ReviewerID: "u2", Decision: domain.ReviewDecisionApproved},
+					{ReviewerID: "u3", Decision: domain.ReviewDecisionChangesRequested},
+				},
+			}, nil
+		},
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			return domain.User{ID: userID, TeamName: "backend"}, nil
+		},
+		getTeamSettingsFn: func(ctx context.Context, name string) (domain.TeamSettings, error) {
+			return domain.TeamSettings{TeamName: name, MergePolicy: domain.MergePolicy{
+				RequiredApprovals:       2,
+				BlockOnChangesRequested: &block,
+				RequiredRole:            "lead",
+			}}, nil
+		},
+		updatePRStatusFn: func(ctx context.Context, prID string, status domain.PRStatus) (domain.PullRequest, error) {
+			merged = true
+			return domain.PullRequest{ID: prID, Status: status}, nil
+		},
+	}
+
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+	_, err := svc.MergePullRequest(ctx, "pr-1")
+	require.ErrorIs(t, err, domain.ErrMergePolicyNotMet)
+	require.False(t, merged)
+
+	var policyErr *domain.MergePolicyError
+	require.True(t, errors.As(err, &policyErr))
+	codes := make([]string, 0, len(policyErr.Unmet))
+	for _, c := range policyErr.Unmet {
+		codes = append(codes, c.Code)
+	}
+	require.Equal(t, []string{
+		domain.MergeConditionApprovals,
+		domain.MergeConditionChangesRequested,
+		domain.MergeConditionRoleApproval,
+	}, codes)
+}
+
+func TestService_MergePullRequest_SatisfiedConfiguredPolicy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cfg := testConfig()
+	cfg.Reviewers.Teams = map[string]config.TeamReviewersConfig{
+		"backend": {MergePolicy: config.MergePolicyConfig{RequiredApprovals: 1, BlockOnChangesRequested: true, RequiredRole: "lead"}},
+	}
+	fake := &fakeRepo{
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{
+				ID:       prID,
+				Status:   domain.PRStatusOpen,
+				AuthorID: "u1",
+				Decisions: []domain.Review{
+					{ReviewerID: "u2", Decision: domain.ReviewDecisionCommented},
+					{ReviewerID: "u3", Decision: domain.ReviewDecisionApproved},
+				},
+			}, nil
+		},
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			user := domain.User{ID: userID, TeamName: "backend"}
+			if userID == "u3" {
+				user.Role = "lead"
+			}
+			return user, nil
+		},
+		updatePRStatusFn: func(ctx context.Context, prID string, status domain.PRStatus) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, Status: status}, nil
+		},
+	}
+
+	svc := New(fake, cfg, stubManager{}, stubRandomizer{})
+	pr, err := svc.MergePullRequest(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, domain.PRStatusMerged, pr.Status)
+}
+
+func TestService_MergePullRequest_MergedSkipsPolicy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cfg := testConfig()
+	cfg.Reviewers.Teams = map[string]config.TeamReviewersConfig{
+		"backend": {MergePolicy: config.MergePolicyConfig{RequiredApprovals: 2}},
+	}
+	fake := &fakeRepo{
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, Status: domain.PRStatusMerged, AuthorID: "u1"}, nil
+		},
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			t.Fatalf("policy must not be checked for merged PR")
+			return domain.User{}, nil
+		},
+		updatePRStatusFn: func(ctx context.Context, prID string, status domain.PRStatus) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, Status: status}, nil
+		},
+	}
+
+	svc := New(fake, cfg, stubManager{}, stubRandomizer{})
+	_, err := svc.MergePullRequest(ctx, "pr-1")
+	require.NoError(t, err)
+}
+
+func TestService_MergePullRequest_ChecksPolicyUnderLock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var calls []string
+	inTx := func(ctx context.Context, call string) {
+		require.Equal(t, true, ctx.Value(txMarkerKey{}), call+" must run in the merge transaction")
+		calls = append(calls, call)
+	}
+	fake := &fakeRepo{
+		lockPullRequestFn: func(ctx context.Context, prID string) error {
+			inTx(ctx, "lock")
+			return nil
+		},
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			inTx(ctx, "get")
+			return domain.PullRequest{ID: prID, Status: domain.PRStatusOpen, AuthorID: "u1"}, nil
+		},
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			inTx(ctx, "policy")
+			return domain.User{ID: userID, TeamName: "backend"}, nil
+		},
+		updatePRStatusFn: func(ctx context.Context, prID string, status domain.PRStatus) (domain.PullRequest, error) {
+			inTx(ctx, "merge")
+			return domain.PullRequest{ID: prID, Status: status}, nil
+		},
+	}
+
+	svc := New(fake, testConfig(), txMarkerManager{}, stubRandomizer{})
+	_, err := svc.MergePullRequest(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"lock", "get", "policy", "merge"}, calls)
+}
+
+func TestService_MergePolicyResolution(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Reviewers.Teams = map[string]config.TeamReviewersConfig{
+		"backend": {MergePolicy: config.MergePolicyConfig{RequiredApprovals: 1, BlockOnChangesRequested: true, RequiredRole: "lead"}},
+	}
+	svc := New(&fakeRepo{}, cfg, stubManager{}, stubRandomizer{})
+
+	allow := false
+	policy := svc.mergePolicyFor(domain.TeamSettings{
+		TeamName:    "backend",
+		MergePolicy: domain.MergePolicy{RequiredApprovals: 3, BlockOnChangesRequested: &allow},
+	})
+	require.Equal(t, 3, policy.RequiredApprovals)
+	require.False(t, *policy.BlockOnChangesRequested)
+	require.Equal(t, "lead", policy.RequiredRole)
+
+	policy = svc.mergePolicyFor(domain.TeamSettings{TeamName: "frontend"})
+	require.Zero(t, policy.RequiredApprovals)
+	require.False(t, *policy.BlockOnChangesRequested)
+	require.Empty(t, policy.RequiredRole)
+}
+
+func TestService_UpdateTeamSettings_MergePolicy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var saved domain.TeamSettings
+	fake := &fakeRepo{
+		updateTeamSettingsFn: func(ctx context.Context, settings domain.TeamSettings) (domain.TeamSettings, error) {
+			saved = settings
+			return settings, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	negative := -1
+	_, err := svc.UpdateTeamSettings(ctx, "backend", TeamSettingsUpdate{MergePolicy: &MergePolicyUpdate{RequiredApprovals: &negative}})
+	require.Error(t, err)
+
+	approvals, block, role := 2, true, "lead"
+	settings, err := svc.UpdateTeamSettings(ctx, "backend", TeamSettingsUpdate{MergePolicy: &MergePolicyUpdate{
+		RequiredApprovals:       &approvals,
+		BlockOnChangesRequested: &block,
+		RequiredRole:            &role,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, 2, saved.MergePolicy.RequiredApprovals)
+	require.True(t, *saved.MergePolicy.BlockOnChangesRequested)
+	require.Equal(t, "lead", settings.MergePolicy.RequiredRole)
+}
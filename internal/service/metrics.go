@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/metrics"
+)
+
+// RefreshWorkloadMetrics обновляет gauge'и текущей нагрузки: открытые PR по командам и открытые ревью по ревьюверам.
+func (s *Service) RefreshWorkloadMetrics(ctx context.Context) error {
+	ctx, cancel := s.longOperationContext(ctx)
+	defer cancel()
+
+	workload, err := s.repo.FetchWorkload(ctx)
+	if err != nil {
+		return err
+	}
+	metrics.SetWorkload(workload)
+	return nil
+}
+
+// RunWorkloadMetricsWorker обновляет gauge'и текущей нагрузки сразу и затем каждые interval, пока не отменён ctx.
+func (s *Service) RunWorkloadMetricsWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.RefreshWorkloadMetrics(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "workload metrics refresh failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// observeMerge записывает в метрики merge PR и время от создания до merge с командой автора.
+func (s *Service) observeMerge(ctx context.Context, pr domain.PullRequest) {
+	if pr.MergedAt == nil {
+		return
+	}
+	team := s.authorTeam(ctx, pr)
+	metrics.IncPullRequestsMerged(team)
+	metrics.ObserveTimeToMerge(team, pr.MergedAt.Sub(pr.CreatedAt))
+}
+
+// observeReassignment записывает в метрики переназначение ревьювера на PR из источника source с командой автора.
+func (s *Service) observeReassignment(ctx context.Context, pr domain.PullRequest, source string) {
+	metrics.IncReassignments(s.authorTeam(ctx, pr), source)
+}
+
+// authorTeam возвращает команду автора PR для метки метрик. Ошибка только логируется: операция уже выполнена,
+// и событие учитывается без команды.
+func (s *Service) authorTeam(ctx context.Context, pr domain.PullRequest) string {
+	author, err := s.repo.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to resolve pull request author team for metrics", "pull_request_id", pr.ID, "error", err)
+		return ""
+	}
+	return author.TeamName
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestService_RefreshWorkloadMetrics(t *testing.T) {
+	t.Parallel()
+	called := false
+	fake := &fakeRepo{
+		fetchWorkloadFn: func(ctx context.Context) (domain.Workload, error) {
+			called = true
+			_, hasDeadline := ctx.Deadline()
+			require.True(t, hasDeadline)
+			return domain.Workload{Teams: []domain.TeamWorkload{{TeamName: "backend", OpenPullRequests: 1}}}, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	require.NoError(t, svc.RefreshWorkloadMetrics(context.Background()))
+	require.True(t, called)
+}
+
+func TestService_RefreshWorkloadMetricsReturnsRepositoryError(t *testing.T) {
+	t.Parallel()
+	repoErr := errors.New("db down")
+	fake := &fakeRepo{
+		fetchWorkloadFn: func(ctx context.Context) (domain.Workload, error) {
+			return domain.Workload{}, repoErr
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	require.ErrorIs(t, svc.RefreshWorkloadMetrics(context.Background()), repoErr)
+}
+
+func TestService_MassDeactivateObservesReassignmentsAfterCommit(t *testing.T) {
+	t.Parallel()
+
+	for _, failing := range []bool{false, true} {
+		var outsideTx int
+		fake := &fakeRepo{
+			getTeamFn: func(ctx context.Context, name string) (domain.Team, error) {
+				return domain.Team{Name: name, Members: []domain.User{
+					{ID: "u1", TeamName: name, IsActive: true},
+					{ID: "u2", TeamName: name, IsActive: true},
+					{ID: "u3", TeamName: name, IsActive: true},
+				}}, nil
+			},
+			getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+				// Вне транзакции команду автора запрашивает только запись метрик
+				if ctx.Value(txMarkerKey{}) == nil {
+					outsideTx++
+				}
+				return domain.User{ID: userID, TeamName: "backend", IsActive: true}, nil
+			},
+			listOpenPRsByReviewerFn: func(ctx context.Context, reviewerIDs []string) (map[string][]string, error) {
+				return map[string][]string{"u2": {"pr-1", "pr-2"}}, nil
+			},
+			getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+				return domain.PullRequest{ID: prID, Status: domain.PRStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil
+			},
+			listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+				return []domain.User{{ID: "u3", TeamName: teamName, IsActive: true}}, nil
+			},
+			replaceReviewerFn: func(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error) {
+				if failing && prID == "pr-2" {
+					return domain.PullRequest{}, "", errors.New("db down")
+				}
+				return domain.PullRequest{}, newReviewer, nil
+			},
+		}
+		svc := New(fake, testConfig(), txMarkerManager{}, stubRandomizer{})
+
+		_, err := svc.MassDeactivate(context.Background(), MassDeactivateInput{TeamName: "backend", UserIDs: []string{"u2"}})
+		if failing {
+			// Откаченное переназначение pr-1 не попадает в метрики
+			require.Error(t, err)
+			require.Zero(t, outsideTx)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, 2, outsideTx)
+	}
+}
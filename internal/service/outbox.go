@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/infrastructure/outbox"
+	"pr-reviewer-service_Avito/internal/metrics"
+)
+
+// PublishOutbox публикует одну пачку записей outbox во все приёмники и отмечает прогресс каждого приёмника.
+// Публикует только экземпляр, захвативший аренду relay, поэтому записи уходят в порядке записи даже при
+// нескольких экземплярах сервиса; вызовы приёмников выполняются вне транзакции. Каждый приёмник получает
+// только ещё не принятые им записи, а ошибка одного приёмника не задерживает остальные: принятое им
+// до ошибки отмечается, остаток будет отправлен ему повторно (доставка at-least-once).
+// Чтение пачки и вызовы приёмников ограничены сроком аренды (Timeouts.LongOperation): по его истечении
+// аренду может захватить другой экземпляр. Приёмник, не соблюдающий отмену контекста, может пережить
+// аренду, и тогда те же записи будут параллельно опубликованы повторно.
+// Возвращает количество записей в пачке; 0, если relay уже работает в другом экземпляре.
+func (s *Service) PublishOutbox(ctx context.Context, sinks []outbox.Sink, now time.Time) (int, error) {
+	token, err := generateSecretToken()
+	if err != nil {
+		return 0, fmt.Errorf("generate outbox lease token: %w", err)
+	}
+	ttl := s.cfg.Timeouts.LongOperation
+	acquired, err := s.repo.AcquireOutboxLease(ctx, token, now, ttl)
+	if err != nil || !acquired {
+		return 0, err
+	}
+	defer func() {
+		if err := s.repo.ReleaseOutboxLease(context.WithoutCancel(ctx), token, now); err != nil {
+			slog.ErrorContext(ctx, "release outbox lease", "error", err)
+		}
+	}()
+	// Прогресс отмечается в ctx без срока аренды, чтобы принятое приёмником до её истечения не отправлялось повторно
+	publishCtx, cancel := context.WithDeadline(ctx, now.Add(ttl))
+	defer cancel()
+
+	messages, err := s.repo.ListUnpublishedOutbox(publishCtx, s.cfg.Outbox.BatchSize)
+	if err != nil || len(messages) == 0 {
+		return 0, err
+	}
+	if len(sinks) == 0 {
+		if err := s.repo.MarkOutboxPublished(ctx, outboxIDs(messages), now); err != nil {
+			return 0, err
+		}
+		return len(messages), nil
+	}
+
+	names := make([]string, 0, len(sinks))
+	for _, sink := range sinks {
+		names = append(names, sink.Name())
+	}
+	var errs []error
+	for _, sink := range sinks {
+		pending := make([]domain.OutboxMessage, 0, len(messages))
+		for _, msg := range messages {
+			if !slices.Contains(msg.PublishedSinks, sink.Name()) {
+				pending = append(pending, msg)
+			}
+		}
+		if len(pending) == 0 {
+			continue
+		}
+		done, publishErr := sink.Publish(publishCtx, pending)
+		done = min(max(done, 0), len(pending))
+		if err := s.repo.MarkOutboxSinkPublished(ctx, outboxIDs(pending[:done]), sink.Name(), names, now); err != nil {
+			return 0, err
+		}
+		metrics.AddOutboxPublished(sink.Name(), done)
+		if publishErr != nil {
+			errs = append(errs, fmt.Errorf("outbox sink %s: %w", sink.Name(), publishErr))
+		}
+	}
+	if len(errs) > 0 {
+		return 0, errors.Join(errs...)
+	}
+	return len(messages), nil
+}
+
+func outboxIDs(messages []domain.OutboxMessage) []int64 {
+	ids := make([]int64, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, msg.ID)
+	}
+	return ids
+}
+
+// RunOutboxRelay публикует outbox каждые PollInterval, пока не отменён ctx.
+// Полная пачка означает, что записи ещё остались, и следующая публикуется сразу.
+func (s *Service) RunOutboxRelay(ctx context.Context, sinks []outbox.Sink) {
+	ticker := time.NewTicker(s.cfg.Outbox.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for ctx.Err() == nil {
+				published, err := s.PublishOutbox(ctx, sinks, time.Now())
+				if err != nil {
+					slog.ErrorContext(ctx, "outbox relay pass failed", "error", err)
+					break
+				}
+				if published < s.cfg.Outbox.BatchSize {
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/infrastructure/outbox"
+)
+
+type stubSink struct {
+	name     string
+	err      error
+	done     int // сколько сообщений обработано до ошибки err
+	received [][]domain.OutboxMessage
+	deadline time.Time
+}
+
+func (s *stubSink) Name() string { return s.name }
+
+func (s *stubSink) Publish(ctx context.Context, messages []domain.OutboxMessage) (int, error) {
+	s.received = append(s.received, messages)
+	s.deadline, _ = ctx.Deadline()
+	if s.err != nil {
+		return s.done, s.err
+	}
+	return len(messages), nil
+}
+
+type sinkMark struct {
+	sink string
+	ids  []int64
+}
+
+func TestService_PublishOutboxPublishesInOrderAndMarks(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	var leased, released string
+	var marks []sinkMark
+	fake := &fakeRepo{
+		acquireOutboxLeaseFn: func(ctx context.Context, token string, at time.Time, ttl time.Duration) (bool, error) {
+			require.Equal(t, now, at)
+			require.Equal(t, 2*time.Second, ttl)
+			leased = token
+			return true, nil
+		},
+		releaseOutboxLeaseFn: func(ctx context.Context, token string, at time.Time) error {
+			released = token
+			return nil
+		},
+		listUnpublishedOutboxFn: func(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+			require.Equal(t, 100, limit)
+			return []domain.OutboxMessage{{ID: 3}, {ID: 4, PublishedSinks: []string{"second"}}}, nil
+		},
+		markOutboxSinkPublishedFn: func(ctx context.Context, ids []int64, sink string, sinks []string, publishedAt time.Time) error {
+			require.Equal(t, []string{"first", "second"}, sinks)
+			require.Equal(t, now, publishedAt)
+			marks = append(marks, sinkMark{sink: sink, ids: ids})
+			return nil
+		},
+	}
+	first, second := &stubSink{name: "first"}, &stubSink{name: "second"}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	published, err := svc.PublishOutbox(context.Background(), []outbox.Sink{first, second}, now)
+	require.NoError(t, err)
+	require.Equal(t, 2, published)
+	require.Equal(t, [][]domain.OutboxMessage{{{ID: 3}, {ID: 4, PublishedSinks: []string{"second"}}}}, first.received)
+	// Второй приёмник уже принял запись 4 и получает только оставшуюся
+	require.Equal(t, [][]domain.OutboxMessage{{{ID: 3}}}, second.received)
+	require.Equal(t, []sinkMark{{sink: "first", ids: []int64{3, 4}}, {sink: "second", ids: []int64{3}}}, marks)
+	require.NotEmpty(t, leased)
+	require.Equal(t, leased, released)
+	// Приёмники работают не дольше аренды
+	require.Equal(t, now.Add(2*time.Second), first.deadline)
+}
+
+func TestService_PublishOutboxSinkErrorDoesNotBlockOthers(t *testing.T) {
+	t.Parallel()
+	var marks []sinkMark
+	fake := &fakeRepo{
+		listUnpublishedOutboxFn: func(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+			return []domain.OutboxMessage{{ID: 1}, {ID: 2}}, nil
+		},
+		markOutboxSinkPublishedFn: func(ctx context.Context, ids []int64, sink string, sinks []string, publishedAt time.Time) error {
+			marks = append(marks, sinkMark{sink: sink, ids: ids})
+			return nil
+		},
+	}
+	failing := &stubSink{name: "failing", err: errors.New("unavailable"), done: 1}
+	next := &stubSink{name: "next"}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	published, err := svc.PublishOutbox(context.Background(), []outbox.Sink{failing, next}, time.Now())
+	require.ErrorContains(t, err, "outbox sink failing")
+	require.Zero(t, published)
+	// Принятое до ошибки отмечается, остальные приёмники получают пачку целиком
+	require.Equal(t, []sinkMark{{sink: "failing", ids: []int64{1}}, {sink: "next", ids: []int64{1, 2}}}, marks)
+	require.Len(t, next.received, 1)
+}
+
+func TestService_PublishOutboxWithoutSinksMarksPublished(t *testing.T) {
+	t.Parallel()
+	var marked []int64
+	fake := &fakeRepo{
+		listUnpublishedOutboxFn: func(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+			return []domain.OutboxMessage{{ID: 1}, {ID: 2}}, nil
+		},
+		markOutboxPublishedFn: func(ctx context.Context, ids []int64, publishedAt time.Time) error {
+			marked = ids
+			return nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	published, err := svc.PublishOutbox(context.Background(), nil, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, 2, published)
+	require.Equal(t, []int64{1, 2}, marked)
+}
+
+func TestService_PublishOutboxSkipsWhenLeasedElsewhere(t *testing.T) {
+	t.Parallel()
+	fake := &fakeRepo{
+		acquireOutboxLeaseFn: func(ctx context.Context, token string, now time.Time, ttl time.Duration) (bool, error) {
+			return false, nil
+		},
+		releaseOutboxLeaseFn: func(ctx context.Context, token string, now time.Time) error {
+			t.Fatal("relay must not release a lease it does not hold")
+			return nil
+		},
+		listUnpublishedOutboxFn: func(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+			t.Fatal("relay must not read outbox without the lease")
+			return nil, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	published, err := svc.PublishOutbox(context.Background(), []outbox.Sink{&stubSink{name: "log"}}, time.Now())
+	require.NoError(t, err)
+	require.Zero(t, published)
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+// DefaultPullRequestsLimit — размер страницы списка PR, если limit не задан.
+const DefaultPullRequestsLimit = 50
+
+// ListPullRequests возвращает страницу списка PR по фильтру. Без сортировки PR идут от новых к старым.
+// Следующая страница запрашивается с курсором NextCursor (см. DecodePullRequestCursor) и той же сортировкой.
+func (s *Service) ListPullRequests(ctx context.Context, filter domain.PullRequestFilter) (domain.PullRequestPage, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if filter.Sort == "" {
+		filter.Sort, filter.Desc = domain.PullRequestSortCreatedAt, true
+	}
+	if err := ValidatePullRequestFilter(filter); err != nil {
+		return domain.PullRequestPage{}, err
+	}
+	limit := filter.Limit
+	if limit == 0 {
+		limit = DefaultPullRequestsLimit
+	}
+	// Лишний PR показывает, что за страницей есть продолжение
+	filter.Limit = limit + 1
+	prs, err := s.repo.ListPullRequests(ctx, filter)
+	if err != nil {
+		return domain.PullRequestPage{}, err
+	}
+	page := domain.PullRequestPage{PullRequests: prs}
+	if len(prs) > limit {
+		page.PullRequests = prs[:limit]
+		last := page.PullRequests[limit-1]
+		if page.NextCursor, err = EncodePullRequestCursor(domain.PullRequestCursor{
+			Sort:      filter.Sort,
+			Desc:      filter.Desc,
+			CreatedAt: last.CreatedAt,
+			Name:      last.Name,
+			ID:        last.ID,
+		}); err != nil {
+			return domain.PullRequestPage{}, err
+		}
+	}
+	return page, nil
+}
+
+// pullRequestCursor — содержимое курсора списка PR. Хранится только значение поля сортировки.
+type pullRequestCursor struct {
+	Sort  domain.PullRequestSort `json:"s"`
+	Desc  bool                   `json:"d,omitempty"`
+	Value string                 `json:"v"`
+	ID    string                 `json:"id"`
+}
+
+// EncodePullRequestCursor возвращает непрозрачный курсор страницы, следующей за PR cursor.ID.
+func EncodePullRequestCursor(cursor domain.PullRequestCursor) (string, error) {
+	raw := pullRequestCursor{Sort: cursor.Sort, Desc: cursor.Desc, Value: cursor.Name, ID: cursor.ID}
+	if cursor.Sort != domain.PullRequestSortName {
+		raw.Value = cursor.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return encodeCursor(raw)
+}
+
+// DecodePullRequestCursor возвращает позицию в списке PR, после которой начинается страница.
+func DecodePullRequestCursor(cursor string) (domain.PullRequestCursor, error) {
+	var raw pullRequestCursor
+	if err := decodeCursor(cursor, &raw); err != nil || raw.ID == "" {
+		return domain.PullRequestCursor{}, errMalformedCursor
+	}
+	result := domain.PullRequestCursor{Sort: raw.Sort, Desc: raw.Desc, ID: raw.ID}
+	switch raw.Sort {
+	case domain.PullRequestSortName:
+		result.Name = raw.Value
+	case domain.PullRequestSortCreatedAt:
+		createdAt, err := time.Parse(time.RFC3339Nano, raw.Value)
+		if err != nil {
+			return domain.PullRequestCursor{}, errMalformedCursor
+		}
+		result.CreatedAt = createdAt
+	default:
+		return domain.PullRequestCursor{}, errMalformedCursor
+	}
+	return result, nil
+}
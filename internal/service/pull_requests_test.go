@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestServiceListPullRequestsDefaultsToNewestFirst(t *testing.T) {
+	t.Parallel()
+	expected := []domain.PullRequestShort{{ID: "pr-1"}}
+	fake := &fakeRepo{
+		listPullRequestsFn: func(ctx context.Context, filter domain.PullRequestFilter) ([]domain.PullRequestShort, error) {
+			require.Equal(t, domain.PullRequestSortCreatedAt, filter.Sort)
+			require.True(t, filter.Desc)
+			require.Equal(t, DefaultPullRequestsLimit+1, filter.Limit)
+			return expected, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	page, err := svc.ListPullRequests(context.Background(), domain.PullRequestFilter{})
+	require.NoError(t, err)
+	require.Equal(t, domain.PullRequestPage{PullRequests: expected}, page)
+}
+
+func TestServiceListPullRequestsPaginates(t *testing.T) {
+	t.Parallel()
+	fake := &fakeRepo{
+		listPullRequestsFn: func(ctx context.Context, filter domain.PullRequestFilter) ([]domain.PullRequestShort, error) {
+			require.Equal(t, 3, filter.Limit)
+			return []domain.PullRequestShort{{ID: "pr-1", Name: "Alpha"}, {ID: "pr-2", Name: "Beta"}, {ID: "pr-3", Name: "Gamma"}}, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	filter := domain.PullRequestFilter{Sort: domain.PullRequestSortName, Limit: 2}
+	page, err := svc.ListPullRequests(context.Background(), filter)
+	require.NoError(t, err)
+	require.Len(t, page.PullRequests, 2)
+
+	cursor, err := DecodePullRequestCursor(page.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, domain.PullRequestCursor{Sort: domain.PullRequestSortName, Name: "Beta", ID: "pr-2"}, cursor)
+
+	// Курсор другой сортировки отклоняется
+	filter.Desc, filter.After = true, &cursor
+	_, err = svc.ListPullRequests(context.Background(), filter)
+	require.Error(t, err)
+}
+
+func TestServiceListPullRequestsValidates(t *testing.T) {
+	t.Parallel()
+	svc := New(&fakeRepo{}, testConfig(), stubManager{}, stubRandomizer{})
+	from := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, -1)
+
+	for name, filter := range map[string]domain.PullRequestFilter{
+		"status":    {Statuses: []domain.PRStatus{"STALE"}},
+		"reviewers": {ReviewerID: "u1", WithoutReviewers: true},
+		"created":   {CreatedFrom: &from, CreatedTo: &to},
+		"merged":    {MergedFrom: &from, MergedTo: &to},
+		"sort":      {Sort: "author_id"},
+		"limit":     {Limit: MaxPullRequestsLimit + 1},
+	} {
+		_, err := svc.ListPullRequests(context.Background(), filter)
+		require.Error(t, err, name)
+	}
+}
+
+func TestPullRequestCursorRoundTrip(t *testing.T) {
+	t.Parallel()
+	created := time.Date(2025, 1, 2, 10, 0, 0, 123, time.UTC)
+	cursor := domain.PullRequestCursor{Sort: domain.PullRequestSortCreatedAt, Desc: true, CreatedAt: created, ID: "pr-1"}
+
+	encoded, err := EncodePullRequestCursor(cursor)
+	require.NoError(t, err)
+	decoded, err := DecodePullRequestCursor(encoded)
+	require.NoError(t, err)
+	require.Equal(t, cursor, decoded)
+
+	// Курсоры других списков не принимаются
+	reviewCursor, err := EncodeReviewCursor(created, "pr-1")
+	require.NoError(t, err)
+	eventCursor, err := EncodeEventCursor(10)
+	require.NoError(t, err)
+	for _, raw := range []string{"%%%", reviewCursor, eventCursor} {
+		_, err := DecodePullRequestCursor(raw)
+		require.Error(t, err, raw)
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+// DefaultReviewAssignmentsLimit — размер страницы PR ревьювера, если limit не задан.
+const DefaultReviewAssignmentsLimit = 50
+
+// defaultReviewStatuses — статусы PR ревьювера, если фильтр статусов не задан.
+var defaultReviewStatuses = []domain.PRStatus{domain.PRStatusOpen}
+
+// ListReviewAssignments возвращает страницу PR, на которые назначен пользователь, от новых к старым.
+// Без фильтра статусов возвращаются только открытые PR. Следующая страница запрашивается с курсором
+// NextCursor (см. DecodeReviewCursor).
+func (s *Service) ListReviewAssignments(ctx context.Context, filter domain.ReviewAssignmentFilter) (domain.ReviewAssignmentPage, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidateReviewAssignmentFilter(filter); err != nil {
+		return domain.ReviewAssignmentPage{}, err
+	}
+	if _, err := s.repo.GetUserByID(ctx, filter.UserID); err != nil {
+		return domain.ReviewAssignmentPage{}, err
+	}
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = defaultReviewStatuses
+	}
+	total, err := s.repo.CountReviewAssignments(ctx, filter)
+	if err != nil {
+		return domain.ReviewAssignmentPage{}, err
+	}
+	limit := filter.Limit
+	if limit == 0 {
+		limit = DefaultReviewAssignmentsLimit
+	}
+	// Лишний PR показывает, что за страницей есть продолжение
+	filter.Limit = limit + 1
+	prs, err := s.repo.ListReviewAssignments(ctx, filter)
+	if err != nil {
+		return domain.ReviewAssignmentPage{}, err
+	}
+	page := domain.ReviewAssignmentPage{UserID: filter.UserID, PullRequests: prs, Total: total}
+	if len(prs) > limit {
+		page.PullRequests = prs[:limit]
+		last := page.PullRequests[limit-1]
+		if page.NextCursor, err = EncodeReviewCursor(last.CreatedAt, last.ID); err != nil {
+			return domain.ReviewAssignmentPage{}, err
+		}
+	}
+	return page, nil
+}
+
+// reviewCursor — содержимое курсора списка ревью пользователя.
+type reviewCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+// EncodeReviewCursor возвращает непрозрачный курсор страницы, следующей за PR prID, созданным в createdAt.
+func EncodeReviewCursor(createdAt time.Time, prID string) (string, error) {
+	return encodeCursor(reviewCursor{CreatedAt: createdAt.UTC(), ID: prID})
+}
+
+// DecodeReviewCursor возвращает время создания и ID PR, после которого начинается страница.
+func DecodeReviewCursor(cursor string) (time.Time, string, error) {
+	var raw reviewCursor
+	if err := decodeCursor(cursor, &raw); err != nil || raw.ID == "" || raw.CreatedAt.IsZero() {
+		return time.Time{}, "", errMalformedCursor
+	}
+	return raw.CreatedAt, raw.ID, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestServiceListReviewAssignmentsDefaultsToOpen(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	expected := []domain.PullRequestShort{{ID: "pr-1"}}
+	fake := &fakeRepo{
+		countReviewAssignmentsFn: func(ctx context.Context, filter domain.ReviewAssignmentFilter) (int64, error) {
+			require.Equal(t, []domain.PRStatus{domain.PRStatusOpen}, filter.Statuses)
+			return 1, nil
+		},
+		listReviewAssignmentsFn: func(ctx context.Context, filter domain.ReviewAssignmentFilter) ([]domain.PullRequestShort, error) {
+			require.Equal(t, "user-1", filter.UserID)
+			require.Equal(t, []domain.PRStatus{domain.PRStatusOpen}, filter.Statuses)
+			require.Equal(t, DefaultReviewAssignmentsLimit+1, filter.Limit)
+			return expected, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	page, err := svc.ListReviewAssignments(ctx, domain.ReviewAssignmentFilter{UserID: "user-1"})
+	require.NoError(t, err)
+	require.Equal(t, domain.ReviewAssignmentPage{UserID: "user-1", PullRequests: expected, Total: 1}, page)
+}
+
+func TestServiceListReviewAssignmentsPaginates(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	created := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	fake := &fakeRepo{
+		countReviewAssignmentsFn: func(ctx context.Context, filter domain.ReviewAssignmentFilter) (int64, error) {
+			return 5, nil
+		},
+		listReviewAssignmentsFn: func(ctx context.Context, filter domain.ReviewAssignmentFilter) ([]domain.PullRequestShort, error) {
+			require.Equal(t, 3, filter.Limit)
+			return []domain.PullRequestShort{
+				{ID: "pr-3", CreatedAt: created.Add(2 * time.Hour)},
+				{ID: "pr-2", CreatedAt: created},
+				{ID: "pr-1", CreatedAt: created.Add(-time.Hour)},
+			}, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	page, err := svc.ListReviewAssignments(ctx, domain.ReviewAssignmentFilter{
+		UserID:   "user-1",
+		Statuses: []domain.PRStatus{domain.PRStatusOpen, domain.PRStatusMerged},
+		Limit:    2,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.PullRequests, 2)
+	require.Equal(t, int64(5), page.Total)
+
+	afterCreatedAt, afterID, err := DecodeReviewCursor(page.NextCursor)
+	require.NoError(t, err)
+	require.True(t, created.Equal(afterCreatedAt))
+	require.Equal(t, "pr-2", afterID)
+}
+
+func TestServiceListReviewAssignmentsValidates(t *testing.T) {
+	t.Parallel()
+	svc := New(&fakeRepo{}, testConfig(), stubManager{}, stubRandomizer{})
+
+	_, err := svc.ListReviewAssignments(context.Background(), domain.ReviewAssignmentFilter{
+		UserID:   "user-1",
+		Statuses: []domain.PRStatus{"STALE"},
+	})
+	require.Error(t, err)
+
+	_, err = svc.ListReviewAssignments(context.Background(), domain.ReviewAssignmentFilter{
+		UserID: "user-1",
+		Limit:  MaxReviewAssignmentsLimit + 1,
+	})
+	require.Error(t, err)
+}
+
+func TestDecodeReviewCursorRejectsMalformed(t *testing.T) {
+	t.Parallel()
+	eventCursor, err := EncodeEventCursor(10)
+	require.NoError(t, err)
+	for _, cursor := range []string{"%%%", eventCursor, "MjAyNS0wMS0wMlQxMDowMDowMFp8"} {
+		_, _, err := DecodeReviewCursor(cursor)
+		require.Error(t, err, cursor)
+	}
+}
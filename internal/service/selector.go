@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/infrastructure/randomizer"
+)
+
+// ReviewerSelector выбирает ревьюверов из списка кандидатов.
+// Реализация должна вернуть не более req.Limit уникальных user_id из req.Candidates.
+type ReviewerSelector interface {
+	Select(ctx context.Context, req SelectionRequest) ([]string, error)
+}
+
+// ReviewerSelectorFunc позволяет использовать обычную функцию как ReviewerSelector.
+type ReviewerSelectorFunc func(ctx context.Context, req SelectionRequest) ([]string, error)
+
+// Select вызывает f(ctx, req).
+func (f ReviewerSelectorFunc) Select(ctx context.Context, req SelectionRequest) ([]string, error) {
+	return f(ctx, req)
+}
+
+// SelectionRequest описывает входные данные для выбора ревьюверов.
+type SelectionRequest struct {
+	TeamName   string        // Команда, для которой выполняется выбор
+	Candidates []domain.User // Активные участники, уже без автора и назначенных ревьюверов
+	Limit      int           // Максимальное количество ревьюверов
+}
+
+// assignmentStatsFetcher возвращает агрегаты назначений по пользователям; нужен стратегии least_loaded.
+type assignmentStatsFetcher interface {
+	FetchUserAssignmentStats(ctx context.Context, userIDs []string) ([]domain.UserAssignmentStat, error)
+}
+
+// RegisterSelector регистрирует стратегию выбора ревьюверов под указанным именем.
+// Позволяет заменить встроенную стратегию или добавить собственную.
+func (s *Service) RegisterSelector(strategy domain.ReviewerStrategy, selector ReviewerSelector) {
+	s.selectorsMu.Lock()
+	defer s.selectorsMu.Unlock()
+	s.selectors[strategy] = selector
+}
+
+// selector возвращает зарегистрированную стратегию по имени.
+func (s *Service) selector(strategy domain.ReviewerStrategy) (ReviewerSelector, bool) {
+	s.selectorsMu.RLock()
+	defer s.selectorsMu.RUnlock()
+	selector, ok := s.selectors[strategy]
+	return selector, ok
+}
+
+// strategyFor определяет стратегию команды: настройка из БД, затем конфигурация команды,
+// затем стратегия по умолчанию.
+func (s *Service) strategyFor(settings domain.TeamSettings) domain.ReviewerStrategy {
+	if settings.ReviewerStrategy != "" {
+		return settings.ReviewerStrategy
+	}
+	if teamCfg, ok := s.cfg.Reviewers.Teams[settings.TeamName]; ok && teamCfg.Strategy != "" {
+		return domain.ReviewerStrategy(teamCfg.Strategy)
+	}
+	if s.cfg.Reviewers.DefaultStrategy != "" {
+		return domain.ReviewerStrategy(s.cfg.Reviewers.DefaultStrategy)
+	}
+	return domain.ReviewerStrategyRandom
+}
+
+// requiredReviewersFor определяет количество ревьюверов на PR команды в том же порядке,
+// что и strategyFor.
+func (s *Service) requiredReviewersFor(settings domain.TeamSettings) int {
+	if settings.RequiredReviewers > 0 {
+		return settings.RequiredReviewers
+	}
+	if teamCfg, ok := s.cfg.Reviewers.Teams[settings.TeamName]; ok && teamCfg.RequiredReviewers > 0 {
+		return teamCfg.RequiredReviewers
+	}
+	if s.cfg.Reviewers.DefaultRequired > 0 {
+		return s.cfg.Reviewers.DefaultRequired
+	}
+	return DefaultRequiredReviewers
+}
+
+// fallbackTeamsFor определяет резервные команды: настройка из БД, затем конфигурация команды.
+func (s *Service) fallbackTeamsFor(settings domain.TeamSettings) []string {
+	if len(settings.FallbackTeams) > 0 {
+		return settings.FallbackTeams
+	}
+	return s.cfg.Reviewers.Teams[settings.TeamName].FallbackTeams
+}
+
+// maxOpenReviewsFor определяет лимит открытых ревью на участника команды по умолчанию
+// в том же порядке, что и strategyFor. 0 означает отсутствие ограничения.
+func (s *Service) maxOpenReviewsFor(settings domain.TeamSettings) int {
+	if settings.MaxOpenReviews > 0 {
+		return settings.MaxOpenReviews
+	}
+	if teamCfg, ok := s.cfg.Reviewers.Teams[settings.TeamName]; ok && teamCfg.MaxOpenReviews > 0 {
+		return teamCfg.MaxOpenReviews
+	}
+	return max(s.cfg.Reviewers.DefaultMaxOpenReviews, 0)
+}
+
+// teamSettings загружает настройки команды для выбора ревьюверов.
+func (s *Service) teamSettings(ctx context.Context, teamName string) (domain.TeamSettings, error) {
+	settings, err := s.repo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return domain.TeamSettings{}, err
+	}
+	settings.TeamName = teamName
+	return settings, nil
+}
+
+// authorTeamSettings загружает настройки команды автора PR. Ими определяются выбор ревьюверов
+// при любом изменении состава (создание, добавление, переназначение), политика merge и SLA.
+func (s *Service) authorTeamSettings(ctx context.Context, pr domain.PullRequest) (domain.TeamSettings, error) {
+	author, err := s.repo.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return domain.TeamSettings{}, err
+	}
+	return s.teamSettings(ctx, author.TeamName)
+}
+
+// selectReviewers выбирает до limit ревьюверов из кандидатов согласно стратегии команды.
+// Если стратегия команды не зарегистрирована, используется случайный выбор.
+func (s *Service) selectReviewers(ctx context.Context, settings domain.TeamSettings, candidates []domain.User, limit int) ([]string, error) {
+	if len(candidates) == 0 || limit <= 0 {
+		return nil, nil
+	}
+	strategy := s.strategyFor(settings)
+	selector, ok := s.selector(strategy)
+	if !ok {
+		slog.WarnContext(ctx, "unknown reviewer strategy, falling back to random", "team_name", settings.TeamName, "strategy", strategy)
+		selector, _ = s.selector(domain.ReviewerStrategyRandom)
+	}
+	return selector.Select(ctx, SelectionRequest{
+		TeamName:   settings.TeamName,
+		Candidates: candidates,
+		Limit:      limit,
+	})
+}
+
+// pickReviewers выбирает до limit ревьюверов среди активных участников команды, кроме exclude.
+// Участники, достигшие лимита открытых ревью, не рассматриваются. Если в команде не осталось
+// ни одного кандидата, по порядку перебирает резервные команды и выбирает из первой, где кандидаты есть,
+// стратегией этой команды. Резервные команды резервных команд не учитываются.
+// Если кандидаты были, но все достигли лимита, возвращает domain.ErrAllSaturated.
+func (s *Service) pickReviewers(ctx context.Context, settings domain.TeamSettings, exclude []string, limit int) ([]string, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	var saturated bool
+	teams := append([]string{settings.TeamName}, s.fallbackTeamsFor(settings)...)
+	for i, teamName := range teams {
+		candidates, err := s.repo.ListActiveTeamMembers(ctx, teamName, exclude)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		teamSettings := settings
+		if i > 0 {
+			if teamSettings, err = s.teamSettings(ctx, teamName); err != nil {
+				return nil, err
+			}
+		}
+		available, err := s.withoutSaturated(ctx, teamSettings, candidates)
+		if err != nil {
+			return nil, err
+		}
+		if len(available) == 0 {
+			saturated = true
+			continue
+		}
+		if i > 0 {
+			slog.InfoContext(ctx, "assigning reviewers from fallback team", "team_name", settings.TeamName, "fallback_team", teamName)
+		}
+		return s.selectReviewers(ctx, teamSettings, available, limit)
+	}
+	if saturated {
+		return nil, domain.ErrAllSaturated
+	}
+	return nil, nil
+}
+
+// withoutSaturated исключает кандидатов, у которых открытых ревью не меньше лимита.
+// Лимит кандидата — персональный max_open_reviews, а если он не задан — значение по умолчанию команды.
+func (s *Service) withoutSaturated(ctx context.Context, settings domain.TeamSettings, candidates []domain.User) ([]domain.User, error) {
+	teamLimit := s.maxOpenReviewsFor(settings)
+	limits := make(map[string]int, len(candidates))
+	ids := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		limit := c.MaxOpenReviews
+		if limit <= 0 {
+			limit = teamLimit
+		}
+		if limit > 0 {
+			limits[c.ID] = limit
+			ids = append(ids, c.ID)
+		}
+	}
+	// Ни у кого нет ограничения — нагрузку можно не запрашивать
+	if len(ids) == 0 {
+		return candidates, nil
+	}
+	stats, err := s.repo.FetchUserAssignmentStats(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	openReviews := make(map[string]int64, len(stats))
+	for _, stat := range stats {
+		openReviews[stat.UserID] = stat.ActivePRs
+	}
+	available := make([]domain.User, 0, len(candidates))
+	for _, c := range candidates {
+		if limit, ok := limits[c.ID]; ok && openReviews[c.ID] >= int64(limit) {
+			continue
+		}
+		available = append(available, c)
+	}
+	return available, nil
+}
+
+// randomSelector выбирает ревьюверов равновероятно.
+type randomSelector struct {
+	randomizer randomizer.Randomizer
+}
+
+func (s randomSelector) Select(_ context.Context, req SelectionRequest) ([]string, error) {
+	return pickRandomIDs(req.Candidates, req.Limit, s.randomizer), nil
+}
+
+// leastLoadedSelector выбирает кандидатов с наименьшим числом открытых ревью
+// (active_pull_requests из статистики назначений). При равной нагрузке порядок определяется случайно.
+type leastLoadedSelector struct {
+	repo       assignmentStatsFetcher
+	randomizer randomizer.Randomizer
+}
+
+func (s leastLoadedSelector) Select(ctx context.Context, req SelectionRequest) ([]string, error) {
+	ids := pickRandomIDs(req.Candidates, len(req.Candidates), s.randomizer)
+	if len(ids) == 0 || req.Limit <= 0 {
+		return nil, nil
+	}
+	stats, err := s.repo.FetchUserAssignmentStats(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	load := make(map[string]int64, len(stats))
+	for _, stat := range stats {
+		load[stat.UserID] = stat.ActivePRs
+	}
+	// Стабильная сортировка сохраняет случайный порядок среди кандидатов с одинаковой нагрузкой
+	sort.SliceStable(ids, func(i, j int) bool {
+		return load[ids[i]] < load[ids[j]]
+	})
+	if len(ids) > req.Limit {
+		ids = ids[:req.Limit]
+	}
+	return ids, nil
+}
+
+// roundRobinSelector выбирает кандидатов по кругу в порядке user_id.
+// Позиция хранится в памяти отдельно для каждой команды.
+type roundRobinSelector struct {
+	mu   sync.Mutex
+	last map[string]string // team_name -> последний выбранный user_id
+}
+
+func newRoundRobinSelector() *roundRobinSelector {
+	return &roundRobinSelector{last: make(map[string]string)}
+}
+
+func (s *roundRobinSelector) Select(_ context.Context, req SelectionRequest) ([]string, error) {
+	if len(req.Candidates) == 0 || req.Limit <= 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(req.Candidates))
+	for i, u := range req.Candidates {
+		ids[i] = u.ID
+	}
+	sort.Strings(ids)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Начинаем с первого user_id, следующего за последним выбранным
+	start := sort.Search(len(ids), func(i int) bool { return ids[i] > s.last[req.TeamName] })
+	limit := min(req.Limit, len(ids))
+	result := make([]string, 0, limit)
+	for i := 0; i < limit; i++ {
+		result = append(result, ids[(start+i)%len(ids)])
+	}
+	s.last[req.TeamName] = result[len(result)-1]
+	return result, nil
+}
+
+// weightedSelector выбирает кандидатов случайно пропорционально весам из конфигурации.
+// Кандидаты без веса получают вес 1, кандидаты с неположительным весом не выбираются.
+type weightedSelector struct {
+	weights    func(teamName string) map[string]int
+	randomizer randomizer.Randomizer
+}
+
+func (s weightedSelector) Select(_ context.Context, req SelectionRequest) ([]string, error) {
+	type weighted struct {
+		id     string
+		weight int
+	}
+	weights := s.weights(req.TeamName)
+	pool := make([]weighted, 0, len(req.Candidates))
+	for _, u := range req.Candidates {
+		w, ok := weights[u.ID]
+		if !ok {
+			w = 1
+		}
+		if w <= 0 {
+			continue
+		}
+		pool = append(pool, weighted{id: u.ID, weight: w})
+	}
+	// Выбор без возвращения: после каждого шага выбранный кандидат удаляется из пула
+	var result []string
+	for len(result) < req.Limit && len(pool) > 0 {
+		total := 0
+		for _, c := range pool {
+			total += c.weight
+		}
+		point := s.randomizer.Intn(total)
+		idx := 0
+		for i, c := range pool {
+			if point < c.weight {
+				idx = i
+				break
+			}
+			point -= c.weight
+		}
+		result = append(result, pool[idx].id)
+		pool = append(pool[:idx], pool[idx+1:]...)
+	}
+	return result, nil
+}
@@ -0,0 +1,517 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/config"
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestLeastLoadedSelectorPrefersIdleReviewers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	fake := &fakeRepo{
+		fetchUserAssignmentStatsFn: func(ctx context.Context, userIDs []string) ([]domain.UserAssignmentStat, error) {
+			require.ElementsMatch(t, []string{"u1", "u2", "u3"}, userIDs)
+			return []domain.UserAssignmentStat{
+				{UserID: "u1", Assigned: 5, ActivePRs: 2},
+				{UserID: "u3", Assigned: 9, ActivePRs: 1},
+				{UserID: "u2", Assigned: 1, ActivePRs: 0},
+			}, nil
+		},
+	}
+	selector := leastLoadedSelector{repo: fake, randomizer: stubRandomizer{}}
+	selected, err := selector.Select(ctx, SelectionRequest{
+		TeamName:   "backend",
+		Candidates: []domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}},
+		Limit:      2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"u2", "u3"}, selected)
+}
+
+func TestLeastLoadedSelectorBreaksTiesRandomly(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	fake := &fakeRepo{
+		fetchUserAssignmentStatsFn: func(ctx context.Context, userIDs []string) ([]domain.UserAssignmentStat, error) {
+			return []domain.UserAssignmentStat{
+				{UserID: "u1", ActivePRs: 1},
+				{UserID: "u2", ActivePRs: 1},
+				{UserID: "u3", ActivePRs: 3},
+			}, nil
+		},
+	}
+	// reverseRandomizer переворачивает порядок кандидатов, поэтому среди равных побеждает u2
+	selector := leastLoadedSelector{repo: fake, randomizer: reverseRandomizer{}}
+	selected, err := selector.Select(ctx, SelectionRequest{
+		Candidates: []domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}},
+		Limit:      1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"u2"}, selected)
+}
+
+func TestRoundRobinSelectorRotatesPerTeam(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	selector := newRoundRobinSelector()
+	candidates := []domain.User{{ID: "u3"}, {ID: "u1"}, {ID: "u2"}}
+	pick := func(team string, limit int) []string {
+		selected, err := selector.Select(ctx, SelectionRequest{TeamName: team, Candidates: candidates, Limit: limit})
+		require.NoError(t, err)
+		return selected
+	}
+
+	require.Equal(t, []string{"u1"}, pick("backend", 1))
+	require.Equal(t, []string{"u2", "u3"}, pick("backend", 2))
+	require.Equal(t, []string{"u1"}, pick("backend", 1))
+	// Позиция другой команды не зависит от backend
+	require.Equal(t, []string{"u1", "u2"}, pick("frontend", 2))
+}
+
+func TestWeightedSelectorUsesWeights(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	rnd := &sequenceRandomizer{values: []int{2, 0}}
+	selector := weightedSelector{
+		weights: func(teamName string) map[string]int {
+			require.Equal(t, "backend", teamName)
+			return map[string]int{"u1": 1, "u2": 3, "u3": 0}
+		},
+		randomizer: rnd,
+	}
+	selected, err := selector.Select(ctx, SelectionRequest{
+		TeamName:   "backend",
+		Candidates: []domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}},
+		Limit:      3,
+	})
+	require.NoError(t, err)
+	// u3 с нулевым весом не выбирается; сначала u2 (точка 2 из 4), затем оставшийся u1
+	require.Equal(t, []string{"u2", "u1"}, selected)
+	require.Equal(t, []int{4, 1}, rnd.bounds)
+}
+
+func TestService_CreatePullRequest_UsesTeamStrategy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var capturedReviewers []string
+	fake := &fakeRepo{
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			return domain.User{ID: userID, TeamName: "backend", IsActive: true}, nil
+		},
+		getTeamSettingsFn: func(ctx context.Context, name string) (domain.TeamSettings, error) {
+			return domain.TeamSettings{TeamName: name, ReviewerStrategy: "custom"}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			return []domain.User{{ID: "u2"}, {ID: "u3"}}, nil
+		},
+		createPullRequestFn: func(ctx context.Context, pr domain.PullRequest, reviewers []string) (domain.PullRequest, error) {
+			capturedReviewers = reviewers
+			return pr, nil
+		},
+	}
+
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+	svc.RegisterSelector("custom", ReviewerSelectorFunc(func(ctx context.Context, req SelectionRequest) ([]string, error) {
+		require.Equal(t, "backend", req.TeamName)
+		require.Equal(t, 2, req.Limit)
+		return []string{"u3"}, nil
+	}))
+	_, err := svc.CreatePullRequest(ctx, "pr-1", "Feature", "u1", false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"u3"}, capturedReviewers)
+}
+
+func TestService_StrategyResolution(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Reviewers = config.ReviewersConfig{
+		DefaultStrategy: "least_loaded",
+		Teams: map[string]config.TeamReviewersConfig{
+			"backend": {Strategy: "weighted"},
+		},
+	}
+	svc := New(&fakeRepo{}, cfg, stubManager{}, stubRandomizer{})
+
+	require.Equal(t, domain.ReviewerStrategyRoundRobin,
+		svc.strategyFor(domain.TeamSettings{TeamName: "backend", ReviewerStrategy: domain.ReviewerStrategyRoundRobin}))
+	require.Equal(t, domain.ReviewerStrategyWeighted, svc.strategyFor(domain.TeamSettings{TeamName: "backend"}))
+	require.Equal(t, domain.ReviewerStrategyLeastLoaded, svc.strategyFor(domain.TeamSettings{TeamName: "frontend"}))
+}
+
+func TestService_UpdateTeamSettings(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var saved domain.TeamSettings
+	fake := &fakeRepo{
+		updateTeamSettingsFn: func(ctx context.Context, settings domain.TeamSettings) (domain.TeamSettings, error) {
+			saved = settings
+			return settings, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	unknown := domain.ReviewerStrategy("fastest")
+	_, err := svc.UpdateTeamSettings(ctx, "backend", TeamSettingsUpdate{ReviewerStrategy: &unknown})
+	require.ErrorIs(t, err, domain.ErrUnknownStrategy)
+
+	negative := -1
+	_, err = svc.UpdateTeamSettings(ctx, "backend", TeamSettingsUpdate{RequiredReviewers: &negative})
+	require.Error(t, err)
+
+	strategy := domain.ReviewerStrategyRoundRobin
+	settings, err := svc.UpdateTeamSettings(ctx, "backend", TeamSettingsUpdate{ReviewerStrategy: &strategy})
+	require.NoError(t, err)
+	require.Equal(t, domain.ReviewerStrategyRoundRobin, saved.ReviewerStrategy)
+	require.Equal(t, domain.ReviewerStrategyRoundRobin, settings.ReviewerStrategy)
+	require.Equal(t, DefaultRequiredReviewers, settings.RequiredReviewers)
+
+	required := 3
+	settings, err = svc.UpdateTeamSettings(ctx, "backend", TeamSettingsUpdate{RequiredReviewers: &required})
+	require.NoError(t, err)
+	require.Equal(t, 3, saved.RequiredReviewers)
+	require.Equal(t, 3, settings.RequiredReviewers)
+}
+
+func TestService_CreatePullRequest_FallsBackToFallbackTeams(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var visited []string
+	var capturedReviewers []string
+	fake := &fakeRepo{
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			return domain.User{ID: userID, TeamName: "tiny", IsActive: true}, nil
+		},
+		getTeamSettingsFn: func(ctx context.Context, name string) (domain.TeamSettings, error) {
+			if name == "tiny" {
+				return domain.TeamSettings{TeamName: name, FallbackTeams: []string{"mobile", "platform", "backend"}}, nil
+			}
+			return domain.TeamSettings{TeamName: name}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			visited = append(visited, teamName)
+			require.Equal(t, []string{"u1"}, exclude)
+			if teamName == "platform" {
+				return []domain.User{{ID: "p1", TeamName: teamName}, {ID: "p2", TeamName: teamName}}, nil
+			}
+			return nil, nil
+		},
+		createPullRequestFn: func(ctx context.Context, pr domain.PullRequest, reviewers []string) (domain.PullRequest, error) {
+			capturedReviewers = reviewers
+			return pr, nil
+		},
+	}
+
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+	_, err := svc.CreatePullRequest(ctx, "pr-1", "Feature", "u1", false)
+	require.NoError(t, err)
+	// Команды перебираются по порядку до первой, где есть кандидаты
+	require.Equal(t, []string{"tiny", "mobile", "platform"}, visited)
+	require.Equal(t, []string{"p1", "p2"}, capturedReviewers)
+}
+
+func TestService_ReassignReviewer_UsesConfiguredFallback(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cfg := testConfig()
+	cfg.Reviewers.Teams = map[string]config.TeamReviewersConfig{"tiny": {FallbackTeams: []string{"platform"}}}
+	fake := &fakeRepo{
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, Status: domain.PRStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil
+		},
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			return domain.User{ID: userID, TeamName: "tiny"}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			if teamName == "platform" {
+				return []domain.User{{ID: "p1", TeamName: teamName}}, nil
+			}
+			return nil, nil
+		},
+		replaceReviewerFn: func(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error) {
+			return domain.PullRequest{ID: prID, AssignedReviewers: []string{newReviewer}, FallbackReviewers: []string{newReviewer}}, newReviewer, nil
+		},
+	}
+
+	svc := New(fake, cfg, stubManager{}, stubRandomizer{})
+	pr, replacedBy, err := svc.ReassignReviewer(ctx, "pr-1", "u2", "")
+	require.NoError(t, err)
+	require.Equal(t, "p1", replacedBy)
+	require.Equal(t, []string{"p1"}, pr.FallbackReviewers)
+}
+
+func TestService_ReassignmentUsesAuthorTeamSettings(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Автор u1 из команды web, снимаемый ревьювер u2 — из backend: замена подбирается
+	// стратегией и резервными командами web, как при создании PR и добавлении ревьювера
+	cfg := testConfig()
+	cfg.Reviewers.Teams = map[string]config.TeamReviewersConfig{
+		"web":     {FallbackTeams: []string{"design"}},
+		"backend": {FallbackTeams: []string{"platform"}},
+	}
+	teams := map[string]string{"u1": "web", "u2": "backend"}
+	var searched []string
+	fake := &fakeRepo{
+		getTeamFn: func(ctx context.Context, name string) (domain.Team, error) {
+			return domain.Team{Name: name, Members: []domain.User{{ID: "u2", TeamName: name, IsActive: true}}}, nil
+		},
+		listOpenPRsByReviewerFn: func(ctx context.Context, reviewerIDs []string) (map[string][]string, error) {
+			return map[string][]string{"u2": {"pr-1"}}, nil
+		},
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, Status: domain.PRStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil
+		},
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			return domain.User{ID: userID, TeamName: teams[userID], IsActive: true}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			searched = append(searched, teamName)
+			if teamName == "design" {
+				return []domain.User{{ID: "d1", TeamName: teamName}}, nil
+			}
+			return nil, nil
+		},
+		replaceReviewerFn: func(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error) {
+			return domain.PullRequest{ID: prID}, newReviewer, nil
+		},
+	}
+	svc := New(fake, cfg, stubManager{}, stubRandomizer{})
+
+	_, replacedBy, err := svc.ReassignReviewer(ctx, "pr-1", "u2", "")
+	require.NoError(t, err)
+	require.Equal(t, "d1", replacedBy)
+	require.Equal(t, []string{"web", "design"}, searched)
+
+	searched = nil
+	result, err := svc.MassDeactivate(ctx, MassDeactivateInput{TeamName: "backend", UserIDs: []string{"u2"}})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"u2": {"pr-1"}}, result.Reassigned)
+	require.Equal(t, []string{"web", "design"}, searched)
+}
+
+func TestService_UpdateTeamSettings_ValidatesFallbackTeams(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	fake := &fakeRepo{
+		getTeamSettingsFn: func(ctx context.Context, name string) (domain.TeamSettings, error) {
+			if name == "ghost" {
+				return domain.TeamSettings{}, domain.ErrTeamNotFound
+			}
+			return domain.TeamSettings{TeamName: name}, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	self := []string{"backend"}
+	_, err := svc.UpdateTeamSettings(ctx, "backend", TeamSettingsUpdate{FallbackTeams: &self})
+	require.Error(t, err)
+
+	missing := []string{"platform", "ghost"}
+	_, err = svc.UpdateTeamSettings(ctx, "backend", TeamSettingsUpdate{FallbackTeams: &missing})
+	require.ErrorIs(t, err, domain.ErrTeamNotFound)
+
+	valid := []string{"platform", "mobile"}
+	settings, err := svc.UpdateTeamSettings(ctx, "backend", TeamSettingsUpdate{FallbackTeams: &valid})
+	require.NoError(t, err)
+	require.Equal(t, []string{"platform", "mobile"}, settings.FallbackTeams)
+}
+
+func TestService_CreatePullRequest_SkipsSaturatedReviewers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cfg := testConfig()
+	cfg.Reviewers.DefaultMaxOpenReviews = 2
+	var capturedReviewers []string
+	var requestedStats []string
+	fake := &fakeRepo{
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			return domain.User{ID: userID, TeamName: "backend", IsActive: true}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			return []domain.User{{ID: "u2"}, {ID: "u3", MaxOpenReviews: 5}, {ID: "u4"}}, nil
+		},
+		fetchUserAssignmentStatsFn: func(ctx context.Context, ids []string) ([]domain.UserAssignmentStat, error) {
+			requestedStats = ids
+			return []domain.UserAssignmentStat{
+				{UserID: "u2", ActivePRs: 2},
+				{UserID: "u3", ActivePRs: 4},
+				{UserID: "u4", ActivePRs: 1},
+			}, nil
+		},
+		createPullRequestFn: func(ctx context.Context, pr domain.PullRequest, reviewers []string) (domain.PullRequest, error) {
+			capturedReviewers = reviewers
+			return pr, nil
+		},
+	}
+
+	svc := New(fake, cfg, stubManager{}, stubRandomizer{})
+	_, err := svc.CreatePullRequest(ctx, "pr-1", "Feature", "u1", false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"u2", "u3", "u4"}, requestedStats)
+	// u2 упёрся в лимит команды, у u3 персональный лимит выше
+	require.ElementsMatch(t, []string{"u3", "u4"}, capturedReviewers)
+}
+
+func TestService_PickReviewers_Saturation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	limits := map[string]int{"backend": 1}
+	fake := &fakeRepo{
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			return domain.User{ID: userID, TeamName: "backend", IsActive: true}, nil
+		},
+		getTeamSettingsFn: func(ctx context.Context, name string) (domain.TeamSettings, error) {
+			settings := domain.TeamSettings{TeamName: name, MaxOpenReviews: limits[name]}
+			if name == "backend" {
+				settings.FallbackTeams = []string{"platform"}
+			}
+			return settings, nil
+		},
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, Status: domain.PRStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			if teamName == "platform" {
+				return []domain.User{{ID: "p1", TeamName: teamName}}, nil
+			}
+			return []domain.User{{ID: "u3", TeamName: teamName}}, nil
+		},
+		fetchUserAssignmentStatsFn: func(ctx context.Context, ids []string) ([]domain.UserAssignmentStat, error) {
+			stats := make([]domain.UserAssignmentStat, 0, len(ids))
+			for _, id := range ids {
+				stats = append(stats, domain.UserAssignmentStat{UserID: id, ActivePRs: 1})
+			}
+			return stats, nil
+		},
+		replaceReviewerFn: func(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error) {
+			return domain.PullRequest{ID: prID, AssignedReviewers: []string{newReviewer}}, newReviewer, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	// В резервной команде лимита нет, поэтому переназначение уходит туда
+	_, replacedBy, err := svc.ReassignReviewer(ctx, "pr-1", "u2", "")
+	require.NoError(t, err)
+	require.Equal(t, "p1", replacedBy)
+
+	limits["platform"] = 1
+	_, _, err = svc.ReassignReviewer(ctx, "pr-1", "u2", "")
+	require.ErrorIs(t, err, domain.ErrAllSaturated)
+
+	_, err = svc.CreatePullRequest(ctx, "pr-2", "Feature", "u1", false)
+	require.ErrorIs(t, err, domain.ErrAllSaturated)
+}
+
+func TestService_MassDeactivate_FreesSlotWhenSaturated(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var replacedWith *string
+	fake := &fakeRepo{
+		getTeamFn: func(ctx context.Context, name string) (domain.Team, error) {
+			return domain.Team{Name: name, Members: []domain.User{{ID: "u2", TeamName: name, IsActive: true}}}, nil
+		},
+		getTeamSettingsFn: func(ctx context.Context, name string) (domain.TeamSettings, error) {
+			return domain.TeamSettings{TeamName: name, MaxOpenReviews: 1}, nil
+		},
+		listOpenPRsByReviewerFn: func(ctx context.Context, reviewerIDs []string) (map[string][]string, error) {
+			return map[string][]string{"u2": {"pr-1"}}, nil
+		},
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, Status: domain.PRStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			return []domain.User{{ID: "u3"}}, nil
+		},
+		fetchUserAssignmentStatsFn: func(ctx context.Context, ids []string) ([]domain.UserAssignmentStat, error) {
+			return []domain.UserAssignmentStat{{UserID: "u3", ActivePRs: 3}}, nil
+		},
+		replaceReviewerFn: func(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error) {
+			replacedWith = &newReviewer
+			return domain.PullRequest{}, newReviewer, nil
+		},
+	}
+
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+	result, err := svc.MassDeactivate(ctx, MassDeactivateInput{TeamName: "backend", UserIDs: []string{"u2"}})
+	require.NoError(t, err)
+	require.Empty(t, result.Skipped)
+	require.Equal(t, map[string][]string{"u2": {"pr-1"}}, result.RemovedWithoutReplacement)
+	require.NotNil(t, replacedWith)
+	require.Empty(t, *replacedWith)
+}
+
+func TestService_UpdateTeamSettings_MaxOpenReviews(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cfg := testConfig()
+	cfg.Reviewers.DefaultMaxOpenReviews = 6
+	fake := &fakeRepo{
+		updateTeamSettingsFn: func(ctx context.Context, settings domain.TeamSettings) (domain.TeamSettings, error) {
+			return settings, nil
+		},
+	}
+	svc := New(fake, cfg, stubManager{}, stubRandomizer{})
+
+	negative := -1
+	_, err := svc.UpdateTeamSettings(ctx, "backend", TeamSettingsUpdate{MaxOpenReviews: &negative})
+	require.Error(t, err)
+
+	limit := 3
+	settings, err := svc.UpdateTeamSettings(ctx, "backend", TeamSettingsUpdate{MaxOpenReviews: &limit})
+	require.NoError(t, err)
+	require.Equal(t, 3, settings.MaxOpenReviews)
+
+	reset := 0
+	settings, err = svc.UpdateTeamSettings(ctx, "backend", TeamSettingsUpdate{MaxOpenReviews: &reset})
+	require.NoError(t, err)
+	require.Equal(t, 6, settings.MaxOpenReviews)
+
+	_, err = svc.SetUserMaxOpenReviews(ctx, "u1", -2)
+	require.Error(t, err)
+}
+
+// sequenceRandomizer возвращает заранее заданные значения Intn и запоминает переданные границы.
+type sequenceRandomizer struct {
+	values []int
+	bounds []int
+}
+
+func (r *sequenceRandomizer) Shuffle(n int, swap func(i, j int)) {}
+
+func (r *sequenceRandomizer) Intn(n int) int {
+	r.bounds = append(r.bounds, n)
+	v := r.values[0]
+	r.values = r.values[1:]
+	return v
+}
+
+// reverseRandomizer детерминированно переворачивает последовательность при Shuffle.
+type reverseRandomizer struct{}
+
+func (reverseRandomizer) Shuffle(n int, swap func(i, j int)) {
+	for i := 0; i < n/2; i++ {
+		swap(i, n-1-i)
+	}
+}
+
+func (reverseRandomizer) Intn(n int) int { return 0 }
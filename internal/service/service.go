@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	trm "github.com/avito-tech/go-transaction-manager/trm/v2"
@@ -19,6 +22,8 @@ const (
 	DefaultOperationTimeout = 30 * time.Second
 	// DefaultLongOperationTimeout таймаут по умолчанию для длительных операций
 	DefaultLongOperationTimeout = 60 * time.Second
+	// DefaultRequiredReviewers количество ревьюверов на PR, если оно не задано ни для команды, ни в конфигурации
+	DefaultRequiredReviewers = 2
 )
 
 // Repository описывает операции, которые требуются сервису.
@@ -33,6 +38,9 @@ type Service struct {
 	cfg        config.Config
 	trMgr      trm.Manager
 	randomizer randomizer.Randomizer
+
+	selectorsMu sync.RWMutex
+	selectors   map[domain.ReviewerStrategy]ReviewerSelector
 }
 
 func New(repo Repository, cfg config.Config, trMgr trm.Manager, randomizer randomizer.Randomizer) *Service {
@@ -42,6 +50,18 @@ func New(repo Repository, cfg config.Config, trMgr trm.Manager, randomizer rando
 		trMgr:      trMgr,
 		randomizer: randomizer,
 	}
+	// Встроенные стратегии выбора ревьюверов
+	svc.selectors = map[domain.ReviewerStrategy]ReviewerSelector{
+		domain.ReviewerStrategyRandom:      randomSelector{randomizer: randomizer},
+		domain.ReviewerStrategyLeastLoaded: leastLoadedSelector{repo: repo, randomizer: randomizer},
+		domain.ReviewerStrategyRoundRobin:  newRoundRobinSelector(),
+		domain.ReviewerStrategyWeighted: weightedSelector{
+			weights: func(teamName string) map[string]int {
+				return svc.cfg.Reviewers.Teams[teamName].Weights
+			},
+			randomizer: randomizer,
+		},
+	}
 	if svc.cfg.Timeouts.Operation <= 0 {
 		svc.cfg.Timeouts.Operation = DefaultOperationTimeout
 	}
@@ -66,13 +86,24 @@ func (s *Service) CreateTeam(ctx context.Context, team domain.Team) (domain.Team
 		if err := ValidateUserID(member.ID); err != nil {
 			return domain.Team{}, err
 		}
+		if err := ValidateRole(member.Role); err != nil {
+			return domain.Team{}, err
+		}
+	}
+	if err := ValidateRequiredReviewers(team.RequiredReviewers); err != nil {
+		return domain.Team{}, err
 	}
 	created, err := s.repo.CreateTeam(ctx, team)
-	if err == nil {
-		metrics.IncTeamsCreated()
-		metrics.AddUsersProcessed(len(created.Members))
+	if err != nil {
+		return domain.Team{}, err
 	}
-	return created, err
+	metrics.IncTeamsCreated()
+	metrics.AddUsersProcessed(len(created.Members))
+	created.RequiredReviewers = s.requiredReviewersFor(domain.TeamSettings{
+		TeamName:          team.Name,
+		RequiredReviewers: team.RequiredReviewers,
+	})
+	return created, nil
 }
 
 // GetTeam возвращает команду.
@@ -86,6 +117,152 @@ func (s *Service) GetTeam(ctx context.Context, name string) (domain.Team, error)
 	return s.repo.GetTeam(ctx, name)
 }
 
+// GetTeamSettings возвращает настройки команды с учётом значений из конфигурации.
+func (s *Service) GetTeamSettings(ctx context.Context, teamName string) (domain.TeamSettings, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidateTeamName(teamName); err != nil {
+		return domain.TeamSettings{}, err
+	}
+	settings, err := s.repo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return domain.TeamSettings{}, err
+	}
+	return s.resolveTeamSettings(settings), nil
+}
+
+// TeamSettingsUpdate описывает изменяемые настройки команды. Поля со значением nil не меняются.
+type TeamSettingsUpdate struct {
+	// ReviewerStrategy задаёт стратегию выбора ревьюверов; пустая строка возвращает значение из конфигурации.
+	ReviewerStrategy *domain.ReviewerStrategy
+	// RequiredReviewers задаёт количество ревьюверов на PR; 0 возвращает значение из конфигурации.
+	RequiredReviewers *int
+	// FallbackTeams задаёт резервные команды по порядку; пустой список возвращает значение из конфигурации.
+	FallbackTeams *[]string
+	// MaxOpenReviews задаёт лимит открытых ревью на участника; 0 возвращает значение из конфигурации.
+	MaxOpenReviews *int
+	// MergePolicy задаёт политику merge; nil оставляет её без изменений.
+	MergePolicy *MergePolicyUpdate
+	// ReviewSLA задаёт SLA на ревью; nil оставляет его без изменений.
+	ReviewSLA *ReviewSLAUpdate
+}
+
+// MergePolicyUpdate описывает изменяемые поля политики merge. Поля со значением nil не меняются.
+type MergePolicyUpdate struct {
+	// RequiredApprovals задаёт количество одобрений; 0 возвращает значение из конфигурации.
+	RequiredApprovals *int
+	// BlockOnChangesRequested задаёт запрет merge, пока есть решение CHANGES_REQUESTED.
+	BlockOnChangesRequested *bool
+	// RequiredRole задаёт роль обязательного одобряющего; пустая строка возвращает значение из конфигурации.
+	RequiredRole *string
+}
+
+// ReviewSLAUpdate описывает изменяемые поля SLA на ревью. Поля со значением nil не меняются.
+type ReviewSLAUpdate struct {
+	// Hours задаёт SLA в часах; 0 возвращает значение из конфигурации.
+	Hours *int
+	// Escalation задаёт действие при нарушении SLA; пустая строка возвращает значение из конфигурации.
+	Escalation *domain.SLAEscalation
+}
+
+// UpdateTeamSettings частично обновляет настройки команды.
+func (s *Service) UpdateTeamSettings(ctx context.Context, teamName string, update TeamSettingsUpdate) (domain.TeamSettings, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidateTeamName(teamName); err != nil {
+		return domain.TeamSettings{}, err
+	}
+	if update.ReviewerStrategy != nil && *update.ReviewerStrategy != "" {
+		if _, ok := s.selector(*update.ReviewerStrategy); !ok {
+			return domain.TeamSettings{}, domain.ErrUnknownStrategy
+		}
+	}
+	if update.RequiredReviewers != nil {
+		if err := ValidateRequiredReviewers(*update.RequiredReviewers); err != nil {
+			return domain.TeamSettings{}, err
+		}
+	}
+	if update.FallbackTeams != nil {
+		if err := ValidateFallbackTeams(teamName, *update.FallbackTeams); err != nil {
+			return domain.TeamSettings{}, err
+		}
+	}
+	if update.MaxOpenReviews != nil {
+		if err := ValidateMaxOpenReviews(*update.MaxOpenReviews); err != nil {
+			return domain.TeamSettings{}, err
+		}
+	}
+	if update.MergePolicy != nil {
+		if err := ValidateMergePolicyUpdate(*update.MergePolicy); err != nil {
+			return domain.TeamSettings{}, err
+		}
+	}
+	if update.ReviewSLA != nil {
+		if err := ValidateReviewSLAUpdate(*update.ReviewSLA); err != nil {
+			return domain.TeamSettings{}, err
+		}
+	}
+	settings, err := s.repo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return domain.TeamSettings{}, err
+	}
+	if update.FallbackTeams != nil {
+		// Резервные команды должны существовать
+		for _, name := range *update.FallbackTeams {
+			if _, err := s.repo.GetTeamSettings(ctx, name); err != nil {
+				return domain.TeamSettings{}, err
+			}
+		}
+		settings.FallbackTeams = *update.FallbackTeams
+	}
+	if update.MaxOpenReviews != nil {
+		settings.MaxOpenReviews = *update.MaxOpenReviews
+	}
+	if update.ReviewerStrategy != nil {
+		settings.ReviewerStrategy = *update.ReviewerStrategy
+	}
+	if update.RequiredReviewers != nil {
+		settings.RequiredReviewers = *update.RequiredReviewers
+	}
+	if policy := update.MergePolicy; policy != nil {
+		if policy.RequiredApprovals != nil {
+			settings.MergePolicy.RequiredApprovals = *policy.RequiredApprovals
+		}
+		if policy.BlockOnChangesRequested != nil {
+			settings.MergePolicy.BlockOnChangesRequested = policy.BlockOnChangesRequested
+		}
+		if policy.RequiredRole != nil {
+			settings.MergePolicy.RequiredRole = *policy.RequiredRole
+		}
+	}
+	if sla := update.ReviewSLA; sla != nil {
+		if sla.Hours != nil {
+			settings.ReviewSLA.Hours = *sla.Hours
+		}
+		if sla.Escalation != nil {
+			settings.ReviewSLA.Escalation = *sla.Escalation
+		}
+	}
+	updated, err := s.repo.UpdateTeamSettings(ctx, settings)
+	if err != nil {
+		return domain.TeamSettings{}, err
+	}
+	return s.resolveTeamSettings(updated), nil
+}
+
+// resolveTeamSettings подставляет значения из конфигурации вместо незаданных настроек команды.
+func (s *Service) resolveTeamSettings(settings domain.TeamSettings) domain.TeamSettings {
+	settings.ReviewerStrategy = s.strategyFor(settings)
+	settings.RequiredReviewers = s.requiredReviewersFor(settings)
+	settings.FallbackTeams = append([]string{}, s.fallbackTeamsFor(settings)...)
+	settings.MaxOpenReviews = s.maxOpenReviewsFor(settings)
+	settings.MergePolicy = s.mergePolicyFor(settings)
+	settings.ReviewSLA = s.reviewSLAFor(settings)
+	return settings
+}
+
 // SetUserActivity обновляет флаг активности.
 func (s *Service) SetUserActivity(ctx context.Context, userID string, active bool) (domain.User, error) {
 	ctx, cancel := s.shortOperationContext(ctx)
@@ -97,8 +274,38 @@ func (s *Service) SetUserActivity(ctx context.Context, userID string, active boo
 	return s.repo.SetUserActivity(ctx, userID, active)
 }
 
-// CreatePullRequest создаёт PR и автоматически назначает до 2 случайных ревьюверов из команды автора.
-func (s *Service) CreatePullRequest(ctx context.Context, prID, name, authorID string) (domain.PullRequest, error) {
+// SetUserRole задаёт роль пользователя, учитываемую политикой merge; пустая строка снимает роль.
+func (s *Service) SetUserRole(ctx context.Context, userID, role string) (domain.User, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidateUserID(userID); err != nil {
+		return domain.User{}, err
+	}
+	if err := ValidateRole(role); err != nil {
+		return domain.User{}, err
+	}
+	return s.repo.SetUserRole(ctx, userID, role)
+}
+
+// SetUserMaxOpenReviews задаёт персональный лимит открытых ревью; 0 возвращает значение команды.
+func (s *Service) SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidateUserID(userID); err != nil {
+		return domain.User{}, err
+	}
+	if err := ValidateMaxOpenReviews(maxOpenReviews); err != nil {
+		return domain.User{}, err
+	}
+	return s.repo.SetUserMaxOpenReviews(ctx, userID, maxOpenReviews)
+}
+
+// CreatePullRequest создаёт PR и автоматически назначает ревьюверов из команды автора
+// согласно стратегии выбора команды. Количество ревьюверов определяется настройкой required_reviewers.
+// Черновик (draft) создаётся без ревьюверов: они назначаются, когда PR отмечен готовым к ревью.
+func (s *Service) CreatePullRequest(ctx context.Context, prID, name, authorID string, draft bool) (domain.PullRequest, error) {
 	ctx, cancel := s.shortOperationContext(ctx)
 	defer cancel()
 
@@ -115,13 +322,6 @@ func (s *Service) CreatePullRequest(ctx context.Context, prID, name, authorID st
 	if err != nil {
 		return domain.PullRequest{}, err
 	}
-	// Исключаем автора из списка кандидатов на ревью
-	candidates, err := s.repo.ListActiveTeamMembers(ctx, author.TeamName, []string{author.ID})
-	if err != nil {
-		return domain.PullRequest{}, err
-	}
-	// Выбираем случайных ревьюверов (до 2 штук)
-	reviewers := pickRandomIDs(candidates, 2, s.randomizer)
 	pr := domain.PullRequest{
 		ID:        prID,
 		Name:      name,
@@ -129,14 +329,122 @@ func (s *Service) CreatePullRequest(ctx context.Context, prID, name, authorID st
 		Status:    domain.PRStatusOpen,
 		CreatedAt: time.Now(),
 	}
+	var reviewers []string
+	if draft {
+		pr.Status = domain.PRStatusDraft
+	} else {
+		reviewers, err = s.initialReviewers(ctx, author)
+		if err != nil {
+			return domain.PullRequest{}, err
+		}
+	}
 	created, err := s.repo.CreatePullRequest(ctx, pr, reviewers)
 	if err == nil {
-		metrics.IncPullRequestsCreated()
+		metrics.IncPullRequestsCreated(author.TeamName)
 	}
 	return created, err
 }
 
-// MergePullRequest помечает PR как MERGED.
+// initialReviewers выбирает ревьюверов для PR, переходящего на ревью, стратегией команды автора
+// в количестве, требуемом командой, исключая автора. Если в команде нет кандидатов,
+// используются резервные команды.
+func (s *Service) initialReviewers(ctx context.Context, author domain.User) ([]string, error) {
+	settings, err := s.teamSettings(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	return s.pickReviewers(ctx, settings, []string{author.ID}, s.requiredReviewersFor(settings))
+}
+
+// MarkReadyForReview переводит черновик PR в статус OPEN и назначает ревьюверов так же,
+// как при создании PR. Для уже открытого PR операция ничего не меняет.
+func (s *Service) MarkReadyForReview(ctx context.Context, prID string) (domain.PullRequest, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidatePRID(prID); err != nil {
+		return domain.PullRequest{}, err
+	}
+	pr, err := s.repo.GetPullRequest(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	if pr.Status != domain.PRStatusDraft {
+		return pr, domain.RequireOpenStatus(pr.Status)
+	}
+	author, err := s.repo.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	reviewers, err := s.initialReviewers(ctx, author)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	ready, err := s.repo.MarkPRReady(ctx, prID, reviewers)
+	if err == nil {
+		metrics.IncPullRequestStatusChanges(domain.PRStatusOpen)
+	}
+	return ready, err
+}
+
+// ClosePullRequest закрывает PR без merge и снимает назначенных ревьюверов.
+// Повторное закрытие ничего не меняет; смерженный PR закрыть нельзя.
+func (s *Service) ClosePullRequest(ctx context.Context, prID string) (domain.PullRequest, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidatePRID(prID); err != nil {
+		return domain.PullRequest{}, err
+	}
+	pr, err := s.repo.GetPullRequest(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	switch pr.Status {
+	case domain.PRStatusClosed:
+		return pr, nil
+	case domain.PRStatusMerged:
+		return domain.PullRequest{}, domain.ErrPRMerged
+	}
+	closed, err := s.repo.ClosePullRequest(ctx, prID)
+	if err == nil {
+		metrics.IncPullRequestStatusChanges(domain.PRStatusClosed)
+	}
+	return closed, err
+}
+
+// ReopenPullRequest переоткрывает закрытый PR и заново назначает ревьюверов так же,
+// как при создании PR. Для уже открытого PR операция ничего не меняет.
+func (s *Service) ReopenPullRequest(ctx context.Context, prID string) (domain.PullRequest, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidatePRID(prID); err != nil {
+		return domain.PullRequest{}, err
+	}
+	pr, err := s.repo.GetPullRequest(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	if pr.Status != domain.PRStatusClosed {
+		return pr, domain.RequireOpenStatus(pr.Status)
+	}
+	author, err := s.repo.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	reviewers, err := s.initialReviewers(ctx, author)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	reopened, err := s.repo.ReopenPullRequest(ctx, prID, reviewers)
+	if err == nil {
+		metrics.IncPullRequestStatusChanges(domain.PRStatusOpen)
+	}
+	return reopened, err
+}
+
+// MergePullRequest помечает PR как MERGED, если выполнены условия политики merge команды автора.
 func (s *Service) MergePullRequest(ctx context.Context, prID string) (domain.PullRequest, error) {
 	ctx, cancel := s.shortOperationContext(ctx)
 	defer cancel()
@@ -144,12 +452,71 @@ func (s *Service) MergePullRequest(ctx context.Context, prID string) (domain.Pul
 	if err := ValidatePRID(prID); err != nil {
 		return domain.PullRequest{}, err
 	}
-	return s.repo.UpdatePRStatus(ctx, prID, domain.PRStatusMerged)
+	// Строка PR блокируется до фиксации: ревьюверы и решения, по которым проверяется политика,
+	// не могут измениться между проверкой и merge
+	var merged domain.PullRequest
+	alreadyMerged := false
+	err := s.trMgr.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockPullRequest(ctx, prID); err != nil {
+			return err
+		}
+		pr, err := s.repo.GetPullRequest(ctx, prID)
+		if err != nil {
+			return err
+		}
+		// Уже смерженный PR возвращается без проверки политики, чтобы merge оставался идемпотентным
+		alreadyMerged = pr.Status == domain.PRStatusMerged
+		if !alreadyMerged {
+			// Черновик и закрытый PR смержить нельзя
+			if err := domain.RequireOpenStatus(pr.Status); err != nil {
+				return err
+			}
+			if err := s.checkMergePolicy(ctx, pr); err != nil {
+				return err
+			}
+		}
+		merged, err = s.repo.UpdatePRStatus(ctx, prID, domain.PRStatusMerged)
+		return err
+	})
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	if !alreadyMerged {
+		metrics.IncPullRequestStatusChanges(domain.PRStatusMerged)
+		s.observeMerge(ctx, merged)
+	}
+	return merged, nil
 }
 
-// ReassignReviewer переназначает ревьювера на случайного активного участника из той же команды.
+// SubmitReview сохраняет решение назначенного ревьювера по открытому PR.
+// Повторное решение того же ревьювера заменяет предыдущее.
+func (s *Service) SubmitReview(ctx context.Context, prID, reviewerID string, decision domain.ReviewDecision) (domain.PullRequest, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidatePRID(prID); err != nil {
+		return domain.PullRequest{}, err
+	}
+	if err := ValidateUserID(reviewerID); err != nil {
+		return domain.PullRequest{}, err
+	}
+	if err := ValidateReviewDecision(decision); err != nil {
+		return domain.PullRequest{}, err
+	}
+	pr, err := s.repo.SubmitReview(ctx, prID, reviewerID, decision)
+	if err == nil {
+		metrics.IncReviewsSubmitted()
+		s.observeDecisionLatency(ctx, prID, reviewerID)
+	}
+	return pr, err
+}
+
+// ReassignReviewer переназначает ревьювера на активного участника команды автора PR, выбранного
+// стратегией этой команды (как при создании PR), а если кандидатов нет — из её резервных команд.
+// Участники, достигшие лимита открытых ревью, не рассматриваются.
 // Исключает автора PR и всех уже назначенных ревьюверов.
-func (s *Service) ReassignReviewer(ctx context.Context, prID, oldReviewer string) (domain.PullRequest, string, error) {
+// Если newReviewer задан, ревью передаётся ему без выбора стратегией (см. checkDelegate).
+func (s *Service) ReassignReviewer(ctx context.Context, prID, oldReviewer, newReviewer string) (domain.PullRequest, string, error) {
 	ctx, cancel := s.shortOperationContext(ctx)
 	defer cancel()
 
@@ -159,13 +526,18 @@ func (s *Service) ReassignReviewer(ctx context.Context, prID, oldReviewer string
 	if err := ValidateUserID(oldReviewer); err != nil {
 		return domain.PullRequest{}, "", err
 	}
+	if newReviewer != "" {
+		if err := ValidateUserID(newReviewer); err != nil {
+			return domain.PullRequest{}, "", err
+		}
+	}
 	pr, err := s.repo.GetPullRequest(ctx, prID)
 	if err != nil {
 		return domain.PullRequest{}, "", err
 	}
-	// Нельзя переназначать ревьюверов для уже смерженных PR
-	if pr.Status == domain.PRStatusMerged {
-		return domain.PullRequest{}, "", domain.ErrPRMerged
+	// Переназначать ревьюверов можно только у открытых PR
+	if err := domain.RequireOpenStatus(pr.Status); err != nil {
+		return domain.PullRequest{}, "", err
 	}
 	// Проверяем, что старый ревьювер действительно назначен на PR
 	var assigned bool
@@ -178,50 +550,167 @@ func (s *Service) ReassignReviewer(ctx context.Context, prID, oldReviewer string
 	if !assigned {
 		return domain.PullRequest{}, "", domain.ErrReviewerAbsent
 	}
-	oldUser, err := s.repo.GetUserByID(ctx, oldReviewer)
+	settings, err := s.authorTeamSettings(ctx, pr)
 	if err != nil {
 		return domain.PullRequest{}, "", err
 	}
-	// Исключаем старого ревьювера, автора и всех остальных назначенных ревьюверов
-	exclude := append([]string{oldReviewer, pr.AuthorID}, pr.AssignedReviewers...)
-	candidates, err := s.repo.ListActiveTeamMembers(ctx, oldUser.TeamName, uniqueIDs(exclude))
+	source := "MANUAL_REASSIGN"
+	if newReviewer != "" {
+		// Ревьювер выбран вручную
+		if err := s.checkDelegate(ctx, pr, settings, newReviewer); err != nil {
+			return domain.PullRequest{}, "", err
+		}
+		source = "MANUAL_DELEGATE"
+	} else {
+		// Исключаем старого ревьювера, автора и всех остальных назначенных ревьюверов.
+		// Выбираем нового ревьювера стратегией команды, при отсутствии кандидатов — из резервных команд
+		exclude := append([]string{oldReviewer, pr.AuthorID}, pr.AssignedReviewers...)
+		selected, err := s.pickReviewers(ctx, settings, uniqueIDs(exclude), 1)
+		if err != nil {
+			return domain.PullRequest{}, "", err
+		}
+		// Если нет доступных кандидатов ни в команде, ни в резервных командах, возвращаем ошибку
+		if len(selected) == 0 {
+			return domain.PullRequest{}, "", domain.ErrNoCandidate
+		}
+		newReviewer = selected[0]
+	}
+	updated, replacedBy, err := s.repo.ReplaceReviewer(ctx, prID, oldReviewer, newReviewer, source)
+	if err == nil {
+		s.observeReassignment(ctx, pr, source)
+	}
+	return updated, replacedBy, err
+}
+
+// AddReviewer назначает на открытый PR дополнительного ревьювера, не снимая уже назначенных.
+// Если reviewerID не задан, ревьювер выбирается стратегией команды автора так же, как при создании PR,
+// иначе проверяется по тем же правилам, что и при ручном переназначении (см. checkDelegate).
+// Возвращает обновлённый PR и user_id назначенного ревьювера.
+func (s *Service) AddReviewer(ctx context.Context, prID, reviewerID string) (domain.PullRequest, string, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidatePRID(prID); err != nil {
+		return domain.PullRequest{}, "", err
+	}
+	if reviewerID != "" {
+		if err := ValidateUserID(reviewerID); err != nil {
+			return domain.PullRequest{}, "", err
+		}
+	}
+	pr, err := s.repo.GetPullRequest(ctx, prID)
 	if err != nil {
 		return domain.PullRequest{}, "", err
 	}
-	// Если нет доступных кандидатов в команде, возвращаем ошибку
-	if len(candidates) == 0 {
-		return domain.PullRequest{}, "", domain.ErrNoCandidate
+	if err := domain.RequireOpenStatus(pr.Status); err != nil {
+		return domain.PullRequest{}, "", err
 	}
-	// Выбираем случайного нового ревьювера
-	newReviewer := pickRandomIDs(candidates, 1, s.randomizer)
-	pr, replacedBy, err := s.repo.ReplaceReviewer(ctx, prID, oldReviewer, newReviewer[0], "MANUAL_REASSIGN")
-	if err == nil {
-		metrics.IncReassignments()
+	settings, err := s.authorTeamSettings(ctx, pr)
+	if err != nil {
+		return domain.PullRequest{}, "", err
 	}
-	return pr, replacedBy, err
+	source := "MANUAL_ADD"
+	if reviewerID != "" {
+		if err := s.checkDelegate(ctx, pr, settings, reviewerID); err != nil {
+			return domain.PullRequest{}, "", err
+		}
+	} else {
+		// Исключаем автора и всех уже назначенных ревьюверов
+		exclude := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+		selected, err := s.pickReviewers(ctx, settings, uniqueIDs(exclude), 1)
+		if err != nil {
+			return domain.PullRequest{}, "", err
+		}
+		if len(selected) == 0 {
+			return domain.PullRequest{}, "", domain.ErrNoCandidate
+		}
+		reviewerID, source = selected[0], "AUTO_ADD"
+	}
+	updated, err := s.repo.AddReviewers(ctx, prID, []string{reviewerID}, source)
+	if err != nil {
+		return domain.PullRequest{}, "", err
+	}
+	return updated, reviewerID, nil
 }
 
-// ListReviewAssignments возвращает PR пользователя.
-func (s *Service) ListReviewAssignments(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+// RemoveReviewer снимает ревьювера с открытого PR без замены.
+func (s *Service) RemoveReviewer(ctx context.Context, prID, reviewerID string) (domain.PullRequest, error) {
 	ctx, cancel := s.shortOperationContext(ctx)
 	defer cancel()
 
-	if err := ValidateUserID(userID); err != nil {
-		return nil, err
+	if err := ValidatePRID(prID); err != nil {
+		return domain.PullRequest{}, err
+	}
+	if err := ValidateUserID(reviewerID); err != nil {
+		return domain.PullRequest{}, err
+	}
+	pr, _, err := s.repo.ReplaceReviewer(ctx, prID, reviewerID, "", "MANUAL_REMOVE")
+	return pr, err
+}
+
+// checkDelegate проверяет, что пользователя можно назначить ревьювером PR по тем же правилам,
+// что и при автоматическом выборе: пользователь активен, состоит в команде settings
+// или в одной из её резервных команд, не является автором и ещё не назначен на PR.
+// Лимит открытых ревью не проверяется: выбор конкретного коллеги — осознанное решение.
+func (s *Service) checkDelegate(ctx context.Context, pr domain.PullRequest, settings domain.TeamSettings, userID string) error {
+	if userID == pr.AuthorID || slices.Contains(pr.AssignedReviewers, userID) {
+		return domain.ErrReviewerNotEligible
 	}
-	_, err := s.repo.GetUserByID(ctx, userID)
+	user, err := s.repo.GetUserByID(ctx, userID)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if !user.IsActive {
+		return domain.ErrReviewerNotEligible
 	}
-	return s.repo.ListReviewAssignments(ctx, userID)
+	if user.TeamName != settings.TeamName && !slices.Contains(s.fallbackTeamsFor(settings), user.TeamName) {
+		return domain.ErrReviewerNotEligible
+	}
+	return nil
 }
 
-// Stats возвращает агрегаты.
-func (s *Service) Stats(ctx context.Context) (domain.AssignmentStats, error) {
+// Stats возвращает агрегаты назначений, ограниченные фильтром.
+func (s *Service) Stats(ctx context.Context, filter domain.StatsFilter) (domain.AssignmentStats, error) {
 	ctx, cancel := s.shortOperationContext(ctx)
 	defer cancel()
 
-	return s.repo.FetchAssignmentStats(ctx)
+	if err := ValidateStatsFilter(filter); err != nil {
+		return domain.AssignmentStats{}, err
+	}
+	return s.repo.FetchAssignmentStats(ctx, filter)
+}
+
+// ExportUserStats передаёт в fn строки раздела per_user статистики назначений, не собирая их в памяти.
+func (s *Service) ExportUserStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.UserAssignmentStat) error) error {
+	ctx, cancel := s.longOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidateStatsFilter(filter); err != nil {
+		return err
+	}
+	return s.repo.StreamUserAssignmentStats(ctx, filter, fn)
+}
+
+// ExportPullRequestStats передаёт в fn строки раздела per_pull_request статистики назначений.
+func (s *Service) ExportPullRequestStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.PRAssignmentStat) error) error {
+	ctx, cancel := s.longOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidateStatsFilter(filter); err != nil {
+		return err
+	}
+	return s.repo.StreamPullRequestStats(ctx, filter, fn)
+}
+
+// ExportSourceStats передаёт в fn строки раздела by_source статистики назначений.
+func (s *Service) ExportSourceStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.SourceAssignmentStat) error) error {
+	ctx, cancel := s.longOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidateStatsFilter(filter); err != nil {
+		return err
+	}
+	return s.repo.StreamSourceStats(ctx, filter, fn)
 }
 
 // MassDeactivateInput описывает вход для массовой деактивации.
@@ -233,8 +722,11 @@ type MassDeactivateInput struct {
 // MassDeactivateResult содержит результат операции.
 type MassDeactivateResult struct {
 	Deactivated []domain.User       `json:"deactivated"`
-	Reassigned  map[string][]string `json:"reassignments"` // userID -> список PR
-	Skipped     map[string]string   `json:"skipped"`       // userID -> причина
+	Reassigned  map[string][]string `json:"reassignments"` // userID -> список PR, где назначена замена
+	// userID -> список PR, где ревьювер снят без замены: на PR достаточно ревьюверов, все кандидаты
+	// достигли лимита открытых ревью или кандидатов нет (тогда причина также попадает в Skipped)
+	RemovedWithoutReplacement map[string][]string `json:"removed_without_replacement"`
+	Skipped                   map[string]string   `json:"skipped"` // userID -> причина, по которой замена не найдена
 }
 
 // MassDeactivate деактивирует пользователей команды и безопасно переназначает их PR на других ревьюверов.
@@ -291,9 +783,10 @@ func (s *Service) MassDeactivate(ctx context.Context, input MassDeactivateInput)
 	if len(targetIDs) == 0 {
 		return MassDeactivateResult{}, nil
 	}
-
 	// Выполняем всю операцию в одной транзакции через transaction manager
 	var result MassDeactivateResult
+	// PR с заменой учитываются в метриках только после фиксации транзакции
+	var reassigned []domain.PullRequest
 	err = s.trMgr.Do(ctx, func(ctx context.Context) error {
 		// Деактивируем пользователей
 		deactivated, err := s.repo.DeactivateUsers(ctx, targetIDs)
@@ -308,10 +801,12 @@ func (s *Service) MassDeactivate(ctx context.Context, input MassDeactivateInput)
 		}
 
 		result = MassDeactivateResult{
-			Deactivated: deactivated,
-			Reassigned:  map[string][]string{},
-			Skipped:     map[string]string{},
+			Deactivated:               deactivated,
+			Reassigned:                map[string][]string{},
+			RemovedWithoutReplacement: map[string][]string{},
+			Skipped:                   map[string]string{},
 		}
+		reassigned = nil
 
 		// Переназначаем ревьюверов для каждого PR деактивированного пользователя
 		for _, userID := range targetIDs {
@@ -320,28 +815,51 @@ func (s *Service) MassDeactivate(ctx context.Context, input MassDeactivateInput)
 				continue
 			}
 			for _, prID := range prList {
+				// Любая ошибка откатывает всю операцию, чтобы пользователи не остались деактивированными
+				// с частично переназначенными ревью
 				prItem, err := s.repo.GetPullRequest(ctx, prID)
 				if err != nil {
-					result.Skipped[userID] = err.Error()
-					break
+					return err
+				}
+				// Замена выбирается по настройкам команды автора PR, как при переназначении
+				settings, err := s.authorTeamSettings(ctx, prItem)
+				if err != nil {
+					return err
 				}
 				// Исключаем деактивированного пользователя, автора и всех остальных ревьюверов
 				exclude := append([]string{userID, prItem.AuthorID}, prItem.AssignedReviewers...)
-				candidates, err := s.repo.ListActiveTeamMembers(ctx, team.Name, uniqueIDs(exclude))
+				// Добираем ревьюверов до количества, требуемого командой, с учётом оставшихся на PR.
+				// Замена подбирается до записи. Если ревьюверов на PR и так достаточно, все кандидаты достигли
+				// лимита или кандидатов нет ни в команде, ни в резервных командах, ревьювер снимается без замены
+				// и PR попадает в RemovedWithoutReplacement; отсутствие кандидатов дополнительно отражается в Skipped.
+				need := s.requiredReviewersFor(settings) - (len(prItem.AssignedReviewers) - 1)
+				selected, err := s.pickReviewers(ctx, settings, uniqueIDs(exclude), need)
+				// Если все кандидаты достигли лимита открытых ревью, слот освобождается без замены
+				if errors.Is(err, domain.ErrAllSaturated) {
+					selected, err = nil, nil
+				} else if err == nil && need > 0 && len(selected) == 0 {
+					result.Skipped[userID] = domain.ErrNoCandidate.Error()
+				}
 				if err != nil {
-					result.Skipped[userID] = err.Error()
-					break
+					return err
 				}
-				// Если есть кандидаты, выбираем случайного; иначе оставляем PR без ревьювера
 				var newReviewer string
-				if len(candidates) > 0 {
-					newReviewer = pickRandomIDs(candidates, 1, s.randomizer)[0]
+				if len(selected) > 0 {
+					newReviewer = selected[0]
 				}
 				if _, _, err := s.repo.ReplaceReviewer(ctx, prID, userID, newReviewer, "TEAM_DEACTIVATION"); err != nil {
-					result.Skipped[userID] = err.Error()
-					break
+					return err
 				}
-				metrics.IncReassignments()
+				if len(selected) > 1 {
+					if _, err := s.repo.AddReviewers(ctx, prID, selected[1:], "TEAM_DEACTIVATION"); err != nil {
+						return err
+					}
+				}
+				if newReviewer == "" {
+					result.RemovedWithoutReplacement[userID] = append(result.RemovedWithoutReplacement[userID], prID)
+					continue
+				}
+				reassigned = append(reassigned, prItem)
 				result.Reassigned[userID] = append(result.Reassigned[userID], prID)
 			}
 		}
@@ -355,6 +873,9 @@ func (s *Service) MassDeactivate(ctx context.Context, input MassDeactivateInput)
 	if err != nil {
 		return MassDeactivateResult{}, err
 	}
+	for _, pr := range reassigned {
+		s.observeReassignment(ctx, pr, "TEAM_DEACTIVATION")
+	}
 
 	return result, nil
 }
@@ -12,7 +12,6 @@ import (
 	"pr-reviewer-service_Avito/internal/config"
 	"pr-reviewer-service_Avito/internal/domain"
 	randomizerpkg "pr-reviewer-service_Avito/internal/infrastructure/randomizer"
-	"pr-reviewer-service_Avito/internal/repository"
 )
 
 func TestService_CreatePullRequest_AssignsTwoReviewers(t *testing.T) {
@@ -43,7 +42,7 @@ func TestService_CreatePullRequest_AssignsTwoReviewers(t *testing.T) {
 	}
 
 	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
-	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add feature", "u1")
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add feature", "u1", false)
 	require.NoError(t, err)
 	require.Equal(t, "pr-1", pr.ID)
 	require.Equal(t, "u1", pr.AuthorID)
@@ -51,6 +50,104 @@ func TestService_CreatePullRequest_AssignsTwoReviewers(t *testing.T) {
 	require.ElementsMatch(t, []string{"u2", "u3"}, capturedReviewers)
 }
 
+func TestService_CreatePullRequest_DraftSkipsReviewers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	fake := &fakeRepo{
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			return domain.User{ID: userID, TeamName: "backend", IsActive: true}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			t.Fatal("reviewers must not be selected for a draft")
+			return nil, nil
+		},
+		createPullRequestFn: func(ctx context.Context, pr domain.PullRequest, reviewers []string) (domain.PullRequest, error) {
+			require.Empty(t, reviewers)
+			return pr, nil
+		},
+	}
+
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add feature", "u1", true)
+	require.NoError(t, err)
+	require.Equal(t, domain.PRStatusDraft, pr.Status)
+}
+
+func TestService_MarkReadyForReview_AssignsReviewers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var capturedReviewers []string
+	fake := &fakeRepo{
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, Status: domain.PRStatusDraft, AuthorID: "u1"}, nil
+		},
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			return domain.User{ID: userID, TeamName: "backend", IsActive: true}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			require.ElementsMatch(t, []string{"u1"}, exclude)
+			return []domain.User{{ID: "u2"}, {ID: "u3"}}, nil
+		},
+		markPRReadyFn: func(ctx context.Context, prID string, reviewers []string) (domain.PullRequest, error) {
+			capturedReviewers = reviewers
+			return domain.PullRequest{ID: prID, Status: domain.PRStatusOpen, AssignedReviewers: reviewers}, nil
+		},
+	}
+
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+	pr, err := svc.MarkReadyForReview(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, domain.PRStatusOpen, pr.Status)
+	require.ElementsMatch(t, []string{"u2", "u3"}, capturedReviewers)
+}
+
+func TestService_PullRequestTransitions_RespectStatus(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	withStatus := func(status domain.PRStatus) *fakeRepo {
+		return &fakeRepo{
+			getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+				return domain.PullRequest{ID: prID, Status: status, AuthorID: "u1"}, nil
+			},
+			closePullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+				t.Fatal("pull request must not be closed")
+				return domain.PullRequest{}, nil
+			},
+			reopenPullRequestFn: func(ctx context.Context, prID string, reviewers []string) (domain.PullRequest, error) {
+				t.Fatal("pull request must not be reopened")
+				return domain.PullRequest{}, nil
+			},
+			updatePRStatusFn: func(ctx context.Context, prID string, status domain.PRStatus) (domain.PullRequest, error) {
+				t.Fatal("pull request must not be merged")
+				return domain.PullRequest{}, nil
+			},
+		}
+	}
+
+	_, err := New(withStatus(domain.PRStatusMerged), testConfig(), stubManager{}, stubRandomizer{}).ClosePullRequest(ctx, "pr-1")
+	require.ErrorIs(t, err, domain.ErrPRMerged)
+
+	pr, err := New(withStatus(domain.PRStatusClosed), testConfig(), stubManager{}, stubRandomizer{}).ClosePullRequest(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, domain.PRStatusClosed, pr.Status)
+
+	_, err = New(withStatus(domain.PRStatusDraft), testConfig(), stubManager{}, stubRandomizer{}).ReopenPullRequest(ctx, "pr-1")
+	require.ErrorIs(t, err, domain.ErrPRDraft)
+
+	pr, err = New(withStatus(domain.PRStatusOpen), testConfig(), stubManager{}, stubRandomizer{}).ReopenPullRequest(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, domain.PRStatusOpen, pr.Status)
+
+	_, err = New(withStatus(domain.PRStatusClosed), testConfig(), stubManager{}, stubRandomizer{}).MergePullRequest(ctx, "pr-1")
+	require.ErrorIs(t, err, domain.ErrPRClosed)
+
+	_, _, err = New(withStatus(domain.PRStatusDraft), testConfig(), stubManager{}, stubRandomizer{}).ReassignReviewer(ctx, "pr-1", "u2", "")
+	require.ErrorIs(t, err, domain.ErrPRDraft)
+}
+
 func TestService_ReassignReviewer_NoCandidate(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -73,10 +170,150 @@ func TestService_ReassignReviewer_NoCandidate(t *testing.T) {
 	}
 
 	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
-	_, _, err := svc.ReassignReviewer(ctx, "pr-1", "old")
+	_, _, err := svc.ReassignReviewer(ctx, "pr-1", "old", "")
 	require.ErrorIs(t, err, domain.ErrNoCandidate)
 }
 
+func TestService_ReassignReviewer_DelegatesToChosenReviewer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cfg := testConfig()
+	cfg.Reviewers.Teams = map[string]config.TeamReviewersConfig{"backend": {FallbackTeams: []string{"platform"}}}
+	users := map[string]domain.User{
+		"old":      {ID: "old", TeamName: "backend", IsActive: true},
+		"author":   {ID: "author", TeamName: "backend", IsActive: true},
+		"peer":     {ID: "peer", TeamName: "backend", IsActive: true},
+		"other":    {ID: "other", TeamName: "backend", IsActive: true},
+		"platform": {ID: "platform", TeamName: "platform", IsActive: true},
+		"inactive": {ID: "inactive", TeamName: "backend", IsActive: false},
+		"mobile":   {ID: "mobile", TeamName: "mobile", IsActive: true},
+	}
+	var source string
+	fake := &fakeRepo{
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{
+				ID:                prID,
+				Status:            domain.PRStatusOpen,
+				AuthorID:          "author",
+				AssignedReviewers: []string{"old", "other"},
+			}, nil
+		},
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			user, ok := users[userID]
+			if !ok {
+				return domain.User{}, domain.ErrUserNotFound
+			}
+			return user, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			t.Fatal("chosen reviewer must not be picked by strategy")
+			return nil, nil
+		},
+		replaceReviewerFn: func(ctx context.Context, prID, oldReviewer, newReviewer, src string) (domain.PullRequest, string, error) {
+			source = src
+			return domain.PullRequest{ID: prID, AssignedReviewers: []string{newReviewer, "other"}}, newReviewer, nil
+		},
+	}
+	svc := New(fake, cfg, stubManager{}, stubRandomizer{})
+
+	for _, id := range []string{"peer", "platform"} {
+		_, replacedBy, err := svc.ReassignReviewer(ctx, "pr-1", "old", id)
+		require.NoError(t, err)
+		require.Equal(t, id, replacedBy)
+		require.Equal(t, "MANUAL_DELEGATE", source)
+	}
+	for _, id := range []string{"author", "other", "old", "inactive", "mobile"} {
+		_, _, err := svc.ReassignReviewer(ctx, "pr-1", "old", id)
+		require.ErrorIs(t, err, domain.ErrReviewerNotEligible, id)
+	}
+	_, _, err := svc.ReassignReviewer(ctx, "pr-1", "old", "ghost")
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestService_AddReviewer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	users := map[string]domain.User{
+		"author":   {ID: "author", TeamName: "backend", IsActive: true},
+		"assigned": {ID: "assigned", TeamName: "backend", IsActive: true},
+		"peer":     {ID: "peer", TeamName: "backend", IsActive: true},
+		"mobile":   {ID: "mobile", TeamName: "mobile", IsActive: true},
+	}
+	status := domain.PRStatusOpen
+	var (
+		added  []string
+		source string
+	)
+	fake := &fakeRepo{
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{
+				ID:                prID,
+				Status:            status,
+				AuthorID:          "author",
+				AssignedReviewers: []string{"assigned"},
+			}, nil
+		},
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			user, ok := users[userID]
+			if !ok {
+				return domain.User{}, domain.ErrUserNotFound
+			}
+			return user, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			require.ElementsMatch(t, []string{"author", "assigned"}, exclude)
+			return []domain.User{users["peer"]}, nil
+		},
+		addReviewersFn: func(ctx context.Context, prID string, reviewers []string, src string) (domain.PullRequest, error) {
+			added, source = reviewers, src
+			return domain.PullRequest{ID: prID, AssignedReviewers: append([]string{"assigned"}, reviewers...)}, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	pr, reviewer, err := svc.AddReviewer(ctx, "pr-1", "")
+	require.NoError(t, err)
+	require.Equal(t, "peer", reviewer)
+	require.Equal(t, []string{"peer"}, added)
+	require.Equal(t, "AUTO_ADD", source)
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	_, reviewer, err = svc.AddReviewer(ctx, "pr-1", "peer")
+	require.NoError(t, err)
+	require.Equal(t, "peer", reviewer)
+	require.Equal(t, "MANUAL_ADD", source)
+
+	for _, id := range []string{"author", "assigned", "mobile"} {
+		_, _, err := svc.AddReviewer(ctx, "pr-1", id)
+		require.ErrorIs(t, err, domain.ErrReviewerNotEligible, id)
+	}
+
+	status = domain.PRStatusMerged
+	_, _, err = svc.AddReviewer(ctx, "pr-1", "peer")
+	require.ErrorIs(t, err, domain.ErrPRMerged)
+}
+
+func TestService_RemoveReviewer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	fake := &fakeRepo{
+		replaceReviewerFn: func(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error) {
+			require.Equal(t, "u1", oldReviewer)
+			require.Empty(t, newReviewer)
+			require.Equal(t, "MANUAL_REMOVE", source)
+			return domain.PullRequest{ID: prID}, "", nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	pr, err := svc.RemoveReviewer(ctx, "pr-1", "u1")
+	require.NoError(t, err)
+	require.Empty(t, pr.AssignedReviewers)
+}
+
 func TestService_MassDeactivate_ReassignsOpenPRs(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -126,6 +363,215 @@ func TestService_MassDeactivate_ReassignsOpenPRs(t *testing.T) {
 	require.Equal(t, []string{"pr-1"}, replaced["u2"])
 }
 
+func TestService_MassDeactivate_FailsWholeOperationOnError(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	fake := &fakeRepo{
+		getTeamFn: func(ctx context.Context, name string) (domain.Team, error) {
+			return domain.Team{Name: name, Members: []domain.User{{ID: "u2", TeamName: name, IsActive: true}}}, nil
+		},
+		listOpenPRsByReviewerFn: func(ctx context.Context, reviewerIDs []string) (map[string][]string, error) {
+			return map[string][]string{"u2": {"pr-1", "pr-2"}}, nil
+		},
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, Status: domain.PRStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			return []domain.User{{ID: "u3"}}, nil
+		},
+		replaceReviewerFn: func(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error) {
+			if prID == "pr-2" {
+				return domain.PullRequest{}, "", domain.ErrPRMerged
+			}
+			return domain.PullRequest{}, newReviewer, nil
+		},
+	}
+
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+	// Ошибка возвращается из транзакции, и transaction manager откатывает деактивацию и замену на pr-1
+	_, err := svc.MassDeactivate(ctx, MassDeactivateInput{TeamName: "backend", UserIDs: []string{"u2"}})
+	require.ErrorIs(t, err, domain.ErrPRMerged)
+}
+
+func TestService_MassDeactivate_SkipsWithoutCandidates(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var replacedWith *string
+	fake := &fakeRepo{
+		getTeamFn: func(ctx context.Context, name string) (domain.Team, error) {
+			return domain.Team{Name: name, Members: []domain.User{{ID: "u2", TeamName: name, IsActive: true}}}, nil
+		},
+		listOpenPRsByReviewerFn: func(ctx context.Context, reviewerIDs []string) (map[string][]string, error) {
+			return map[string][]string{"u2": {"pr-1"}}, nil
+		},
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, Status: domain.PRStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil
+		},
+		replaceReviewerFn: func(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error) {
+			replacedWith = &newReviewer
+			return domain.PullRequest{}, newReviewer, nil
+		},
+	}
+
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+	result, err := svc.MassDeactivate(ctx, MassDeactivateInput{TeamName: "backend", UserIDs: []string{"u2"}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"u2": domain.ErrNoCandidate.Error()}, result.Skipped)
+	require.Equal(t, map[string][]string{"u2": {"pr-1"}}, result.RemovedWithoutReplacement)
+	require.Empty(t, result.Reassigned)
+	require.NotNil(t, replacedWith)
+	require.Empty(t, *replacedWith)
+}
+
+func TestService_MassDeactivate_ReportsRemovalWhenEnoughReviewersRemain(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var replacedWith *string
+	fake := &fakeRepo{
+		getTeamFn: func(ctx context.Context, name string) (domain.Team, error) {
+			return domain.Team{Name: name, Members: []domain.User{{ID: "u2", TeamName: name, IsActive: true}}}, nil
+		},
+		listOpenPRsByReviewerFn: func(ctx context.Context, reviewerIDs []string) (map[string][]string, error) {
+			return map[string][]string{"u2": {"pr-1"}}, nil
+		},
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, Status: domain.PRStatusOpen, AuthorID: "u1",
+				AssignedReviewers: []string{"u2", "u3", "u4"}}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			t.Fatal("no candidates are needed when enough reviewers remain")
+			return nil, nil
+		},
+		replaceReviewerFn: func(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error) {
+			replacedWith = &newReviewer
+			return domain.PullRequest{}, newReviewer, nil
+		},
+	}
+
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+	result, err := svc.MassDeactivate(ctx, MassDeactivateInput{TeamName: "backend", UserIDs: []string{"u2"}})
+	require.NoError(t, err)
+	// На PR остаются u3 и u4 — это требуемые два ревьювера, поэтому u2 снимается без замены
+	require.Equal(t, map[string][]string{"u2": {"pr-1"}}, result.RemovedWithoutReplacement)
+	require.Empty(t, result.Reassigned)
+	require.Empty(t, result.Skipped)
+	require.NotNil(t, replacedWith)
+	require.Empty(t, *replacedWith)
+}
+
+func TestService_CreatePullRequest_UsesRequiredReviewers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cfg := testConfig()
+	cfg.Reviewers.Teams = map[string]config.TeamReviewersConfig{"small": {RequiredReviewers: 1}}
+
+	required := map[string]int{"platform": 3}
+	captured := map[string][]string{}
+	fake := &fakeRepo{
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			return domain.User{ID: userID, TeamName: userID, IsActive: true}, nil
+		},
+		getTeamSettingsFn: func(ctx context.Context, name string) (domain.TeamSettings, error) {
+			return domain.TeamSettings{TeamName: name, RequiredReviewers: required[name]}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			return []domain.User{{ID: "u2"}, {ID: "u3"}, {ID: "u4"}, {ID: "u5"}}, nil
+		},
+		createPullRequestFn: func(ctx context.Context, pr domain.PullRequest, reviewers []string) (domain.PullRequest, error) {
+			captured[pr.AuthorID] = reviewers
+			return pr, nil
+		},
+	}
+
+	svc := New(fake, cfg, stubManager{}, stubRandomizer{})
+	// Автор с user_id, совпадающим с именем команды, позволяет проверить три источника настройки
+	for _, team := range []string{"platform", "small", "backend"} {
+		_, err := svc.CreatePullRequest(ctx, "pr-"+team, "Feature", team, false)
+		require.NoError(t, err)
+	}
+	require.Len(t, captured["platform"], 3) // настройка команды в БД
+	require.Len(t, captured["small"], 1)    // конфигурация команды
+	require.Len(t, captured["backend"], 2)  // значение по умолчанию
+}
+
+func TestService_MassDeactivate_BackfillsToRequiredReviewers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var added []string
+	fake := &fakeRepo{
+		getTeamFn: func(ctx context.Context, name string) (domain.Team, error) {
+			return domain.Team{Name: name, Members: []domain.User{{ID: "u2", TeamName: name, IsActive: true}}}, nil
+		},
+		getTeamSettingsFn: func(ctx context.Context, name string) (domain.TeamSettings, error) {
+			return domain.TeamSettings{TeamName: name, RequiredReviewers: 3}, nil
+		},
+		listOpenPRsByReviewerFn: func(ctx context.Context, reviewerIDs []string) (map[string][]string, error) {
+			return map[string][]string{"u2": {"pr-1"}}, nil
+		},
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{
+				ID:                prID,
+				Status:            domain.PRStatusOpen,
+				AuthorID:          "u1",
+				AssignedReviewers: []string{"u2", "u4"},
+			}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			return []domain.User{{ID: "u3"}, {ID: "u5"}, {ID: "u6"}}, nil
+		},
+		replaceReviewerFn: func(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error) {
+			require.Equal(t, "u3", newReviewer)
+			return domain.PullRequest{}, newReviewer, nil
+		},
+		addReviewersFn: func(ctx context.Context, prID string, reviewers []string, source string) (domain.PullRequest, error) {
+			require.Equal(t, "TEAM_DEACTIVATION", source)
+			added = reviewers
+			return domain.PullRequest{}, nil
+		},
+	}
+
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+	result, err := svc.MassDeactivate(ctx, MassDeactivateInput{TeamName: "backend", UserIDs: []string{"u2"}})
+	require.NoError(t, err)
+	require.Empty(t, result.Skipped)
+	// На PR остаётся u4, поэтому до трёх ревьюверов добираем двоих: замена u3 и дополнительно u5
+	require.Equal(t, []string{"u5"}, added)
+}
+
+func TestService_SubmitReview(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var submitted domain.ReviewDecision
+	fake := &fakeRepo{
+		submitReviewFn: func(ctx context.Context, prID, reviewerID string, decision domain.ReviewDecision) (domain.PullRequest, error) {
+			if reviewerID != "u2" {
+				return domain.PullRequest{}, domain.ErrReviewerAbsent
+			}
+			submitted = decision
+			return domain.PullRequest{ID: prID, Decisions: []domain.Review{{ReviewerID: reviewerID, Decision: decision}}}, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	_, err := svc.SubmitReview(ctx, "pr-1", "u2", "LGTM")
+	require.Error(t, err)
+	require.Empty(t, submitted)
+
+	_, err = svc.SubmitReview(ctx, "pr-1", "u3", domain.ReviewDecisionApproved)
+	require.ErrorIs(t, err, domain.ErrReviewerAbsent)
+
+	pr, err := svc.SubmitReview(ctx, "pr-1", "u2", domain.ReviewDecisionChangesRequested)
+	require.NoError(t, err)
+	require.Equal(t, domain.ReviewDecisionChangesRequested, submitted)
+	require.Len(t, pr.Decisions, 1)
+}
+
 func TestPickRandomIDsRespectLimit(t *testing.T) {
 	users := []domain.User{
 		{ID: "u1"},
@@ -215,36 +661,62 @@ func TestServiceMergePullRequest(t *testing.T) {
 	require.Equal(t, domain.PRStatusMerged, pr.Status)
 }
 
-func TestServiceListReviewAssignments(t *testing.T) {
+func TestServiceStats(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	expected := []domain.PullRequestShort{{ID: "pr-1"}}
+	stats := domain.AssignmentStats{
+		PerUser: []domain.UserAssignmentStat{{UserID: "u1", Assigned: 2}},
+	}
 	fake := &fakeRepo{
-		listReviewAssignmentsFn: func(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
-			return expected, nil
+		fetchAssignmentStatsFn: func(ctx context.Context, filter domain.StatsFilter) (domain.AssignmentStats, error) {
+			require.Equal(t, "backend", filter.TeamName)
+			return stats, nil
 		},
 	}
 	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
-	assignments, err := svc.ListReviewAssignments(ctx, "user-1")
+	result, err := svc.Stats(ctx, domain.StatsFilter{TeamName: "backend"})
 	require.NoError(t, err)
-	require.Equal(t, expected, assignments)
+	require.Equal(t, stats, result)
 }
 
-func TestServiceStats(t *testing.T) {
+func TestServiceExportPullRequestStatsStreams(t *testing.T) {
 	t.Parallel()
-	ctx := context.Background()
-	stats := domain.AssignmentStats{
-		PerUser: []domain.UserAssignmentStat{{UserID: "u1", Assigned: 2}},
-	}
 	fake := &fakeRepo{
-		fetchAssignmentStatsFn: func(ctx context.Context) (domain.AssignmentStats, error) {
-			return stats, nil
+		streamPullRequestStatsFn: func(ctx context.Context, filter domain.StatsFilter, fn func(domain.PRAssignmentStat) error) error {
+			require.Equal(t, domain.PRStatusOpen, filter.Status)
+			for _, id := range []string{"pr-1", "pr-2"} {
+				if err := fn(domain.PRAssignmentStat{PullRequestID: id}); err != nil {
+					return err
+				}
+			}
+			return nil
 		},
 	}
 	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
-	result, err := svc.Stats(ctx)
+
+	var ids []string
+	err := svc.ExportPullRequestStats(context.Background(), domain.StatsFilter{Status: domain.PRStatusOpen},
+		func(stat domain.PRAssignmentStat) error {
+			ids = append(ids, stat.PullRequestID)
+			return nil
+		})
 	require.NoError(t, err)
-	require.Equal(t, stats, result)
+	require.Equal(t, []string{"pr-1", "pr-2"}, ids)
+
+	err = svc.ExportUserStats(context.Background(), domain.StatsFilter{Status: "DONE"},
+		func(domain.UserAssignmentStat) error { return nil })
+	require.Error(t, err)
+}
+
+func TestServiceStatsValidatesFilter(t *testing.T) {
+	t.Parallel()
+	svc := New(&fakeRepo{}, testConfig(), stubManager{}, stubRandomizer{})
+	now := time.Now()
+
+	_, err := svc.Stats(context.Background(), domain.StatsFilter{Status: "DONE"})
+	require.Error(t, err)
+	_, err = svc.Stats(context.Background(), domain.StatsFilter{From: &now, To: &now})
+	require.Error(t, err)
 }
 
 func TestServiceHealthCheckUsesRepo(t *testing.T) {
@@ -280,6 +752,7 @@ func testConfig() config.Config {
 			Operation:     time.Second,
 			LongOperation: 2 * time.Second,
 		},
+		Outbox: config.OutboxConfig{BatchSize: 100},
 	}
 }
 
@@ -297,6 +770,8 @@ type stubRandomizer struct{}
 
 func (stubRandomizer) Shuffle(n int, swap func(i, j int)) {}
 
+func (stubRandomizer) Intn(n int) int { return 0 }
+
 var (
 	_ trm.Manager              = stubManager{}
 	_ randomizerpkg.Randomizer = stubRandomizer{}
@@ -304,20 +779,58 @@ var (
 
 // fakeRepo позволяет настраивать ответы для юнит-тестов.
 type fakeRepo struct {
-	createTeamFn            func(context.Context, domain.Team) (domain.Team, error)
-	getTeamFn               func(context.Context, string) (domain.Team, error)
-	setUserActivityFn       func(context.Context, string, bool) (domain.User, error)
-	getUserByIDFn           func(context.Context, string) (domain.User, error)
-	listActiveTeamMembersFn func(context.Context, string, []string) ([]domain.User, error)
-	createPullRequestFn     func(context.Context, domain.PullRequest, []string) (domain.PullRequest, error)
-	updatePRStatusFn        func(context.Context, string, domain.PRStatus) (domain.PullRequest, error)
-	getPullRequestFn        func(context.Context, string) (domain.PullRequest, error)
-	replaceReviewerFn       func(context.Context, string, string, string, string) (domain.PullRequest, string, error)
-	listReviewAssignmentsFn func(context.Context, string) ([]domain.PullRequestShort, error)
-	fetchAssignmentStatsFn  func(context.Context) (domain.AssignmentStats, error)
-	deactivateUsersFn       func(context.Context, []string) ([]domain.User, error)
-	listOpenPRsByReviewerFn func(context.Context, []string) (map[string][]string, error)
-	pingFn                  func(context.Context) error
+	createTeamFn               func(context.Context, domain.Team) (domain.Team, error)
+	getTeamFn                  func(context.Context, string) (domain.Team, error)
+	getTeamSettingsFn          func(context.Context, string) (domain.TeamSettings, error)
+	updateTeamSettingsFn       func(context.Context, domain.TeamSettings) (domain.TeamSettings, error)
+	setUserActivityFn          func(context.Context, string, bool) (domain.User, error)
+	setUserMaxOpenReviewsFn    func(context.Context, string, int) (domain.User, error)
+	setUserRoleFn              func(context.Context, string, string) (domain.User, error)
+	getUserByIDFn              func(context.Context, string) (domain.User, error)
+	listActiveTeamMembersFn    func(context.Context, string, []string) ([]domain.User, error)
+	createPullRequestFn        func(context.Context, domain.PullRequest, []string) (domain.PullRequest, error)
+	updatePRStatusFn           func(context.Context, string, domain.PRStatus) (domain.PullRequest, error)
+	getPullRequestFn           func(context.Context, string) (domain.PullRequest, error)
+	lockPullRequestFn          func(context.Context, string) error
+	markPRReadyFn              func(context.Context, string, []string) (domain.PullRequest, error)
+	closePullRequestFn         func(context.Context, string) (domain.PullRequest, error)
+	reopenPullRequestFn        func(context.Context, string, []string) (domain.PullRequest, error)
+	replaceReviewerFn          func(context.Context, string, string, string, string) (domain.PullRequest, string, error)
+	addReviewersFn             func(context.Context, string, []string, string) (domain.PullRequest, error)
+	submitReviewFn             func(context.Context, string, string, domain.ReviewDecision) (domain.PullRequest, error)
+	listPullRequestsFn         func(context.Context, domain.PullRequestFilter) ([]domain.PullRequestShort, error)
+	listReviewAssignmentsFn    func(context.Context, domain.ReviewAssignmentFilter) ([]domain.PullRequestShort, error)
+	countReviewAssignmentsFn   func(context.Context, domain.ReviewAssignmentFilter) (int64, error)
+	fetchAssignmentStatsFn     func(context.Context, domain.StatsFilter) (domain.AssignmentStats, error)
+	streamUserStatsFn          func(context.Context, domain.StatsFilter, func(domain.UserAssignmentStat) error) error
+	streamPullRequestStatsFn   func(context.Context, domain.StatsFilter, func(domain.PRAssignmentStat) error) error
+	streamSourceStatsFn        func(context.Context, domain.StatsFilter, func(domain.SourceAssignmentStat) error) error
+	fetchLatencyStatsFn        func(context.Context, domain.LatencyFilter) (domain.LatencyStats, error)
+	fetchMemberAssignmentsFn   func(context.Context, domain.FairnessFilter) ([]domain.MemberAssignments, error)
+	fetchUserAssignmentStatsFn func(context.Context, []string) ([]domain.UserAssignmentStat, error)
+	fetchWorkloadFn            func(context.Context) (domain.Workload, error)
+	deactivateUsersFn          func(context.Context, []string) ([]domain.User, error)
+	listOpenPRsByReviewerFn    func(context.Context, []string) (map[string][]string, error)
+	listPendingReviewsFn       func(context.Context, time.Time) ([]domain.PendingReview, error)
+	recordSLABreachFn          func(context.Context, string, string, string) error
+	createWebhookFn            func(context.Context, domain.Webhook) (domain.Webhook, error)
+	getWebhookFn               func(context.Context, int64) (domain.Webhook, error)
+	listWebhooksFn             func(context.Context) ([]domain.Webhook, error)
+	updateWebhookFn            func(context.Context, domain.Webhook) (domain.Webhook, error)
+	deleteWebhookFn            func(context.Context, int64) error
+	claimWebhookDeliveriesFn   func(context.Context, time.Time, time.Duration, int) ([]domain.WebhookDelivery, error)
+	saveWebhookAttemptFn       func(context.Context, domain.WebhookDelivery) error
+	listWebhookDeliveriesFn    func(context.Context, domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)
+	redeliverWebhookFn         func(context.Context, int64, time.Time) (domain.WebhookDelivery, error)
+	acquireOutboxLeaseFn       func(context.Context, string, time.Time, time.Duration) (bool, error)
+	releaseOutboxLeaseFn       func(context.Context, string, time.Time) error
+	listUnpublishedOutboxFn    func(context.Context, int) ([]domain.OutboxMessage, error)
+	markOutboxSinkPublishedFn  func(context.Context, []int64, string, []string, time.Time) error
+	markOutboxPublishedFn      func(context.Context, []int64, time.Time) error
+	claimGitHubDeliveryFn      func(context.Context, string, string, string) (bool, error)
+	listEventsFn               func(context.Context, domain.EventFilter) ([]domain.AssignmentEvent, error)
+	streamEventsFn             func(context.Context, domain.EventFilter, func(domain.AssignmentEvent) error) error
+	pingFn                     func(context.Context) error
 }
 
 func (f *fakeRepo) CreateTeam(ctx context.Context, team domain.Team) (domain.Team, error) {
@@ -334,6 +847,20 @@ func (f *fakeRepo) GetTeam(ctx context.Context, name string) (domain.Team, error
 	return domain.Team{}, nil
 }
 
+func (f *fakeRepo) GetTeamSettings(ctx context.Context, name string) (domain.TeamSettings, error) {
+	if f.getTeamSettingsFn != nil {
+		return f.getTeamSettingsFn(ctx, name)
+	}
+	return domain.TeamSettings{TeamName: name}, nil
+}
+
+func (f *fakeRepo) UpdateTeamSettings(ctx context.Context, settings domain.TeamSettings) (domain.TeamSettings, error) {
+	if f.updateTeamSettingsFn != nil {
+		return f.updateTeamSettingsFn(ctx, settings)
+	}
+	return settings, nil
+}
+
 func (f *fakeRepo) SetUserActivity(ctx context.Context, userID string, active bool) (domain.User, error) {
 	if f.setUserActivityFn != nil {
 		return f.setUserActivityFn(ctx, userID, active)
@@ -341,6 +868,20 @@ func (f *fakeRepo) SetUserActivity(ctx context.Context, userID string, active bo
 	return domain.User{}, nil
 }
 
+func (f *fakeRepo) SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error) {
+	if f.setUserMaxOpenReviewsFn != nil {
+		return f.setUserMaxOpenReviewsFn(ctx, userID, maxOpenReviews)
+	}
+	return domain.User{}, nil
+}
+
+func (f *fakeRepo) SetUserRole(ctx context.Context, userID, role string) (domain.User, error) {
+	if f.setUserRoleFn != nil {
+		return f.setUserRoleFn(ctx, userID, role)
+	}
+	return domain.User{}, nil
+}
+
 func (f *fakeRepo) GetUserByID(ctx context.Context, userID string) (domain.User, error) {
 	if f.getUserByIDFn != nil {
 		return f.getUserByIDFn(ctx, userID)
@@ -376,6 +917,34 @@ func (f *fakeRepo) GetPullRequest(ctx context.Context, prID string) (domain.Pull
 	return domain.PullRequest{}, nil
 }
 
+func (f *fakeRepo) LockPullRequest(ctx context.Context, prID string) error {
+	if f.lockPullRequestFn != nil {
+		return f.lockPullRequestFn(ctx, prID)
+	}
+	return nil
+}
+
+func (f *fakeRepo) MarkPRReady(ctx context.Context, prID string, reviewers []string) (domain.PullRequest, error) {
+	if f.markPRReadyFn != nil {
+		return f.markPRReadyFn(ctx, prID, reviewers)
+	}
+	return domain.PullRequest{}, nil
+}
+
+func (f *fakeRepo) ClosePullRequest(ctx context.Context, prID string) (domain.PullRequest, error) {
+	if f.closePullRequestFn != nil {
+		return f.closePullRequestFn(ctx, prID)
+	}
+	return domain.PullRequest{}, nil
+}
+
+func (f *fakeRepo) ReopenPullRequest(ctx context.Context, prID string, reviewers []string) (domain.PullRequest, error) {
+	if f.reopenPullRequestFn != nil {
+		return f.reopenPullRequestFn(ctx, prID, reviewers)
+	}
+	return domain.PullRequest{}, nil
+}
+
 func (f *fakeRepo) ReplaceReviewer(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error) {
 	if f.replaceReviewerFn != nil {
 		return f.replaceReviewerFn(ctx, prID, oldReviewer, newReviewer, source)
@@ -383,20 +952,97 @@ func (f *fakeRepo) ReplaceReviewer(ctx context.Context, prID, oldReviewer, newRe
 	return domain.PullRequest{}, "", nil
 }
 
-func (f *fakeRepo) ListReviewAssignments(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+func (f *fakeRepo) AddReviewers(ctx context.Context, prID string, reviewers []string, source string) (domain.PullRequest, error) {
+	if f.addReviewersFn != nil {
+		return f.addReviewersFn(ctx, prID, reviewers, source)
+	}
+	return domain.PullRequest{}, nil
+}
+
+func (f *fakeRepo) SubmitReview(ctx context.Context, prID, reviewerID string, decision domain.ReviewDecision) (domain.PullRequest, error) {
+	if f.submitReviewFn != nil {
+		return f.submitReviewFn(ctx, prID, reviewerID, decision)
+	}
+	return domain.PullRequest{}, nil
+}
+
+func (f *fakeRepo) ListPullRequests(ctx context.Context, filter domain.PullRequestFilter) ([]domain.PullRequestShort, error) {
+	if f.listPullRequestsFn != nil {
+		return f.listPullRequestsFn(ctx, filter)
+	}
+	return nil, nil
+}
+
+func (f *fakeRepo) ListReviewAssignments(ctx context.Context, filter domain.ReviewAssignmentFilter) ([]domain.PullRequestShort, error) {
 	if f.listReviewAssignmentsFn != nil {
-		return f.listReviewAssignmentsFn(ctx, userID)
+		return f.listReviewAssignmentsFn(ctx, filter)
 	}
 	return nil, nil
 }
 
-func (f *fakeRepo) FetchAssignmentStats(ctx context.Context) (domain.AssignmentStats, error) {
+func (f *fakeRepo) CountReviewAssignments(ctx context.Context, filter domain.ReviewAssignmentFilter) (int64, error) {
+	if f.countReviewAssignmentsFn != nil {
+		return f.countReviewAssignmentsFn(ctx, filter)
+	}
+	return 0, nil
+}
+
+func (f *fakeRepo) FetchAssignmentStats(ctx context.Context, filter domain.StatsFilter) (domain.AssignmentStats, error) {
 	if f.fetchAssignmentStatsFn != nil {
-		return f.fetchAssignmentStatsFn(ctx)
+		return f.fetchAssignmentStatsFn(ctx, filter)
 	}
 	return domain.AssignmentStats{}, nil
 }
 
+func (f *fakeRepo) StreamUserAssignmentStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.UserAssignmentStat) error) error {
+	if f.streamUserStatsFn != nil {
+		return f.streamUserStatsFn(ctx, filter, fn)
+	}
+	return nil
+}
+
+func (f *fakeRepo) StreamPullRequestStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.PRAssignmentStat) error) error {
+	if f.streamPullRequestStatsFn != nil {
+		return f.streamPullRequestStatsFn(ctx, filter, fn)
+	}
+	return nil
+}
+
+func (f *fakeRepo) StreamSourceStats(ctx context.Context, filter domain.StatsFilter, fn func(domain.SourceAssignmentStat) error) error {
+	if f.streamSourceStatsFn != nil {
+		return f.streamSourceStatsFn(ctx, filter, fn)
+	}
+	return nil
+}
+
+func (f *fakeRepo) FetchLatencyStats(ctx context.Context, filter domain.LatencyFilter) (domain.LatencyStats, error) {
+	if f.fetchLatencyStatsFn != nil {
+		return f.fetchLatencyStatsFn(ctx, filter)
+	}
+	return domain.LatencyStats{}, nil
+}
+
+func (f *fakeRepo) FetchMemberAssignments(ctx context.Context, filter domain.FairnessFilter) ([]domain.MemberAssignments, error) {
+	if f.fetchMemberAssignmentsFn != nil {
+		return f.fetchMemberAssignmentsFn(ctx, filter)
+	}
+	return []domain.MemberAssignments{}, nil
+}
+
+func (f *fakeRepo) FetchUserAssignmentStats(ctx context.Context, userIDs []string) ([]domain.UserAssignmentStat, error) {
+	if f.fetchUserAssignmentStatsFn != nil {
+		return f.fetchUserAssignmentStatsFn(ctx, userIDs)
+	}
+	return nil, nil
+}
+
+func (f *fakeRepo) FetchWorkload(ctx context.Context) (domain.Workload, error) {
+	if f.fetchWorkloadFn != nil {
+		return f.fetchWorkloadFn(ctx)
+	}
+	return domain.Workload{}, nil
+}
+
 func (f *fakeRepo) DeactivateUsers(ctx context.Context, userIDs []string) ([]domain.User, error) {
 	if f.deactivateUsersFn != nil {
 		return f.deactivateUsersFn(ctx, userIDs)
@@ -411,9 +1057,137 @@ func (f *fakeRepo) ListOpenPRsByReviewer(ctx context.Context, reviewerIDs []stri
 	return map[string][]string{}, nil
 }
 
-func (f *fakeRepo) WithTransaction(ctx context.Context, fn func(repository.Repository) error) error {
-	// В тестах просто вызываем функцию без реальной транзакции
-	return fn(f)
+func (f *fakeRepo) ListPendingReviews(ctx context.Context, assignedBefore time.Time) ([]domain.PendingReview, error) {
+	if f.listPendingReviewsFn != nil {
+		return f.listPendingReviewsFn(ctx, assignedBefore)
+	}
+	return nil, nil
+}
+
+func (f *fakeRepo) RecordSLABreach(ctx context.Context, prID, reviewerID, source string) error {
+	if f.recordSLABreachFn != nil {
+		return f.recordSLABreachFn(ctx, prID, reviewerID, source)
+	}
+	return nil
+}
+
+func (f *fakeRepo) CreateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	if f.createWebhookFn != nil {
+		return f.createWebhookFn(ctx, webhook)
+	}
+	return webhook, nil
+}
+
+func (f *fakeRepo) GetWebhook(ctx context.Context, id int64) (domain.Webhook, error) {
+	if f.getWebhookFn != nil {
+		return f.getWebhookFn(ctx, id)
+	}
+	return domain.Webhook{}, domain.ErrWebhookNotFound
+}
+
+func (f *fakeRepo) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	if f.listWebhooksFn != nil {
+		return f.listWebhooksFn(ctx)
+	}
+	return nil, nil
+}
+
+func (f *fakeRepo) UpdateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	if f.updateWebhookFn != nil {
+		return f.updateWebhookFn(ctx, webhook)
+	}
+	return webhook, nil
+}
+
+func (f *fakeRepo) DeleteWebhook(ctx context.Context, id int64) error {
+	if f.deleteWebhookFn != nil {
+		return f.deleteWebhookFn(ctx, id)
+	}
+	return nil
+}
+
+func (f *fakeRepo) ClaimWebhookDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	if f.claimWebhookDeliveriesFn != nil {
+		return f.claimWebhookDeliveriesFn(ctx, now, lease, limit)
+	}
+	return nil, nil
+}
+
+func (f *fakeRepo) SaveWebhookAttempt(ctx context.Context, delivery domain.WebhookDelivery) error {
+	if f.saveWebhookAttemptFn != nil {
+		return f.saveWebhookAttemptFn(ctx, delivery)
+	}
+	return nil
+}
+
+func (f *fakeRepo) ListWebhookDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	if f.listWebhookDeliveriesFn != nil {
+		return f.listWebhookDeliveriesFn(ctx, filter)
+	}
+	return nil, nil
+}
+
+func (f *fakeRepo) RedeliverWebhookDelivery(ctx context.Context, id int64, now time.Time) (domain.WebhookDelivery, error) {
+	if f.redeliverWebhookFn != nil {
+		return f.redeliverWebhookFn(ctx, id, now)
+	}
+	return domain.WebhookDelivery{}, domain.ErrDeliveryNotFound
+}
+
+func (f *fakeRepo) AcquireOutboxLease(ctx context.Context, token string, now time.Time, ttl time.Duration) (bool, error) {
+	if f.acquireOutboxLeaseFn != nil {
+		return f.acquireOutboxLeaseFn(ctx, token, now, ttl)
+	}
+	return true, nil
+}
+
+func (f *fakeRepo) ReleaseOutboxLease(ctx context.Context, token string, now time.Time) error {
+	if f.releaseOutboxLeaseFn != nil {
+		return f.releaseOutboxLeaseFn(ctx, token, now)
+	}
+	return nil
+}
+
+func (f *fakeRepo) ListUnpublishedOutbox(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	if f.listUnpublishedOutboxFn != nil {
+		return f.listUnpublishedOutboxFn(ctx, limit)
+	}
+	return nil, nil
+}
+
+func (f *fakeRepo) MarkOutboxSinkPublished(ctx context.Context, ids []int64, sink string, sinks []string, publishedAt time.Time) error {
+	if f.markOutboxSinkPublishedFn != nil {
+		return f.markOutboxSinkPublishedFn(ctx, ids, sink, sinks, publishedAt)
+	}
+	return nil
+}
+
+func (f *fakeRepo) MarkOutboxPublished(ctx context.Context, ids []int64, publishedAt time.Time) error {
+	if f.markOutboxPublishedFn != nil {
+		return f.markOutboxPublishedFn(ctx, ids, publishedAt)
+	}
+	return nil
+}
+
+func (f *fakeRepo) ClaimGitHubDelivery(ctx context.Context, deliveryID, event, action string) (bool, error) {
+	if f.claimGitHubDeliveryFn != nil {
+		return f.claimGitHubDeliveryFn(ctx, deliveryID, event, action)
+	}
+	return true, nil
+}
+
+func (f *fakeRepo) ListEvents(ctx context.Context, filter domain.EventFilter) ([]domain.AssignmentEvent, error) {
+	if f.listEventsFn != nil {
+		return f.listEventsFn(ctx, filter)
+	}
+	return []domain.AssignmentEvent{}, nil
+}
+
+func (f *fakeRepo) StreamEvents(ctx context.Context, filter domain.EventFilter, fn func(domain.AssignmentEvent) error) error {
+	if f.streamEventsFn != nil {
+		return f.streamEventsFn(ctx, filter, fn)
+	}
+	return nil
 }
 
 func (f *fakeRepo) Ping(ctx context.Context) error {
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/metrics"
+)
+
+// minReviewSLA — минимальный SLA на ревью; более свежие назначения не проверяются.
+const minReviewSLA = time.Hour
+
+// slaSources задаёт source событий журнала для каждого действия при нарушении SLA.
+var slaSources = map[domain.SLAEscalation]string{
+	domain.SLAEscalationNotify:      "SLA_NOTIFY",
+	domain.SLAEscalationReassign:    "SLA_REASSIGN",
+	domain.SLAEscalationAddReviewer: "SLA_ADD_REVIEWER",
+}
+
+// SLAReport описывает результат одной проверки SLA на ревью.
+type SLAReport struct {
+	Notified   int // Нарушения, по которым только записано событие
+	Reassigned int // Просроченные ревьюверы, заменённые другими
+	Added      int // PR, на которые добавлен ещё один ревьювер
+	Failed     int // Нарушения, которые не удалось обработать; будут повторены при следующей проверке
+}
+
+// reviewSLAFor определяет SLA на ревью команды: настройка из БД, затем конфигурация команды.
+// Без действия при нарушении используется NOTIFY.
+func (s *Service) reviewSLAFor(settings domain.TeamSettings) domain.ReviewSLA {
+	sla := settings.ReviewSLA
+	teamCfg := s.cfg.Reviewers.Teams[settings.TeamName].ReviewSLA
+	if sla.Hours <= 0 {
+		sla.Hours = max(teamCfg.Hours, 0)
+	}
+	if sla.Escalation == "" {
+		sla.Escalation = domain.SLAEscalation(teamCfg.Escalation)
+	}
+	if sla.Escalation == "" {
+		sla.Escalation = domain.SLAEscalationNotify
+	}
+	return sla
+}
+
+// EscalateStaleReviews находит назначения на открытые PR, по которым ревьювер не вынес решение
+// дольше SLA команды автора, и обрабатывает каждое действием из политики команды.
+// Каждое нарушение записывается в журнал событий (SLA_BREACHED) вместе с действием
+// в одной транзакции, поэтому одно и то же назначение не обрабатывается повторно.
+func (s *Service) EscalateStaleReviews(ctx context.Context, now time.Time) (SLAReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeouts.LongOperation)
+	defer cancel()
+
+	pending, err := s.repo.ListPendingReviews(ctx, now.Add(-minReviewSLA))
+	if err != nil {
+		return SLAReport{}, err
+	}
+	var report SLAReport
+	settingsByTeam := make(map[string]domain.TeamSettings)
+	for _, review := range pending {
+		settings, ok := settingsByTeam[review.AuthorTeam]
+		if !ok {
+			if settings, err = s.teamSettings(ctx, review.AuthorTeam); err != nil {
+				return report, err
+			}
+			settingsByTeam[review.AuthorTeam] = settings
+		}
+		sla := s.reviewSLAFor(settings)
+		if sla.Hours <= 0 || now.Sub(review.AssignedAt) < time.Duration(sla.Hours)*time.Hour {
+			continue
+		}
+		escalation, err := s.escalate(ctx, review, settings, sla.Escalation)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to escalate stale review",
+				"pull_request_id", review.PullRequestID, "reviewer_id", review.ReviewerID, "error", err)
+			report.Failed++
+			continue
+		}
+		slog.InfoContext(ctx, "review SLA breached",
+			"pull_request_id", review.PullRequestID, "reviewer_id", review.ReviewerID,
+			"team_name", review.AuthorTeam, "escalation", escalation)
+		metrics.IncSLAEscalations(escalation)
+		switch escalation {
+		case domain.SLAEscalationReassign:
+			report.Reassigned++
+			metrics.IncReassignments(review.AuthorTeam, slaSources[escalation])
+		case domain.SLAEscalationAddReviewer:
+			report.Added++
+		default:
+			report.Notified++
+		}
+	}
+	return report, nil
+}
+
+// escalate обрабатывает нарушение SLA действием escalation и возвращает фактически выполненное действие.
+// Замена и дополнительный ревьювер выбираются стратегией команды автора (settings), как при
+// переназначении и добавлении ревьювера. Если кандидатов нет, нарушение только фиксируется (NOTIFY).
+func (s *Service) escalate(ctx context.Context, review domain.PendingReview, settings domain.TeamSettings, escalation domain.SLAEscalation) (domain.SLAEscalation, error) {
+	var candidate string
+	if escalation == domain.SLAEscalationReassign || escalation == domain.SLAEscalationAddReviewer {
+		pr, err := s.repo.GetPullRequest(ctx, review.PullRequestID)
+		if err != nil {
+			return "", err
+		}
+		exclude := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+		selected, err := s.pickReviewers(ctx, settings, uniqueIDs(exclude), 1)
+		if err != nil && !errors.Is(err, domain.ErrAllSaturated) {
+			return "", err
+		}
+		if len(selected) == 0 {
+			escalation = domain.SLAEscalationNotify
+		} else {
+			candidate = selected[0]
+		}
+	}
+
+	source := slaSources[escalation]
+	err := s.trMgr.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.RecordSLABreach(ctx, review.PullRequestID, review.ReviewerID, source); err != nil {
+			return err
+		}
+		switch escalation {
+		case domain.SLAEscalationReassign:
+			_, _, err := s.repo.ReplaceReviewer(ctx, review.PullRequestID, review.ReviewerID, candidate, source)
+			return err
+		case domain.SLAEscalationAddReviewer:
+			_, err := s.repo.AddReviewers(ctx, review.PullRequestID, []string{candidate}, source)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return escalation, nil
+}
+
+// RunSLAWorker проверяет SLA на ревью каждые interval, пока не отменён ctx.
+func (s *Service) RunSLAWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			report, err := s.EscalateStaleReviews(ctx, now)
+			if err != nil {
+				slog.ErrorContext(ctx, "review SLA check failed", "error", err)
+				continue
+			}
+			if report != (SLAReport{}) {
+				slog.InfoContext(ctx, "review SLA check finished",
+					"notified", report.Notified, "reassigned", report.Reassigned,
+					"added", report.Added, "failed", report.Failed)
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/config"
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestService_EscalateStaleReviews(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	cfg := testConfig()
+	cfg.Reviewers.Teams = map[string]config.TeamReviewersConfig{
+		"backend": {ReviewSLA: config.ReviewSLAConfig{Hours: 24}},
+	}
+	settings := map[string]domain.TeamSettings{
+		"backend": {TeamName: "backend", ReviewSLA: domain.ReviewSLA{Escalation: domain.SLAEscalationReassign}},
+		"mobile":  {TeamName: "mobile", ReviewSLA: domain.ReviewSLA{Hours: 4, Escalation: domain.SLAEscalationAddReviewer}},
+		"web":     {TeamName: "web"},
+	}
+	var (
+		breaches []string
+		replaced []string
+		added    []string
+	)
+	fake := &fakeRepo{
+		listPendingReviewsFn: func(ctx context.Context, assignedBefore time.Time) ([]domain.PendingReview, error) {
+			require.Equal(t, now.Add(-minReviewSLA), assignedBefore)
+			return []domain.PendingReview{
+				{PullRequestID: "pr-1", ReviewerID: "u2", AuthorTeam: "backend", AssignedAt: now.Add(-25 * time.Hour)},
+				{PullRequestID: "pr-2", ReviewerID: "u3", AuthorTeam: "backend", AssignedAt: now.Add(-5 * time.Hour)},
+				{PullRequestID: "pr-3", ReviewerID: "m2", AuthorTeam: "mobile", AssignedAt: now.Add(-5 * time.Hour)},
+				{PullRequestID: "pr-4", ReviewerID: "w2", AuthorTeam: "web", AssignedAt: now.Add(-100 * time.Hour)},
+			}, nil
+		},
+		getTeamSettingsFn: func(ctx context.Context, name string) (domain.TeamSettings, error) {
+			return settings[name], nil
+		},
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			switch prID {
+			case "pr-1":
+				return domain.PullRequest{ID: prID, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil
+			default:
+				return domain.PullRequest{ID: prID, AuthorID: "m1", AssignedReviewers: []string{"m2"}}, nil
+			}
+		},
+		getUserByIDFn: func(ctx context.Context, userID string) (domain.User, error) {
+			return domain.User{ID: userID, TeamName: "backend", IsActive: true}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			if teamName == "backend" {
+				return []domain.User{{ID: "u4", TeamName: teamName, IsActive: true}}, nil
+			}
+			// В команде mobile кандидатов нет: нарушение только фиксируется
+			return nil, nil
+		},
+		recordSLABreachFn: func(ctx context.Context, prID, reviewerID, source string) error {
+			breaches = append(breaches, prID+"/"+reviewerID+"/"+source)
+			return nil
+		},
+		replaceReviewerFn: func(ctx context.Context, prID, oldReviewer, newReviewer, source string) (domain.PullRequest, string, error) {
+			require.Equal(t, "SLA_REASSIGN", source)
+			replaced = append(replaced, oldReviewer+"->"+newReviewer)
+			return domain.PullRequest{ID: prID}, newReviewer, nil
+		},
+		addReviewersFn: func(ctx context.Context, prID string, reviewers []string, source string) (domain.PullRequest, error) {
+			added = append(added, reviewers...)
+			return domain.PullRequest{ID: prID}, nil
+		},
+	}
+	svc := New(fake, cfg, stubManager{}, stubRandomizer{})
+
+	report, err := svc.EscalateStaleReviews(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, SLAReport{Reassigned: 1, Notified: 1}, report)
+	require.Equal(t, []string{"pr-1/u2/SLA_REASSIGN", "pr-3/m2/SLA_NOTIFY"}, breaches)
+	require.Equal(t, []string{"u2->u4"}, replaced)
+	require.Empty(t, added)
+}
+
+func TestService_EscalateStaleReviews_AddsReviewer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	var source string
+	fake := &fakeRepo{
+		listPendingReviewsFn: func(ctx context.Context, assignedBefore time.Time) ([]domain.PendingReview, error) {
+			return []domain.PendingReview{
+				{PullRequestID: "pr-1", ReviewerID: "u2", AuthorTeam: "backend", AssignedAt: now.Add(-3 * time.Hour)},
+			}, nil
+		},
+		getTeamSettingsFn: func(ctx context.Context, name string) (domain.TeamSettings, error) {
+			return domain.TeamSettings{TeamName: name, ReviewSLA: domain.ReviewSLA{Hours: 2, Escalation: domain.SLAEscalationAddReviewer}}, nil
+		},
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil
+		},
+		listActiveTeamMembersFn: func(ctx context.Context, teamName string, exclude []string) ([]domain.User, error) {
+			require.ElementsMatch(t, []string{"u1", "u2"}, exclude)
+			return []domain.User{{ID: "u3", TeamName: teamName, IsActive: true}}, nil
+		},
+		addReviewersFn: func(ctx context.Context, prID string, reviewers []string, src string) (domain.PullRequest, error) {
+			require.Equal(t, []string{"u3"}, reviewers)
+			source = src
+			return domain.PullRequest{ID: prID}, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	report, err := svc.EscalateStaleReviews(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, SLAReport{Added: 1}, report)
+	require.Equal(t, "SLA_ADD_REVIEWER", source)
+}
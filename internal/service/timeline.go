@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+// statusAfterEvent — статус PR после события смены статуса.
+var statusAfterEvent = map[domain.EventType]domain.PRStatus{
+	domain.EventReadyForReview: domain.PRStatusOpen,
+	domain.EventReopened:       domain.PRStatusOpen,
+	domain.EventMerged:         domain.PRStatusMerged,
+	domain.EventClosed:         domain.PRStatusClosed,
+}
+
+// GetPullRequestTimeline возвращает историю PR: создание, назначения и снятия ревьюверов с источником,
+// решения, смены статуса и merge в порядке журнала назначений.
+func (s *Service) GetPullRequestTimeline(ctx context.Context, prID string) (domain.PullRequestTimeline, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	pr, err := s.repo.GetPullRequest(ctx, prID)
+	if err != nil {
+		return domain.PullRequestTimeline{}, err
+	}
+	events, err := s.repo.ListEvents(ctx, domain.EventFilter{PullRequestID: prID})
+	if err != nil {
+		return domain.PullRequestTimeline{}, err
+	}
+	return domain.PullRequestTimeline{PullRequest: pr, Timeline: buildTimeline(pr, events)}, nil
+}
+
+// buildTimeline собирает историю PR из его событий. Статус при создании восстанавливается по первой
+// смене статуса; merge без события в журнале добавляется по merged_at.
+func buildTimeline(pr domain.PullRequest, events []domain.AssignmentEvent) []domain.TimelineEntry {
+	created := domain.TimelineEntry{Type: domain.TimelineCreated, Status: initialStatus(pr, events), At: pr.CreatedAt}
+	timeline := make([]domain.TimelineEntry, 0, len(events)+1)
+	timeline = append(timeline, created)
+
+	merged := false
+	for _, event := range events {
+		entry := domain.TimelineEntry{
+			EventID:    event.ID,
+			Type:       event.EventType,
+			ReviewerID: event.ReviewerID,
+			Source:     event.Source,
+			Status:     statusAfterEvent[event.EventType],
+			At:         event.CreatedAt,
+		}
+		if event.EventType == domain.EventMerged {
+			merged = true
+			if pr.MergedAt != nil {
+				entry.At = *pr.MergedAt
+			}
+		}
+		timeline = append(timeline, entry)
+	}
+	if !merged && pr.MergedAt != nil {
+		timeline = append(timeline, domain.TimelineEntry{
+			Type:   domain.EventMerged,
+			Status: domain.PRStatusMerged,
+			At:     *pr.MergedAt,
+		})
+	}
+	return timeline
+}
+
+// initialStatus возвращает статус PR при создании: черновик становится OPEN только через READY_FOR_REVIEW,
+// а ревьюверы назначаются только на открытый PR. Если PR закрыт раньше, чем получил ревьюверов,
+// статус при создании неизвестен и возвращается пустым.
+func initialStatus(pr domain.PullRequest, events []domain.AssignmentEvent) domain.PRStatus {
+	for _, event := range events {
+		switch event.EventType {
+		case domain.EventAssigned, domain.EventMerged:
+			return domain.PRStatusOpen
+		case domain.EventReadyForReview:
+			return domain.PRStatusDraft
+		case domain.EventClosed:
+			return ""
+		}
+	}
+	if pr.Status == domain.PRStatusDraft {
+		return domain.PRStatusDraft
+	}
+	return domain.PRStatusOpen
+}
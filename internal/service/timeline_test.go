@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/domain"
+)
+
+func TestService_GetPullRequestTimeline(t *testing.T) {
+	t.Parallel()
+	created := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	mergedAt := created.Add(3 * time.Hour)
+	u1, u2 := "u1", "u2"
+	fake := &fakeRepo{
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, Status: domain.PRStatusMerged, CreatedAt: created, MergedAt: &mergedAt}, nil
+		},
+		listEventsFn: func(ctx context.Context, filter domain.EventFilter) ([]domain.AssignmentEvent, error) {
+			require.Equal(t, domain.EventFilter{PullRequestID: "pr-1"}, filter)
+			return []domain.AssignmentEvent{
+				{ID: 1, EventType: domain.EventReadyForReview, Source: "STATUS_CHANGE", CreatedAt: created.Add(time.Hour)},
+				{ID: 2, EventType: domain.EventAssigned, ReviewerID: &u1, Source: "AUTO", CreatedAt: created.Add(time.Hour)},
+				{ID: 3, EventType: domain.EventUnassigned, ReviewerID: &u1, Source: "REASSIGN", CreatedAt: created.Add(2 * time.Hour)},
+				{ID: 4, EventType: domain.EventAssigned, ReviewerID: &u2, Source: "REASSIGN", CreatedAt: created.Add(2 * time.Hour)},
+				{ID: 5, EventType: domain.EventMerged, Source: "STATUS_CHANGE", CreatedAt: mergedAt.Add(time.Millisecond)},
+			}, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	timeline, err := svc.GetPullRequestTimeline(context.Background(), "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, "pr-1", timeline.PullRequest.ID)
+	require.Equal(t, []domain.TimelineEntry{
+		{Type: domain.TimelineCreated, Status: domain.PRStatusDraft, At: created},
+		{EventID: 1, Type: domain.EventReadyForReview, Source: "STATUS_CHANGE", Status: domain.PRStatusOpen, At: created.Add(time.Hour)},
+		{EventID: 2, Type: domain.EventAssigned, ReviewerID: &u1, Source: "AUTO", At: created.Add(time.Hour)},
+		{EventID: 3, Type: domain.EventUnassigned, ReviewerID: &u1, Source: "REASSIGN", At: created.Add(2 * time.Hour)},
+		{EventID: 4, Type: domain.EventAssigned, ReviewerID: &u2, Source: "REASSIGN", At: created.Add(2 * time.Hour)},
+		{EventID: 5, Type: domain.EventMerged, Source: "STATUS_CHANGE", Status: domain.PRStatusMerged, At: mergedAt},
+	}, timeline.Timeline)
+}
+
+func TestService_GetPullRequestTimelineAddsMergeWithoutEvent(t *testing.T) {
+	t.Parallel()
+	created := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	mergedAt := created.Add(time.Hour)
+	fake := &fakeRepo{
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{ID: prID, Status: domain.PRStatusMerged, CreatedAt: created, MergedAt: &mergedAt}, nil
+		},
+		listEventsFn: func(ctx context.Context, filter domain.EventFilter) ([]domain.AssignmentEvent, error) {
+			return nil, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	timeline, err := svc.GetPullRequestTimeline(context.Background(), "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, []domain.TimelineEntry{
+		{Type: domain.TimelineCreated, Status: domain.PRStatusOpen, At: created},
+		{Type: domain.EventMerged, Status: domain.PRStatusMerged, At: mergedAt},
+	}, timeline.Timeline)
+}
+
+func TestService_GetPullRequestTimelineNotFound(t *testing.T) {
+	t.Parallel()
+	fake := &fakeRepo{
+		getPullRequestFn: func(ctx context.Context, prID string) (domain.PullRequest, error) {
+			return domain.PullRequest{}, domain.ErrPRNotFound
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	_, err := svc.GetPullRequestTimeline(context.Background(), "missing")
+	require.ErrorIs(t, err, domain.ErrPRNotFound)
+}
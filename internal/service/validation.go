@@ -2,9 +2,35 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
+	"slices"
 	"strings"
+
+	"pr-reviewer-service_Avito/internal/domain"
 )
 
+// MaxRequiredReviewers ограничивает количество ревьюверов, которое можно задать команде.
+const MaxRequiredReviewers = 10
+
+// MaxWebhookDeliveriesLimit ограничивает размер страницы журнала доставок webhook'ов.
+const MaxWebhookDeliveriesLimit = 500
+
+// MaxEventsLimit ограничивает размер страницы журнала назначений.
+const MaxEventsLimit = 1000
+
+// MaxReviewAssignmentsLimit ограничивает размер страницы PR ревьювера.
+const MaxReviewAssignmentsLimit = 500
+
+// MaxPullRequestsLimit ограничивает размер страницы списка PR.
+const MaxPullRequestsLimit = 500
+
+// MaxPullRequestQueryLength ограничивает длину подстроки поиска по названию PR.
+const MaxPullRequestQueryLength = 200
+
+// MaxReviewSLAHours ограничивает SLA на ревью, которое можно задать команде (30 дней).
+const MaxReviewSLAHours = 720
+
 var (
 	// ErrInvalidInput ошибка валидации входных данных
 	ErrInvalidInput = errors.New("invalid input")
@@ -57,3 +83,256 @@ func ValidatePRName(name string) error {
 	}
 	return nil
 }
+
+// ValidateRequiredReviewers проверяет количество ревьюверов, заданное для команды.
+// Значение 0 означает использование значения из конфигурации.
+func ValidateRequiredReviewers(n int) error {
+	if n < 0 {
+		return errors.New("required reviewers cannot be negative")
+	}
+	if n > MaxRequiredReviewers {
+		return fmt.Errorf("required reviewers too large (max %d)", MaxRequiredReviewers)
+	}
+	return nil
+}
+
+// ValidateFallbackTeams проверяет список резервных команд: имена корректны,
+// не повторяются и не совпадают с самой командой.
+func ValidateFallbackTeams(teamName string, fallbackTeams []string) error {
+	seen := make(map[string]struct{}, len(fallbackTeams))
+	for _, name := range fallbackTeams {
+		if err := ValidateTeamName(name); err != nil {
+			return err
+		}
+		if name == teamName {
+			return errors.New("team cannot be its own fallback")
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("duplicate fallback team %q", name)
+		}
+		seen[name] = struct{}{}
+	}
+	return nil
+}
+
+// ValidateMaxOpenReviews проверяет лимит открытых ревью.
+// Значение 0 означает использование значения по умолчанию.
+func ValidateMaxOpenReviews(n int) error {
+	if n < 0 {
+		return errors.New("max open reviews cannot be negative")
+	}
+	return nil
+}
+
+// ValidateReviewDecision проверяет, что решение ревьювера входит в допустимый набор.
+func ValidateReviewDecision(decision domain.ReviewDecision) error {
+	switch decision {
+	case domain.ReviewDecisionApproved, domain.ReviewDecisionChangesRequested, domain.ReviewDecisionCommented:
+		return nil
+	default:
+		return fmt.Errorf("unknown review decision %q", decision)
+	}
+}
+
+// ValidateRole проверяет роль пользователя. Пустая строка означает отсутствие роли.
+func ValidateRole(role string) error {
+	if role != strings.TrimSpace(role) {
+		return errors.New("role cannot have leading or trailing spaces")
+	}
+	if len(role) > 100 {
+		return errors.New("role too long (max 100 characters)")
+	}
+	return nil
+}
+
+// ValidateMergePolicyUpdate проверяет изменяемые поля политики merge.
+func ValidateMergePolicyUpdate(update MergePolicyUpdate) error {
+	if update.RequiredApprovals != nil {
+		if *update.RequiredApprovals < 0 {
+			return errors.New("required approvals cannot be negative")
+		}
+		if *update.RequiredApprovals > MaxRequiredReviewers {
+			return fmt.Errorf("required approvals too large (max %d)", MaxRequiredReviewers)
+		}
+	}
+	if update.RequiredRole != nil {
+		return ValidateRole(*update.RequiredRole)
+	}
+	return nil
+}
+
+// ValidateReviewSLAUpdate проверяет изменяемые поля SLA на ревью.
+func ValidateReviewSLAUpdate(update ReviewSLAUpdate) error {
+	if update.Hours != nil {
+		if *update.Hours < 0 {
+			return errors.New("review SLA hours cannot be negative")
+		}
+		if *update.Hours > MaxReviewSLAHours {
+			return fmt.Errorf("review SLA too large (max %d hours)", MaxReviewSLAHours)
+		}
+	}
+	if update.Escalation != nil {
+		switch *update.Escalation {
+		case "", domain.SLAEscalationNotify, domain.SLAEscalationReassign, domain.SLAEscalationAddReviewer:
+		default:
+			return fmt.Errorf("unknown SLA escalation %q", *update.Escalation)
+		}
+	}
+	return nil
+}
+
+// ValidateWebhookURL проверяет, что URL подписки — абсолютный http(s)-адрес.
+func ValidateWebhookURL(rawURL string) error {
+	if len(rawURL) > 2000 {
+		return errors.New("webhook URL too long (max 2000 characters)")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.New("webhook URL must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// ValidateEventTypes проверяет, что все типы событий известны.
+func ValidateEventTypes(eventTypes []domain.EventType) error {
+	for _, eventType := range eventTypes {
+		if !slices.Contains(domain.EventTypes, eventType) {
+			return fmt.Errorf("unknown event type %q", eventType)
+		}
+	}
+	return nil
+}
+
+// ValidateWebhookTeams проверяет имена команд фильтра подписки.
+func ValidateWebhookTeams(teams []string) error {
+	for _, team := range teams {
+		if err := ValidateTeamName(team); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateWebhookDeliveryFilter проверяет фильтр журнала доставок.
+func ValidateWebhookDeliveryFilter(filter domain.WebhookDeliveryFilter) error {
+	if filter.WebhookID < 0 {
+		return errors.New("webhook ID cannot be negative")
+	}
+	switch filter.Status {
+	case "", domain.WebhookDeliveryPending, domain.WebhookDeliveryDelivered, domain.WebhookDeliveryFailed:
+	default:
+		return fmt.Errorf("unknown delivery status %q", filter.Status)
+	}
+	if filter.Limit < 0 || filter.Limit > MaxWebhookDeliveriesLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxWebhookDeliveriesLimit)
+	}
+	return nil
+}
+
+// ValidateEventFilter проверяет фильтр журнала назначений.
+func ValidateEventFilter(filter domain.EventFilter) error {
+	if filter.EventType != "" {
+		if err := ValidateEventTypes([]domain.EventType{filter.EventType}); err != nil {
+			return err
+		}
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return errors.New("from must be before to")
+	}
+	if filter.AfterID < 0 {
+		return errors.New("cursor cannot be negative")
+	}
+	if filter.Limit < 0 || filter.Limit > MaxEventsLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxEventsLimit)
+	}
+	return nil
+}
+
+// ValidateReviewAssignmentFilter проверяет фильтр PR ревьювера.
+func ValidateReviewAssignmentFilter(filter domain.ReviewAssignmentFilter) error {
+	if err := ValidateUserID(filter.UserID); err != nil {
+		return err
+	}
+	if err := ValidatePRStatuses(filter.Statuses); err != nil {
+		return err
+	}
+	if filter.AfterCreatedAt != nil && filter.AfterID == "" {
+		return errors.New("cursor must include pull request ID")
+	}
+	if filter.Limit < 0 || filter.Limit > MaxReviewAssignmentsLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxReviewAssignmentsLimit)
+	}
+	return nil
+}
+
+// ValidateStatsFilter проверяет фильтр статистики назначений.
+func ValidateStatsFilter(filter domain.StatsFilter) error {
+	switch filter.Status {
+	case "", domain.PRStatusDraft, domain.PRStatusOpen, domain.PRStatusMerged, domain.PRStatusClosed:
+	default:
+		return fmt.Errorf("unknown pull request status %q", filter.Status)
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return errors.New("from must be before to")
+	}
+	return nil
+}
+
+// ValidateLatencyFilter проверяет фильтр задержек ревью.
+func ValidateLatencyFilter(filter domain.LatencyFilter) error {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return errors.New("from must be before to")
+	}
+	return nil
+}
+
+// ValidateFairnessFilter проверяет фильтр отчёта о равномерности назначений.
+func ValidateFairnessFilter(filter domain.FairnessFilter) error {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return errors.New("from must be before to")
+	}
+	return nil
+}
+
+// ValidatePRStatuses проверяет, что все статусы PR известны.
+func ValidatePRStatuses(statuses []domain.PRStatus) error {
+	for _, status := range statuses {
+		switch status {
+		case domain.PRStatusDraft, domain.PRStatusOpen, domain.PRStatusMerged, domain.PRStatusClosed:
+		default:
+			return fmt.Errorf("unknown pull request status %q", status)
+		}
+	}
+	return nil
+}
+
+// ValidatePullRequestFilter проверяет фильтр списка PR. Курсор должен быть выдан для той же сортировки.
+func ValidatePullRequestFilter(filter domain.PullRequestFilter) error {
+	if err := ValidatePRStatuses(filter.Statuses); err != nil {
+		return err
+	}
+	if filter.ReviewerID != "" && filter.WithoutReviewers {
+		return errors.New("reviewer_id conflicts with without_reviewers")
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return errors.New("created_from must be before created_to")
+	}
+	if filter.MergedFrom != nil && filter.MergedTo != nil && !filter.MergedFrom.Before(*filter.MergedTo) {
+		return errors.New("merged_from must be before merged_to")
+	}
+	if len([]rune(filter.Query)) > MaxPullRequestQueryLength {
+		return fmt.Errorf("query too long (max %d characters)", MaxPullRequestQueryLength)
+	}
+	switch filter.Sort {
+	case domain.PullRequestSortCreatedAt, domain.PullRequestSortName:
+	default:
+		return fmt.Errorf("unknown sort field %q", filter.Sort)
+	}
+	if filter.After != nil && (filter.After.Sort != filter.Sort || filter.After.Desc != filter.Desc) {
+		return errors.New("cursor was issued for a different sort")
+	}
+	if filter.Limit < 0 || filter.Limit > MaxPullRequestsLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxPullRequestsLimit)
+	}
+	return nil
+}
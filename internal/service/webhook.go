@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/infrastructure/webhook"
+	"pr-reviewer-service_Avito/internal/metrics"
+)
+
+// DefaultWebhookDeliveriesLimit — размер страницы журнала доставок, если limit не задан.
+const DefaultWebhookDeliveriesLimit = 50
+
+// WebhookUpdate описывает изменяемые поля подписки; nil означает «не менять».
+type WebhookUpdate struct {
+	URL        *string
+	Secret     *string
+	EventTypes *[]domain.EventType
+	Teams      *[]string
+	IsActive   *bool
+}
+
+// WebhookReport описывает результат одного прохода доставки webhook'ов.
+type WebhookReport struct {
+	Delivered int // Доставки, получившие ответ 2xx
+	Retried   int // Неудачные попытки, которые будут повторены
+	Failed    int // Доставки, исчерпавшие попытки
+}
+
+// CreateWebhook создаёт подписку на события журнала назначений. Если секрет не задан, он генерируется;
+// секрет возвращается только в ответе на создание.
+func (s *Service) CreateWebhook(ctx context.Context, hook domain.Webhook) (domain.Webhook, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := validateWebhook(hook); err != nil {
+		return domain.Webhook{}, err
+	}
+	if hook.Secret == "" {
+		secret, err := generateSecretToken()
+		if err != nil {
+			return domain.Webhook{}, err
+		}
+		hook.Secret = secret
+	}
+	return s.repo.CreateWebhook(ctx, hook)
+}
+
+// ListWebhooks возвращает все подписки без секретов.
+func (s *Service) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+	return s.repo.ListWebhooks(ctx)
+}
+
+// UpdateWebhook частично обновляет подписку.
+func (s *Service) UpdateWebhook(ctx context.Context, id int64, update WebhookUpdate) (domain.Webhook, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	var updated domain.Webhook
+	err := s.trMgr.Do(ctx, func(ctx context.Context) error {
+		hook, err := s.repo.GetWebhook(ctx, id)
+		if err != nil {
+			return err
+		}
+		if update.URL != nil {
+			hook.URL = *update.URL
+		}
+		if update.Secret != nil {
+			hook.Secret = *update.Secret
+		}
+		if update.EventTypes != nil {
+			hook.EventTypes = *update.EventTypes
+		}
+		if update.Teams != nil {
+			hook.Teams = *update.Teams
+		}
+		if update.IsActive != nil {
+			hook.IsActive = *update.IsActive
+		}
+		if err := validateWebhook(hook); err != nil {
+			return err
+		}
+		updated, err = s.repo.UpdateWebhook(ctx, hook)
+		return err
+	})
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	updated.Secret = ""
+	return updated, nil
+}
+
+// DeleteWebhook удаляет подписку и её журнал доставок.
+func (s *Service) DeleteWebhook(ctx context.Context, id int64) error {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+	return s.repo.DeleteWebhook(ctx, id)
+}
+
+// ListWebhookDeliveries возвращает журнал доставок, начиная с последних.
+func (s *Service) ListWebhookDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+
+	if err := ValidateWebhookDeliveryFilter(filter); err != nil {
+		return nil, err
+	}
+	if filter.Limit == 0 {
+		filter.Limit = DefaultWebhookDeliveriesLimit
+	}
+	return s.repo.ListWebhookDeliveries(ctx, filter)
+}
+
+// RedeliverWebhook ставит доставку в очередь заново: попытки начинаются сначала
+// при ближайшем проходе фоновой доставки, независимо от текущего статуса.
+func (s *Service) RedeliverWebhook(ctx context.Context, deliveryID int64) (domain.WebhookDelivery, error) {
+	ctx, cancel := s.shortOperationContext(ctx)
+	defer cancel()
+	return s.repo.RedeliverWebhookDelivery(ctx, deliveryID, time.Now())
+}
+
+// DeliverWebhooks выполняет один проход доставки: отправляет доставки, которым пора выполнить попытку.
+// Доставки создаются в транзакции, записывающей событие журнала. Неудачная попытка откладывается с экспоненциальной
+// задержкой; после MaxAttempts попыток доставка помечается FAILED.
+func (s *Service) DeliverWebhooks(ctx context.Context, sender webhook.Sender, now time.Time) (WebhookReport, error) {
+	ctx, cancel := s.longOperationContext(ctx)
+	defer cancel()
+
+	cfg := s.cfg.Webhooks
+	var report WebhookReport
+	// Аренда не даёт другим экземплярам взять доставку, пока идёт проход
+	deliveries, err := s.repo.ClaimWebhookDeliveries(ctx, now, s.cfg.Timeouts.LongOperation, cfg.BatchSize)
+	if err != nil {
+		return report, err
+	}
+	for _, delivery := range deliveries {
+		code, sendErr := sender.Send(ctx, webhook.Request{
+			URL:        delivery.URL,
+			Secret:     delivery.Secret,
+			EventType:  string(delivery.EventType),
+			DeliveryID: delivery.ID,
+			Body:       delivery.Payload,
+		})
+		s.recordWebhookAttempt(&delivery, code, sendErr, time.Now())
+		if err := s.repo.SaveWebhookAttempt(ctx, delivery); err != nil {
+			return report, err
+		}
+		metrics.IncWebhookAttempts(delivery.Status)
+		switch {
+		case delivery.Status == domain.WebhookDeliveryDelivered:
+			report.Delivered++
+		case delivery.Status == domain.WebhookDeliveryFailed:
+			slog.WarnContext(ctx, "webhook delivery failed",
+				"delivery_id", delivery.ID, "webhook_id", delivery.WebhookID, "attempts", delivery.Attempts)
+			report.Failed++
+		default:
+			report.Retried++
+		}
+	}
+	return report, nil
+}
+
+// recordWebhookAttempt переносит результат попытки в delivery и назначает следующую попытку.
+func (s *Service) recordWebhookAttempt(delivery *domain.WebhookDelivery, code int, sendErr error, now time.Time) {
+	delivery.Attempts++
+	delivery.LastStatusCode = nil
+	delivery.LastError = nil
+	if sendErr == nil {
+		delivery.LastStatusCode = &code
+		if code >= 200 && code < 300 {
+			delivery.Status = domain.WebhookDeliveryDelivered
+			delivery.DeliveredAt = &now
+			return
+		}
+		msg := "unexpected status " + strconv.Itoa(code)
+		delivery.LastError = &msg
+	} else {
+		msg := sendErr.Error()
+		delivery.LastError = &msg
+	}
+	if delivery.Attempts >= s.cfg.Webhooks.MaxAttempts {
+		delivery.Status = domain.WebhookDeliveryFailed
+		return
+	}
+	delivery.Status = domain.WebhookDeliveryPending
+	delivery.NextAttemptAt = now.Add(s.webhookBackoff(delivery.Attempts))
+}
+
+// webhookBackoff возвращает задержку перед попыткой после attempts неудачных: base·2^(attempts-1), не больше max.
+func (s *Service) webhookBackoff(attempts int) time.Duration {
+	cfg := s.cfg.Webhooks
+	delay := cfg.BackoffBase
+	for i := 1; i < attempts && delay < cfg.BackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, cfg.BackoffMax)
+}
+
+// RunWebhookWorker доставляет webhook'и каждые PollInterval, пока не отменён ctx.
+func (s *Service) RunWebhookWorker(ctx context.Context, sender webhook.Sender) {
+	ticker := time.NewTicker(s.cfg.Webhooks.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			report, err := s.DeliverWebhooks(ctx, sender, now)
+			if err != nil {
+				slog.ErrorContext(ctx, "webhook delivery pass failed", "error", err)
+				continue
+			}
+			if report != (WebhookReport{}) {
+				slog.DebugContext(ctx, "webhook delivery pass finished",
+					"delivered", report.Delivered,
+					"retried", report.Retried, "failed", report.Failed)
+			}
+		}
+	}
+}
+
+func validateWebhook(hook domain.Webhook) error {
+	if err := ValidateWebhookURL(hook.URL); err != nil {
+		return err
+	}
+	if err := ValidateEventTypes(hook.EventTypes); err != nil {
+		return err
+	}
+	return ValidateWebhookTeams(hook.Teams)
+}
+
+// generateSecretToken возвращает случайный 32-байтовый токен в hex (ключ подписи webhook, токен аренды outbox).
+func generateSecretToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer-service_Avito/internal/config"
+	"pr-reviewer-service_Avito/internal/domain"
+	"pr-reviewer-service_Avito/internal/infrastructure/webhook"
+)
+
+type stubSender struct {
+	responses map[int64]int // delivery_id -> HTTP-код; отсутствие — ошибка соединения
+	requests  []webhook.Request
+}
+
+func (s *stubSender) Send(ctx context.Context, req webhook.Request) (int, error) {
+	s.requests = append(s.requests, req)
+	code, ok := s.responses[req.DeliveryID]
+	if !ok {
+		return 0, errors.New("connection refused")
+	}
+	return code, nil
+}
+
+func webhookTestConfig() config.Config {
+	cfg := testConfig()
+	cfg.Webhooks = config.WebhooksConfig{
+		MaxAttempts: 3,
+		BackoffBase: 10 * time.Second,
+		BackoffMax:  15 * time.Second,
+		BatchSize:   10,
+	}
+	return cfg
+}
+
+func TestService_CreateWebhookGeneratesSecret(t *testing.T) {
+	t.Parallel()
+	fake := &fakeRepo{
+		createWebhookFn: func(ctx context.Context, hook domain.Webhook) (domain.Webhook, error) {
+			require.Len(t, hook.Secret, 64)
+			hook.ID = 1
+			return hook, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	hook, err := svc.CreateWebhook(context.Background(), domain.Webhook{
+		URL:        "https://example.com/hook",
+		EventTypes: []domain.EventType{domain.EventAssigned},
+		IsActive:   true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), hook.ID)
+	require.NotEmpty(t, hook.Secret)
+}
+
+func TestService_CreateWebhookValidates(t *testing.T) {
+	t.Parallel()
+	svc := New(&fakeRepo{}, testConfig(), stubManager{}, stubRandomizer{})
+
+	_, err := svc.CreateWebhook(context.Background(), domain.Webhook{URL: "ftp://example.com"})
+	require.Error(t, err)
+	_, err = svc.CreateWebhook(context.Background(), domain.Webhook{
+		URL:        "https://example.com",
+		EventTypes: []domain.EventType{"PUSHED"},
+	})
+	require.Error(t, err)
+}
+
+func TestService_UpdateWebhookKeepsUnchangedFields(t *testing.T) {
+	t.Parallel()
+	fake := &fakeRepo{
+		getWebhookFn: func(ctx context.Context, id int64) (domain.Webhook, error) {
+			return domain.Webhook{ID: id, URL: "https://example.com", Teams: []string{"backend"}, IsActive: true}, nil
+		},
+		updateWebhookFn: func(ctx context.Context, hook domain.Webhook) (domain.Webhook, error) {
+			require.Equal(t, "https://example.com", hook.URL)
+			require.Equal(t, []string{"backend"}, hook.Teams)
+			require.False(t, hook.IsActive)
+			require.Equal(t, "rotated", hook.Secret)
+			return hook, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	inactive, secret := false, "rotated"
+	hook, err := svc.UpdateWebhook(context.Background(), 7, WebhookUpdate{IsActive: &inactive, Secret: &secret})
+	require.NoError(t, err)
+	require.Empty(t, hook.Secret)
+}
+
+func TestService_ListWebhookDeliveriesDefaultsLimit(t *testing.T) {
+	t.Parallel()
+	fake := &fakeRepo{
+		listWebhookDeliveriesFn: func(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+			require.Equal(t, DefaultWebhookDeliveriesLimit, filter.Limit)
+			return nil, nil
+		},
+	}
+	svc := New(fake, testConfig(), stubManager{}, stubRandomizer{})
+
+	_, err := svc.ListWebhookDeliveries(context.Background(), domain.WebhookDeliveryFilter{WebhookID: 1})
+	require.NoError(t, err)
+	_, err = svc.ListWebhookDeliveries(context.Background(), domain.WebhookDeliveryFilter{Status: "LOST"})
+	require.Error(t, err)
+}
+
+func TestService_DeliverWebhooks(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	saved := map[int64]domain.WebhookDelivery{}
+	fake := &fakeRepo{
+		claimWebhookDeliveriesFn: func(ctx context.Context, at time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+			require.Equal(t, now, at)
+			return []domain.WebhookDelivery{
+				{ID: 1, EventType: domain.EventAssigned, Payload: []byte(`{"event_id":1}`), URL: "https://a", Secret: "s"},
+				{ID: 2, Attempts: 1, URL: "https://b"},
+				{ID: 3, Attempts: 2, URL: "https://c"},
+				{ID: 4, Attempts: 0, URL: "https://d"},
+			}, nil
+		},
+		saveWebhookAttemptFn: func(ctx context.Context, delivery domain.WebhookDelivery) error {
+			saved[delivery.ID] = delivery
+			return nil
+		},
+	}
+	sender := &stubSender{responses: map[int64]int{1: http.StatusOK, 2: http.StatusInternalServerError, 3: http.StatusBadGateway}}
+	svc := New(fake, webhookTestConfig(), stubManager{}, stubRandomizer{})
+
+	report, err := svc.DeliverWebhooks(context.Background(), sender, now)
+	require.NoError(t, err)
+	require.Equal(t, WebhookReport{Delivered: 1, Retried: 2, Failed: 1}, report)
+
+	require.Equal(t, "ASSIGNED", sender.requests[0].EventType)
+	require.Equal(t, "s", sender.requests[0].Secret)
+
+	require.Equal(t, domain.WebhookDeliveryDelivered, saved[1].Status)
+	require.NotNil(t, saved[1].DeliveredAt)
+
+	// Вторая неудачная попытка: задержка удваивается, но не превышает BackoffMax
+	require.Equal(t, domain.WebhookDeliveryPending, saved[2].Status)
+	require.Equal(t, 2, saved[2].Attempts)
+	require.Equal(t, http.StatusInternalServerError, *saved[2].LastStatusCode)
+	require.WithinDuration(t, time.Now().Add(15*time.Second), saved[2].NextAttemptAt, time.Second)
+
+	require.Equal(t, domain.WebhookDeliveryFailed, saved[3].Status)
+
+	require.Equal(t, domain.WebhookDeliveryPending, saved[4].Status)
+	require.Nil(t, saved[4].LastStatusCode)
+	require.Equal(t, "connection refused", *saved[4].LastError)
+	require.WithinDuration(t, time.Now().Add(10*time.Second), saved[4].NextAttemptAt, time.Second)
+}
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Стратегия выбора ревьюверов, заданная для команды через API.
+-- NULL означает, что используется значение из конфигурации сервиса.
+-- Допустимые значения проверяются сервисом, так как стратегии можно регистрировать дополнительно.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS reviewer_strategy TEXT;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Количество ревьюверов, назначаемых на PR команды.
+-- NULL означает, что используется значение из конфигурации сервиса.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS required_reviewers INT CHECK (required_reviewers > 0);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Резервные команды, из которых назначаются ревьюверы, если в команде нет активных кандидатов.
+-- Порядок элементов задаёт порядок обхода. NULL означает значение из конфигурации сервиса.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS fallback_teams TEXT[];
+
+-- Признак того, что ревьювер на момент назначения был не из команды автора PR.
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS from_fallback BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Максимальное количество открытых ревью пользователя. NULL означает значение по умолчанию команды.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_open_reviews INT CHECK (max_open_reviews > 0);
+
+-- Значение max_open_reviews по умолчанию для участников команды.
+-- NULL означает значение из конфигурации сервиса (по умолчанию без ограничения).
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS max_open_reviews INT CHECK (max_open_reviews > 0);
+
+COMMIT;
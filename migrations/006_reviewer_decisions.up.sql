@@ -0,0 +1,13 @@
+BEGIN;
+
+-- Последнее решение ревьювера по PR и время его вынесения. NULL означает, что решения ещё нет.
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN IF NOT EXISTS decision TEXT CHECK (decision IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    ADD COLUMN IF NOT EXISTS decided_at TIMESTAMPTZ;
+
+-- Решения ревьюверов также попадают в журнал событий.
+ALTER TABLE review_assignment_events DROP CONSTRAINT IF EXISTS review_assignment_events_event_type_check;
+ALTER TABLE review_assignment_events ADD CONSTRAINT review_assignment_events_event_type_check
+    CHECK (event_type IN ('ASSIGNED', 'UNASSIGNED', 'APPROVED', 'CHANGES_REQUESTED', 'COMMENTED'));
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+-- Роль пользователя (например, lead или security), используемая политикой merge.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role TEXT;
+
+-- Политика merge для PR авторов команды. NULL означает значение из конфигурации сервиса.
+-- Количество одобрений, необходимое для merge.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS merge_required_approvals INT CHECK (merge_required_approvals > 0);
+-- Запрещать merge, пока у кого-то из ревьюверов последнее решение CHANGES_REQUESTED.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS merge_block_on_changes_requested BOOLEAN;
+-- Роль, одобрение от обладателя которой обязательно.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS merge_required_role TEXT;
+
+COMMIT;
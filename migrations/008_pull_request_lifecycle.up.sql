@@ -0,0 +1,18 @@
+BEGIN;
+
+-- Черновики (DRAFT) и закрытые без merge PR (CLOSED).
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_status_check
+    CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED'));
+
+-- Смены статуса PR записываются в журнал событий и не относятся к конкретному ревьюверу.
+ALTER TABLE review_assignment_events ALTER COLUMN reviewer_id DROP NOT NULL;
+ALTER TABLE review_assignment_events DROP CONSTRAINT IF EXISTS review_assignment_events_event_type_check;
+ALTER TABLE review_assignment_events ADD CONSTRAINT review_assignment_events_event_type_check
+    CHECK (event_type IN (
+        'ASSIGNED', 'UNASSIGNED',
+        'APPROVED', 'CHANGES_REQUESTED', 'COMMENTED',
+        'READY_FOR_REVIEW', 'MERGED', 'CLOSED', 'REOPENED'
+    ));
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+-- SLA на ревью PR авторов команды. NULL означает значение из конфигурации сервиса.
+-- Время в часах, за которое назначенный ревьювер должен вынести решение.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS review_sla_hours INT CHECK (review_sla_hours > 0);
+-- Действие при нарушении SLA.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS review_sla_escalation TEXT
+    CHECK (review_sla_escalation IN ('NOTIFY', 'REASSIGN', 'ADD_REVIEWER'));
+
+-- Нарушение SLA фиксируется в журнале событий для просроченного ревьювера.
+ALTER TABLE review_assignment_events DROP CONSTRAINT IF EXISTS review_assignment_events_event_type_check;
+ALTER TABLE review_assignment_events ADD CONSTRAINT review_assignment_events_event_type_check
+    CHECK (event_type IN (
+        'ASSIGNED', 'UNASSIGNED',
+        'APPROVED', 'CHANGES_REQUESTED', 'COMMENTED',
+        'READY_FOR_REVIEW', 'MERGED', 'CLOSED', 'REOPENED',
+        'SLA_BREACHED'
+    ));
+
+-- Поиск назначений без решения, ожидающих дольше SLA.
+CREATE INDEX IF NOT EXISTS idx_reviewers_pending ON pull_request_reviewers(assigned_at) WHERE decision IS NULL;
+
+COMMIT;
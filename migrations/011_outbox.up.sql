@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Transactional outbox: доменные события записываются в одной транзакции с изменением состояния
+-- и публикуются relay'ем в порядке id.
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    -- team, user или pull_request
+    aggregate_type TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- NULL, пока событие не принято всеми sink'ами
+    published_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_unpublished ON outbox(id) WHERE published_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- Входящие webhook'и GitHub, уже принятые в обработку. GitHub повторяет доставку с тем же
+-- X-GitHub-Delivery, поэтому повтор распознаётся по первичному ключу.
+CREATE TABLE IF NOT EXISTS github_deliveries (
+    delivery_id TEXT PRIMARY KEY,
+    event TEXT NOT NULL,
+    action TEXT NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Выборки журнала назначений по PR и по времени (/events, /pullRequest/timeline).
+CREATE INDEX IF NOT EXISTS idx_events_pull_request ON review_assignment_events(pull_request_id, id);
+CREATE INDEX IF NOT EXISTS idx_events_created_at ON review_assignment_events(created_at);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Статистика назначений за интервал и по команде (/stats/assignments).
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created_at ON pull_requests(created_at);
+CREATE INDEX IF NOT EXISTS idx_reviewers_assigned_at ON pull_request_reviewers(assigned_at);
+CREATE INDEX IF NOT EXISTS idx_users_team ON users(team_name);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Задержки ревью за интервал (/stats/latency): смерженные PR по времени merge
+-- и назначения ревьювера на PR в порядке журнала.
+CREATE INDEX IF NOT EXISTS idx_pull_requests_merged_at ON pull_requests(merged_at) WHERE merged_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_events_pull_request_reviewer ON review_assignment_events(pull_request_id, reviewer_id, id);
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+-- Список PR (/pullRequest/list): поиск подстроки в названии через триграммы
+-- и keyset-пагинация по дате создания или названию с фильтрами по статусу и автору.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_name_trgm ON pull_requests USING GIN (pull_request_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created_id ON pull_requests(created_at, pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_name_id ON pull_requests(pull_request_name, pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_status_created_id ON pull_requests(status, created_at, pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author_created_id ON pull_requests(author_id, created_at, pull_request_id);
+
+COMMIT;
@@ -0,0 +1,27 @@
+BEGIN;
+
+-- Доставки webhook'ов создаются в той же транзакции, что и событие журнала назначений, поэтому курсор
+-- подписки по id событий больше не нужен. Перед удалением курсора создаются доставки для событий,
+-- которые фоновый проход ещё не успел разобрать.
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+SELECT w.id, e.id, e.event_type, jsonb_build_object(
+    'event_id', e.id,
+    'event_type', e.event_type,
+    'pull_request_id', e.pull_request_id,
+    'reviewer_id', e.reviewer_id,
+    'source', e.source,
+    'team_name', a.team_name,
+    'created_at', e.created_at
+)
+FROM webhook_subscriptions w
+JOIN review_assignment_events e ON e.id > w.last_event_id
+JOIN pull_requests p ON p.pull_request_id=e.pull_request_id
+JOIN users a ON a.user_id=p.author_id
+WHERE w.is_active
+    AND (cardinality(w.event_types) = 0 OR e.event_type = ANY(w.event_types))
+    AND (cardinality(w.teams) = 0 OR a.team_name = ANY(w.teams))
+ON CONFLICT (subscription_id, event_id) DO NOTHING;
+
+ALTER TABLE webhook_subscriptions DROP COLUMN IF EXISTS last_event_id;
+
+COMMIT;
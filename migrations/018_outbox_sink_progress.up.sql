@@ -0,0 +1,17 @@
+BEGIN;
+
+-- Прогресс публикации outbox по приёмникам: сообщение повторно отправляется только тем приёмникам,
+-- которые его ещё не приняли. published_at заполняется, когда сообщение приняли все приёмники.
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS published_sinks TEXT[] NOT NULL DEFAULT '{}';
+
+-- Аренда relay: публикует только экземпляр, владеющий неистёкшей арендой. В отличие от advisory lock,
+-- аренда не требует держать транзакцию открытой, пока приёмники выполняют внешние вызовы.
+CREATE TABLE IF NOT EXISTS outbox_relay_lease (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    token TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+INSERT INTO outbox_relay_lease (expires_at) VALUES ('-infinity') ON CONFLICT DO NOTHING;
+
+COMMIT;